@@ -0,0 +1,58 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"context"
+	"fmt"
+
+	"arcadium.dev/core/errors"
+)
+
+// DefaultActor is the actor of a request without one, e.g. one made before
+// authentication, or by the service itself.
+const DefaultActor = "system"
+
+type (
+	actorKey struct{}
+)
+
+// WithActor returns a context carrying the id of the actor making a request,
+// as set by an authentication middleware. An asset created with the context
+// is stamped as created by the actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the given context, or DefaultActor
+// when it has none.
+func ActorFromContext(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
+
+// newCreatedBy parses the createdBy query parameter of a list request,
+// returning nil if it is not given.
+func newCreatedBy(values []string) (*string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if values[0] == "" {
+		return nil, fmt.Errorf("%w: empty createdBy query parameter", errors.ErrInvalidArgument)
+	}
+	return &values[0], nil
+}
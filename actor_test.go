@@ -0,0 +1,35 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"context"
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestActorFromContext(t *testing.T) {
+	ctx := context.Background()
+	if actor := arcade.ActorFromContext(ctx); actor != arcade.DefaultActor {
+		t.Errorf("Unexpected actor: %s", actor)
+	}
+	if actor := arcade.ActorFromContext(arcade.WithActor(ctx, "")); actor != arcade.DefaultActor {
+		t.Errorf("Unexpected actor: %s", actor)
+	}
+	if actor := arcade.ActorFromContext(arcade.WithActor(ctx, "player:42")); actor != "player:42" {
+		t.Errorf("Unexpected actor: %s", actor)
+	}
+}
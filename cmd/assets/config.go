@@ -16,8 +16,17 @@ package main
 
 import (
 	"crypto/tls"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kelseyhightower/envconfig"
 
 	"arcadium.dev/core/config"
+
+	"arcadium.dev/arcade"
+	"arcadium.dev/arcade/http"
 )
 
 type (
@@ -28,6 +37,204 @@ type (
 		TLS             TLSConfig
 		APIServer       ServerConfig
 		TelemetryServer ServerConfig
+		Assets          AssetsConfig
+	}
+
+	// AssetsConfig contains the configuration of the asset services.
+	AssetsConfig struct {
+		// MaxOffset is the largest offset allowed in a list request, zero
+		// disables the check.
+		MaxOffset int `split_words:"true"`
+
+		// ValidateItemMarkdown enables the validation of the markdown of item
+		// descriptions.
+		ValidateItemMarkdown bool `split_words:"true"`
+
+		// NotFoundForMissingFilter causes list requests filtered by an entity
+		// that does not exist to return not found instead of an empty list.
+		NotFoundForMissingFilter bool `split_words:"true"`
+
+		// DBPingInterval is the interval at which idle database connections
+		// are pinged, zero disables pinging.
+		DBPingInterval time.Duration `split_words:"true"`
+
+		// StatsInterval is the interval at which the entity counts are
+		// recorded for the stats history, zero disables recording.
+		StatsInterval time.Duration `split_words:"true"`
+
+		// DBConnectRetries is the number of times opening the database is
+		// retried at startup, waiting for it to become available, with the
+		// wait starting at DBConnectBackoff and doubling with each retry.
+		// Zero fails fast.
+		DBConnectRetries int           `split_words:"true"`
+		DBConnectBackoff time.Duration `split_words:"true" default:"1s"`
+
+		// DBApplicationName labels the database connections of the server,
+		// as the application_name of pg_stat_activity and the db_name of the
+		// connection metrics. Defaults to the name of the service.
+		DBApplicationName string `split_words:"true"`
+
+		// The default sort of each entity list, in the form "column" or
+		// "-column" for descending order. When unset, lists are sorted
+		// ascending by creation time.
+		PlayersDefaultSort arcade.Sort `split_words:"true"`
+		RoomsDefaultSort   arcade.Sort `split_words:"true"`
+		ItemsDefaultSort   arcade.Sort `split_words:"true"`
+		LinksDefaultSort   arcade.Sort `split_words:"true"`
+
+		// DefaultItemOwnerID is the owner of a created item when the request
+		// does not give one. When unset, an owner is required.
+		DefaultItemOwnerID uuid.UUID `split_words:"true"`
+
+		// CoerceNumericIDs accepts ids given as json numbers by legacy
+		// clients, converting them into strings.
+		CoerceNumericIDs bool `envconfig:"COERCE_NUMERIC_IDS"`
+
+		// RejectUnknownFields rejects a request body giving a field the
+		// request does not know. By default such a field is ignored, so
+		// clients may send fields a newer schema will understand.
+		RejectUnknownFields bool `split_words:"true"`
+
+		// URNIDs renders the ids of the responses as typed URNs, e.g.
+		// urn:arcade:item:<uuid>, unless a request asks for plain ids. Ids
+		// are accepted as URNs on input either way.
+		URNIDs bool `envconfig:"URN_IDS"`
+
+		// StrictItemLocations checks that the location of a created item is a
+		// room and its inventory is a player, at the cost of extra queries.
+		StrictItemLocations bool `split_words:"true"`
+
+		// RequireInventoryOwner rejects a created or updated item in the
+		// inventory of a player other than its owner.
+		RequireInventoryOwner bool `split_words:"true"`
+
+		// ProtectReferencedRooms refuses to remove a room referenced by items
+		// or links, unless the remove cascades.
+		ProtectReferencedRooms bool `split_words:"true"`
+
+		// NoFullTextSearch searches items using ILIKE, for versions of
+		// cockroach without full-text search support (prior to v23.1).
+		NoFullTextSearch bool `split_words:"true"`
+
+		// MaxListRows caps the rows returned by a list query. When unset,
+		// cockroach.DefaultMaxListRows is used.
+		MaxListRows int `split_words:"true"`
+
+		// EmptyListFilterMatchesNothing has an empty list filter, e.g. an
+		// empty ownerIDs query parameter, match nothing rather than be
+		// ignored.
+		EmptyListFilterMatchesNothing bool `split_words:"true"`
+
+		// RequirePlayerHome rejects a created or updated player without a
+		// home, rather than homing the player in Limbo.
+		RequirePlayerHome bool `split_words:"true"`
+
+		// ReturnExistingPlayer answers a create of a taken player name, e.g.
+		// by a concurrent create, with the existing player rather than a
+		// conflict.
+		ReturnExistingPlayer bool `split_words:"true"`
+
+		// V1DeprecationDate and V1SunsetDate, in RFC 3339 form, annotate the
+		// v1 route responses with Deprecation and Sunset headers. When the
+		// sunset date is unset, responses are not annotated.
+		V1DeprecationDate time.Time `split_words:"true"`
+		V1SunsetDate      time.Time `split_words:"true"`
+
+		// RoomsListCacheTTL is how long a room list is cached, zero disables
+		// the cache.
+		RoomsListCacheTTL time.Duration `split_words:"true"`
+
+		// ReadyCacheTTL is how long the outcome of the database ping of a
+		// readiness probe is reused by later probes, zero disables caching.
+		ReadyCacheTTL time.Duration `split_words:"true" default:"1s"`
+
+		// StrictQueryParams rejects list requests with unknown query
+		// parameters, rather than ignoring them.
+		StrictQueryParams bool `split_words:"true"`
+
+		// TimestampZone is the time zone timestamps are serialized in, by
+		// name, e.g. "America/New_York". When unset, timestamps are UTC.
+		TimestampZone Zone `split_words:"true"`
+
+		// NamePattern, when set, is a regular expression the name of a
+		// created or updated asset must match. NameBlocklist is a comma
+		// separated list of substrings a name may not contain.
+		NamePattern   Regexp   `split_words:"true"`
+		NameBlocklist []string `split_words:"true"`
+
+		// The longest name of each entity, when non-zero. One above the
+		// entity's maximum, e.g. arcade.MaxRoomNameLen, the size of its
+		// name column, is rejected rather than lowered to it.
+		PlayerMaxNameLen int `split_words:"true"`
+		RoomMaxNameLen   int `split_words:"true"`
+		LinkMaxNameLen   int `split_words:"true"`
+		ItemMaxNameLen   int `split_words:"true"`
+
+		// The entity services not to serve, so a deployment may serve a
+		// subset of them. At least one must be served.
+		DisablePlayers bool `split_words:"true"`
+		DisableRooms   bool `split_words:"true"`
+		DisableLinks   bool `split_words:"true"`
+		DisableItems   bool `split_words:"true"`
+
+		// MaxWorldEntities, when non-zero, is the most players, rooms, links
+		// and items together the world may hold. A create beyond it fails.
+		MaxWorldEntities int `split_words:"true"`
+
+		// TrailingSlash is the handling of an entity route given with a
+		// trailing slash: strict, redirect or ignore. Strict by default.
+		TrailingSlash http.TrailingSlash `split_words:"true"`
+
+		// MaxBatchSize is the most ids a bulk request may give.
+		MaxBatchSize int `split_words:"true" default:"100"`
+
+		// MaxConcurrentRequests caps the weight of the requests of each
+		// entity served at once, refusing the others with a 503 and a
+		// Retry-After of ConcurrencyRetryAfter. Zero is unlimited.
+		MaxConcurrentRequests int64         `split_words:"true"`
+		ConcurrencyRetryAfter time.Duration `split_words:"true" default:"1s"`
+
+		// StaleReads lets a get or list request given the X-Stale-Read header
+		// read slightly stale data, served by cockroach as a follower read.
+		StaleReads bool `split_words:"true"`
+
+		// StrictImmutableFields rejects an update request giving the created
+		// timestamp or a different id, rather than ignoring them.
+		StrictImmutableFields bool `split_words:"true"`
+
+		// MaxMergeDependents, when set, is the most items and links a room
+		// merge may move or redirect.
+		MaxMergeDependents int `split_words:"true"`
+
+		// MaxNearbyHops is the most links a nearby rooms request may follow.
+		MaxNearbyHops int `split_words:"true" default:"5"`
+
+		// MaxRouteHops is the most links a route between rooms may follow.
+		MaxRouteHops int `split_words:"true" default:"50"`
+
+		// RequireDescription rejects an asset created or updated with an
+		// empty description. Required by default.
+		RequireDescription bool `split_words:"true" default:"true"`
+
+		// DBTimeout, when set, is the time limit of a storage operation.
+		// DBListTimeout, DBGetTimeout, DBCreateTimeout, DBUpdateTimeout and
+		// DBRemoveTimeout override it for their operation.
+		DBTimeout       time.Duration `split_words:"true"`
+		DBListTimeout   time.Duration `split_words:"true"`
+		DBGetTimeout    time.Duration `split_words:"true"`
+		DBCreateTimeout time.Duration `split_words:"true"`
+		DBUpdateTimeout time.Duration `split_words:"true"`
+		DBRemoveTimeout time.Duration `split_words:"true"`
+	}
+
+	// Zone is a time zone read from the environment by name.
+	Zone struct {
+		*time.Location
+	}
+
+	// Regexp is a regular expression read from the environment.
+	Regexp struct {
+		*regexp.Regexp
 	}
 
 	LoggerConfig interface {
@@ -52,6 +259,27 @@ type (
 	}
 )
 
+// Decode allows a zone to be read from the environment by envconfig.
+func (z *Zone) Decode(value string) error {
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return err
+	}
+	z.Location = loc
+	return nil
+}
+
+// Decode allows a regular expression to be read from the environment by
+// envconfig.
+func (r *Regexp) Decode(value string) error {
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return err
+	}
+	r.Regexp = re
+	return nil
+}
+
 // NewConfig returns the configuration of the server.
 func NewConfig(opts ...config.Option) (Config, error) {
 	var err error
@@ -73,5 +301,21 @@ func NewConfig(opts ...config.Option) (Config, error) {
 	if c.TelemetryServer, err = config.NewServer(telemertyOpts...); err != nil {
 		return Config{}, err
 	}
+	if err = envconfig.Process("assets", &c.Assets); err != nil {
+		return Config{}, err
+	}
+	for _, limit := range []struct {
+		env      string
+		len, max int
+	}{
+		{"ASSETS_PLAYER_MAX_NAME_LEN", c.Assets.PlayerMaxNameLen, arcade.MaxPlayerNameLen},
+		{"ASSETS_ROOM_MAX_NAME_LEN", c.Assets.RoomMaxNameLen, arcade.MaxRoomNameLen},
+		{"ASSETS_LINK_MAX_NAME_LEN", c.Assets.LinkMaxNameLen, arcade.MaxLinkNameLen},
+		{"ASSETS_ITEM_MAX_NAME_LEN", c.Assets.ItemMaxNameLen, arcade.MaxItemNameLen},
+	} {
+		if limit.len < 0 || limit.len > limit.max {
+			return Config{}, fmt.Errorf("%s must be between 0 and %d: %d", limit.env, limit.max, limit.len)
+		}
+	}
 	return c, nil
 }
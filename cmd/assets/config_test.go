@@ -16,8 +16,11 @@ package main_test
 
 import (
 	"testing"
+	"time"
 
+	"arcadium.dev/arcade"
 	assets "arcadium.dev/arcade/cmd/assets"
+	"arcadium.dev/arcade/http"
 )
 
 func TestConfig(t *testing.T) {
@@ -38,6 +41,59 @@ func TestConfig(t *testing.T) {
 	t.Setenv("API_SERVER_ADDR", ":4201")
 	t.Setenv("TELEMETRY_SERVER_ADDR", ":4202")
 
+	// Assets config
+	t.Setenv("ASSETS_MAX_OFFSET", "1000")
+	t.Setenv("ASSETS_VALIDATE_ITEM_MARKDOWN", "true")
+	t.Setenv("ASSETS_NOT_FOUND_FOR_MISSING_FILTER", "true")
+	t.Setenv("ASSETS_DB_PING_INTERVAL", "30s")
+	t.Setenv("ASSETS_STATS_INTERVAL", "1h")
+	t.Setenv("ASSETS_ROOMS_DEFAULT_SORT", "name")
+	t.Setenv("ASSETS_ITEMS_DEFAULT_SORT", "-updated")
+	t.Setenv("ASSETS_DEFAULT_ITEM_OWNER_ID", "00000000-0000-0000-0000-000000000001")
+	t.Setenv("ASSETS_COERCE_NUMERIC_IDS", "true")
+	t.Setenv("ASSETS_URN_IDS", "true")
+	t.Setenv("ASSETS_STRICT_ITEM_LOCATIONS", "true")
+	t.Setenv("ASSETS_REQUIRE_INVENTORY_OWNER", "true")
+	t.Setenv("ASSETS_PROTECT_REFERENCED_ROOMS", "true")
+	t.Setenv("ASSETS_V1_DEPRECATION_DATE", "2022-06-01T00:00:00Z")
+	t.Setenv("ASSETS_V1_SUNSET_DATE", "2023-01-01T00:00:00Z")
+	t.Setenv("ASSETS_ROOMS_LIST_CACHE_TTL", "5s")
+	t.Setenv("ASSETS_STRICT_QUERY_PARAMS", "true")
+	t.Setenv("ASSETS_TIMESTAMP_ZONE", "UTC")
+	t.Setenv("ASSETS_DB_CONNECT_RETRIES", "5")
+	t.Setenv("ASSETS_DB_APPLICATION_NAME", "assets-eu")
+	t.Setenv("ASSETS_MAX_LIST_ROWS", "500")
+	t.Setenv("ASSETS_NO_FULL_TEXT_SEARCH", "true")
+	t.Setenv("ASSETS_DISABLE_LINKS", "true")
+	t.Setenv("ASSETS_MAX_WORLD_ENTITIES", "10000")
+	t.Setenv("ASSETS_REJECT_UNKNOWN_FIELDS", "true")
+	t.Setenv("ASSETS_READY_CACHE_TTL", "2s")
+	t.Setenv("ASSETS_EMPTY_LIST_FILTER_MATCHES_NOTHING", "true")
+	t.Setenv("ASSETS_REQUIRE_PLAYER_HOME", "true")
+	t.Setenv("ASSETS_RETURN_EXISTING_PLAYER", "true")
+	t.Setenv("ASSETS_NAME_PATTERN", "^[A-Z]")
+	t.Setenv("ASSETS_NAME_BLOCKLIST", "darn,heck")
+	t.Setenv("ASSETS_PLAYER_MAX_NAME_LEN", "32")
+	t.Setenv("ASSETS_ROOM_MAX_NAME_LEN", "200")
+	t.Setenv("ASSETS_LINK_MAX_NAME_LEN", "48")
+	t.Setenv("ASSETS_ITEM_MAX_NAME_LEN", "64")
+	t.Setenv("ASSETS_TRAILING_SLASH", "redirect")
+	t.Setenv("ASSETS_MAX_BATCH_SIZE", "50")
+	t.Setenv("ASSETS_MAX_CONCURRENT_REQUESTS", "8")
+	t.Setenv("ASSETS_CONCURRENCY_RETRY_AFTER", "5s")
+	t.Setenv("ASSETS_STALE_READS", "true")
+	t.Setenv("ASSETS_STRICT_IMMUTABLE_FIELDS", "true")
+	t.Setenv("ASSETS_MAX_MERGE_DEPENDENTS", "1000")
+	t.Setenv("ASSETS_MAX_NEARBY_HOPS", "3")
+	t.Setenv("ASSETS_MAX_ROUTE_HOPS", "20")
+	t.Setenv("ASSETS_REQUIRE_DESCRIPTION", "false")
+	t.Setenv("ASSETS_DB_TIMEOUT", "5s")
+	t.Setenv("ASSETS_DB_LIST_TIMEOUT", "30s")
+	t.Setenv("ASSETS_DB_GET_TIMEOUT", "1s")
+	t.Setenv("ASSETS_DB_CREATE_TIMEOUT", "2s")
+	t.Setenv("ASSETS_DB_UPDATE_TIMEOUT", "3s")
+	t.Setenv("ASSETS_DB_REMOVE_TIMEOUT", "4s")
+
 	cfg, err := assets.NewConfig()
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err)
@@ -84,4 +140,232 @@ func TestConfig(t *testing.T) {
 			t.Errorf("Unexpected server address: %s", telemetryServer.Addr())
 		}
 	})
+	t.Run("Test Assets", func(t *testing.T) {
+		a := cfg.Assets
+		if a.MaxOffset != 1000 {
+			t.Errorf("Unexpected max offset: %d", a.MaxOffset)
+		}
+		if !a.ValidateItemMarkdown {
+			t.Error("Unexpected validate item markdown")
+		}
+		if !a.NotFoundForMissingFilter {
+			t.Error("Unexpected not found for missing filter")
+		}
+		if a.DBPingInterval != 30*time.Second {
+			t.Errorf("Unexpected db ping interval: %s", a.DBPingInterval)
+		}
+		if a.StatsInterval != time.Hour {
+			t.Errorf("Unexpected stats interval: %s", a.StatsInterval)
+		}
+		if a.RoomsDefaultSort != (arcade.Sort{Column: "name"}) {
+			t.Errorf("Unexpected rooms default sort: %+v", a.RoomsDefaultSort)
+		}
+		if a.ItemsDefaultSort != (arcade.Sort{Column: "updated", Desc: true}) {
+			t.Errorf("Unexpected items default sort: %+v", a.ItemsDefaultSort)
+		}
+		if a.PlayersDefaultSort != (arcade.Sort{}) {
+			t.Errorf("Unexpected players default sort: %+v", a.PlayersDefaultSort)
+		}
+		if a.DefaultItemOwnerID.String() != "00000000-0000-0000-0000-000000000001" {
+			t.Errorf("Unexpected default item owner id: %s", a.DefaultItemOwnerID)
+		}
+		if !a.URNIDs {
+			t.Error("Unexpected urn ids")
+		}
+		if !a.CoerceNumericIDs {
+			t.Error("Unexpected coerce numeric ids")
+		}
+		if !a.StrictItemLocations {
+			t.Error("Unexpected strict item locations")
+		}
+		if !a.RequireInventoryOwner {
+			t.Error("Unexpected require inventory owner")
+		}
+		if !a.ProtectReferencedRooms {
+			t.Error("Unexpected protect referenced rooms")
+		}
+		if !a.V1DeprecationDate.Equal(time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected v1 deprecation date: %s", a.V1DeprecationDate)
+		}
+		if !a.V1SunsetDate.Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected v1 sunset date: %s", a.V1SunsetDate)
+		}
+		if a.RoomsListCacheTTL != 5*time.Second {
+			t.Errorf("Unexpected rooms list cache ttl: %s", a.RoomsListCacheTTL)
+		}
+		if !a.StrictQueryParams {
+			t.Error("Unexpected strict query params")
+		}
+		if a.TimestampZone.Location != time.UTC {
+			t.Errorf("Unexpected timestamp zone: %s", a.TimestampZone)
+		}
+		if a.DBConnectRetries != 5 || a.DBConnectBackoff != time.Second {
+			t.Errorf("Unexpected db connect retries: %d, backoff: %s", a.DBConnectRetries, a.DBConnectBackoff)
+		}
+		if a.DBApplicationName != "assets-eu" {
+			t.Errorf("Unexpected db application name: %s", a.DBApplicationName)
+		}
+		if a.MaxListRows != 500 {
+			t.Errorf("Unexpected max list rows: %d", a.MaxListRows)
+		}
+		if !a.NoFullTextSearch {
+			t.Error("Unexpected no full text search")
+		}
+		if a.DisablePlayers || a.DisableRooms || !a.DisableLinks || a.DisableItems {
+			t.Errorf("Unexpected disabled services: %t, %t, %t, %t", a.DisablePlayers, a.DisableRooms, a.DisableLinks, a.DisableItems)
+		}
+		if a.ReadyCacheTTL != 2*time.Second {
+			t.Errorf("Unexpected ready cache ttl: %s", a.ReadyCacheTTL)
+		}
+		if !a.RejectUnknownFields {
+			t.Error("Unexpected reject unknown fields")
+		}
+		if a.MaxWorldEntities != 10000 {
+			t.Errorf("Unexpected max world entities: %d", a.MaxWorldEntities)
+		}
+		if !a.EmptyListFilterMatchesNothing {
+			t.Error("Expected empty list filters to match nothing")
+		}
+		if !a.RequirePlayerHome {
+			t.Error("Unexpected require player home")
+		}
+		if !a.ReturnExistingPlayer {
+			t.Error("Unexpected return existing player")
+		}
+		if a.NamePattern.Regexp == nil || a.NamePattern.String() != "^[A-Z]" {
+			t.Errorf("Unexpected name pattern: %v", a.NamePattern.Regexp)
+		}
+		if len(a.NameBlocklist) != 2 || a.NameBlocklist[0] != "darn" || a.NameBlocklist[1] != "heck" {
+			t.Errorf("Unexpected name blocklist: %v", a.NameBlocklist)
+		}
+		if a.PlayerMaxNameLen != 32 || a.RoomMaxNameLen != 200 || a.LinkMaxNameLen != 48 || a.ItemMaxNameLen != 64 {
+			t.Errorf("Unexpected max name lengths: %d %d %d %d", a.PlayerMaxNameLen, a.RoomMaxNameLen, a.LinkMaxNameLen, a.ItemMaxNameLen)
+		}
+		if a.TrailingSlash != http.TrailingSlashRedirect {
+			t.Errorf("Unexpected trailing slash: %d", a.TrailingSlash)
+		}
+		if a.MaxBatchSize != 50 {
+			t.Errorf("Unexpected max batch size: %d", a.MaxBatchSize)
+		}
+		if a.MaxConcurrentRequests != 8 || a.ConcurrencyRetryAfter != 5*time.Second {
+			t.Errorf("Unexpected concurrency limit: %d, %s", a.MaxConcurrentRequests, a.ConcurrencyRetryAfter)
+		}
+		if !a.StaleReads {
+			t.Error("Unexpected stale reads")
+		}
+		if !a.StrictImmutableFields {
+			t.Error("Unexpected strict immutable fields")
+		}
+		if a.MaxMergeDependents != 1000 {
+			t.Errorf("Unexpected max merge dependents: %d", a.MaxMergeDependents)
+		}
+		if a.MaxNearbyHops != 3 {
+			t.Errorf("Unexpected max nearby hops: %d", a.MaxNearbyHops)
+		}
+		if a.MaxRouteHops != 20 {
+			t.Errorf("Unexpected max route hops: %d", a.MaxRouteHops)
+		}
+		if a.RequireDescription {
+			t.Error("Unexpected require description")
+		}
+		if a.DBTimeout != 5*time.Second {
+			t.Errorf("Unexpected db timeout: %s", a.DBTimeout)
+		}
+		if a.DBListTimeout != 30*time.Second {
+			t.Errorf("Unexpected db list timeout: %s", a.DBListTimeout)
+		}
+		if a.DBGetTimeout != time.Second {
+			t.Errorf("Unexpected db get timeout: %s", a.DBGetTimeout)
+		}
+		if a.DBCreateTimeout != 2*time.Second {
+			t.Errorf("Unexpected db create timeout: %s", a.DBCreateTimeout)
+		}
+		if a.DBUpdateTimeout != 3*time.Second {
+			t.Errorf("Unexpected db update timeout: %s", a.DBUpdateTimeout)
+		}
+		if a.DBRemoveTimeout != 4*time.Second {
+			t.Errorf("Unexpected db remove timeout: %s", a.DBRemoveTimeout)
+		}
+	})
+}
+
+func TestConfigInvalidDefaultItemOwner(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "Debug")
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_DSN", "cockroachdb://arcadium@cockroah:26257/assets?sslmode=verify-full")
+	t.Setenv("TLS_CERT", "/etc/certs/cert.pem")
+	t.Setenv("TLS_KEY", "/etc/certs/key.pem")
+	t.Setenv("TLS_CACERT", "/etc/certs/rootCA.pem")
+	t.Setenv("API_SERVER_ADDR", ":4201")
+	t.Setenv("TELEMETRY_SERVER_ADDR", ":4202")
+
+	t.Setenv("ASSETS_DEFAULT_ITEM_OWNER_ID", "42")
+
+	if _, err := assets.NewConfig(); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestConfigInvalidTimestampZone(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "Debug")
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_DSN", "cockroachdb://arcadium@cockroah:26257/assets?sslmode=verify-full")
+	t.Setenv("TLS_CERT", "/etc/certs/cert.pem")
+	t.Setenv("TLS_KEY", "/etc/certs/key.pem")
+	t.Setenv("TLS_CACERT", "/etc/certs/rootCA.pem")
+	t.Setenv("API_SERVER_ADDR", ":4201")
+	t.Setenv("TELEMETRY_SERVER_ADDR", ":4202")
+
+	t.Setenv("ASSETS_TIMESTAMP_ZONE", "Nowhere/Special")
+
+	if _, err := assets.NewConfig(); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestConfigInvalidNamePattern(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "Debug")
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_DSN", "cockroachdb://arcadium@cockroah:26257/assets?sslmode=verify-full")
+	t.Setenv("TLS_CERT", "/etc/certs/cert.pem")
+	t.Setenv("TLS_KEY", "/etc/certs/key.pem")
+	t.Setenv("TLS_CACERT", "/etc/certs/rootCA.pem")
+	t.Setenv("API_SERVER_ADDR", ":4201")
+	t.Setenv("TELEMETRY_SERVER_ADDR", ":4202")
+
+	t.Setenv("ASSETS_NAME_PATTERN", "[A-Z")
+
+	if _, err := assets.NewConfig(); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestConfigInvalidMaxNameLen(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "Debug")
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_DSN", "cockroachdb://arcadium@cockroah:26257/assets?sslmode=verify-full")
+	t.Setenv("TLS_CERT", "/etc/certs/cert.pem")
+	t.Setenv("TLS_KEY", "/etc/certs/key.pem")
+	t.Setenv("TLS_CACERT", "/etc/certs/rootCA.pem")
+	t.Setenv("API_SERVER_ADDR", ":4201")
+	t.Setenv("TELEMETRY_SERVER_ADDR", ":4202")
+
+	for _, env := range []string{
+		"ASSETS_PLAYER_MAX_NAME_LEN", "ASSETS_ROOM_MAX_NAME_LEN", "ASSETS_LINK_MAX_NAME_LEN", "ASSETS_ITEM_MAX_NAME_LEN",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, "256")
+
+			_, err := assets.NewConfig()
+
+			expected := env + " must be between 0 and 255: 256"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+		})
+	}
 }
@@ -16,15 +16,22 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	l "log"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"os"
 	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 
 	"arcadium.dev/core/build"
 	"arcadium.dev/core/config"
@@ -32,6 +39,7 @@ import (
 	"arcadium.dev/core/log"
 	"arcadium.dev/core/sql"
 
+	"arcadium.dev/arcade"
 	"arcadium.dev/arcade/http"
 	"arcadium.dev/arcade/storage"
 	"arcadium.dev/arcade/storage/cockroach"
@@ -185,34 +193,228 @@ func (s *Server) Start(args []string) {
 	var start []interface{} = append([]interface{}{"msg", "starting"}, info.Fields()...)
 	s.logger.Info(start...)
 
+	// Serve at least one entity.
+	if a := s.config.Assets; a.DisablePlayers && a.DisableRooms && a.DisableLinks && a.DisableItems {
+		err = errors.New("all of the players, rooms, links and items services are disabled")
+		s.logger.Error("msg", "no services enabled", "error", err)
+		return
+	}
+
 	// Setup database.
-	s.db, err = s.Constructors.NewDB(s.config.DB, s.logger)
+	s.db, err = s.openDB(ctx)
 	if err != nil {
 		s.logger.Error("msg", "failed to open db", "error", err)
 		return
 	}
 	defer s.db.Close()
 
+	// Label the connection pool metrics with the application name.
+	dbStats := collectors.NewDBStatsCollector(s.db.DB, s.applicationName())
+	if err := prometheus.Register(dbStats); err != nil {
+		s.logger.Error("msg", "failed to register db metrics", "error", err)
+	} else {
+		defer prometheus.Unregister(dbStats)
+	}
+
+	// Keep the connection pool warm, until shutdown.
+	if s.config.Assets.DBPingInterval > 0 {
+		pingCtx, stopPing := context.WithCancel(ctx)
+		defer stopPing()
+		go storage.Ping(pingCtx, s.db.DB, s.config.Assets.DBPingInterval)
+	}
+
+	// Serialize timestamps in the configured zone.
+	if loc := s.config.Assets.TimestampZone.Location; loc != nil {
+		arcade.TimestampLocation = loc
+	}
+
+	// Permit empty descriptions, when configured.
+	arcade.RequireDescription = s.config.Assets.RequireDescription
+
 	// Setup API services.
-	s.apiServices = []chttp.Service{
-		http.PlayersService{Storage: storage.Players{DB: s.db.DB, Driver: cockroach.Driver{}}},
-		http.RoomsService{Storage: storage.Rooms{DB: s.db.DB, Driver: cockroach.Driver{}}},
-		http.LinksService{Storage: storage.Links{DB: s.db.DB, Driver: cockroach.Driver{}}},
-		http.ItemsService{Storage: storage.Items{DB: s.db.DB, Driver: cockroach.Driver{}}},
+	driver := cockroach.Driver{
+		NoFullTextSearch:              s.config.Assets.NoFullTextSearch,
+		MaxListRows:                   s.config.Assets.MaxListRows,
+		EmptyListFilterMatchesNothing: s.config.Assets.EmptyListFilterMatchesNothing,
+	}
+	names := arcade.NamePolicy{
+		Pattern:   s.config.Assets.NamePattern.Regexp,
+		Blocklist: s.config.Assets.NameBlocklist,
+	}
+	timeouts := storage.Timeouts{
+		Default: s.config.Assets.DBTimeout,
+		List:    s.config.Assets.DBListTimeout,
+		Get:     s.config.Assets.DBGetTimeout,
+		Create:  s.config.Assets.DBCreateTimeout,
+		Update:  s.config.Assets.DBUpdateTimeout,
+		Remove:  s.config.Assets.DBRemoveTimeout,
+	}
+	playerNames, roomNames, linkNames, itemNames := names, names, names, names
+	playerNames.MaxLen = s.config.Assets.PlayerMaxNameLen
+	roomNames.MaxLen = s.config.Assets.RoomMaxNameLen
+	linkNames.MaxLen = s.config.Assets.LinkMaxNameLen
+	itemNames.MaxLen = s.config.Assets.ItemMaxNameLen
+	world := storage.WorldLimit{MaxEntities: s.config.Assets.MaxWorldEntities}
+	players := storage.Players{
+		DB:             s.db.DB,
+		Driver:         driver,
+		Names:          playerNames,
+		RoomNames:      roomNames,
+		Timeouts:       timeouts,
+		World:          world,
+		RequireHome:    s.config.Assets.RequirePlayerHome,
+		ReturnExisting: s.config.Assets.ReturnExistingPlayer,
+	}
+	rooms := storage.Rooms{
+		DB:                 s.db.DB,
+		Driver:             driver,
+		Names:              roomNames,
+		Timeouts:           timeouts,
+		World:              world,
+		MaxMergeDependents: s.config.Assets.MaxMergeDependents,
+		ProtectReferenced:  s.config.Assets.ProtectReferencedRooms,
+	}
+	links := storage.Links{DB: s.db.DB, Driver: driver, Names: linkNames, Timeouts: timeouts, World: world}
+	items := storage.Items{
+		DB:                    s.db.DB,
+		Driver:                driver,
+		Names:                 itemNames,
+		Timeouts:              timeouts,
+		World:                 world,
+		ValidateMarkdown:      s.config.Assets.ValidateItemMarkdown,
+		DefaultOwnerID:        s.config.Assets.DefaultItemOwnerID,
+		StrictLocations:       s.config.Assets.StrictItemLocations,
+		RequireInventoryOwner: s.config.Assets.RequireInventoryOwner,
+	}
+	var roomsStorage arcade.RoomsStorage = rooms
+	if s.config.Assets.RoomsListCacheTTL > 0 {
+		players.RoomsCache = storage.NewRoomsCache(rooms, s.config.Assets.RoomsListCacheTTL)
+		roomsStorage = players.RoomsCache
+	}
+	// Serve the enabled entities, which alone may be resolved.
+	resolve := http.ResolveService{
+		MaxBatchSize:        s.config.Assets.MaxBatchSize,
+		TrailingSlash:       s.config.Assets.TrailingSlash,
+		RejectUnknownFields: s.config.Assets.RejectUnknownFields,
+	}
+	s.apiServices = nil
+	if !s.config.Assets.DisablePlayers {
+		resolve.Players = players
+		s.apiServices = append(s.apiServices, http.PlayersService{
+			Storage:                  players,
+			Rooms:                    rooms,
+			Items:                    items,
+			MaxOffset:                s.config.Assets.MaxOffset,
+			NotFoundForMissingFilter: s.config.Assets.NotFoundForMissingFilter,
+			DefaultSort:              s.config.Assets.PlayersDefaultSort,
+			CoerceNumericIDs:         s.config.Assets.CoerceNumericIDs,
+			RejectUnknownFields:      s.config.Assets.RejectUnknownFields,
+			StrictQueryParams:        s.config.Assets.StrictQueryParams,
+			TrailingSlash:            s.config.Assets.TrailingSlash,
+			StrictImmutableFields:    s.config.Assets.StrictImmutableFields,
+		})
+	}
+	if !s.config.Assets.DisableRooms {
+		resolve.Rooms = roomsStorage
+		s.apiServices = append(s.apiServices, http.RoomsService{
+			Storage:                  roomsStorage,
+			Players:                  players,
+			Links:                    links,
+			Items:                    items,
+			MaxOffset:                s.config.Assets.MaxOffset,
+			NotFoundForMissingFilter: s.config.Assets.NotFoundForMissingFilter,
+			DefaultSort:              s.config.Assets.RoomsDefaultSort,
+			MaxBatchSize:             s.config.Assets.MaxBatchSize,
+			MaxNearbyHops:            s.config.Assets.MaxNearbyHops,
+			MaxRouteHops:             s.config.Assets.MaxRouteHops,
+			CoerceNumericIDs:         s.config.Assets.CoerceNumericIDs,
+			RejectUnknownFields:      s.config.Assets.RejectUnknownFields,
+			StrictQueryParams:        s.config.Assets.StrictQueryParams,
+			TrailingSlash:            s.config.Assets.TrailingSlash,
+			StrictImmutableFields:    s.config.Assets.StrictImmutableFields,
+		})
+	}
+	if !s.config.Assets.DisableLinks {
+		resolve.Links = links
+		s.apiServices = append(s.apiServices, http.LinksService{
+			Storage:               links,
+			MaxOffset:             s.config.Assets.MaxOffset,
+			DefaultSort:           s.config.Assets.LinksDefaultSort,
+			CoerceNumericIDs:      s.config.Assets.CoerceNumericIDs,
+			RejectUnknownFields:   s.config.Assets.RejectUnknownFields,
+			StrictQueryParams:     s.config.Assets.StrictQueryParams,
+			TrailingSlash:         s.config.Assets.TrailingSlash,
+			StrictImmutableFields: s.config.Assets.StrictImmutableFields,
+		})
+	}
+	if !s.config.Assets.DisableItems {
+		resolve.Items = items
+		s.apiServices = append(s.apiServices, http.ItemsService{
+			Storage:               items,
+			MaxOffset:             s.config.Assets.MaxOffset,
+			DefaultSort:           s.config.Assets.ItemsDefaultSort,
+			CoerceNumericIDs:      s.config.Assets.CoerceNumericIDs,
+			RejectUnknownFields:   s.config.Assets.RejectUnknownFields,
+			StrictQueryParams:     s.config.Assets.StrictQueryParams,
+			TrailingSlash:         s.config.Assets.TrailingSlash,
+			StrictImmutableFields: s.config.Assets.StrictImmutableFields,
+			MaxBatchSize:          s.config.Assets.MaxBatchSize,
+			Names:                 itemNames,
+		})
+	}
+	s.apiServices = append(s.apiServices,
+		resolve,
+		http.ExportService{Storage: storage.Export{DB: s.db.DB, Driver: driver}},
+		http.VersionService{
+			Info: arcade.Version{Name: Name, Version: Version, Branch: Branch, Commit: Commit, Date: Date, Go: Go},
+		},
+	)
+	serviceNames := make([]string, 0, len(s.apiServices))
+	for _, service := range s.apiServices {
+		serviceNames = append(serviceNames, service.Name())
+	}
+	s.logger.Info("msg", "api services", "services", strings.Join(serviceNames, ","))
+
+	// Record the entity counts for the stats history, until shutdown.
+	stats := storage.Stats{DB: s.db.DB, Driver: driver}
+	if s.config.Assets.StatsInterval > 0 {
+		statsCtx, stopStats := context.WithCancel(ctx)
+		defer stopStats()
+		go storage.RecordStats(statsCtx, stats, s.config.Assets.StatsInterval)
 	}
 
-	// Setup telemetry services.
+	// Setup telemetry services. Rapid readiness probes reuse a recent ping.
+	var readiness http.Pinger = s.db.DB
+	if s.config.Assets.ReadyCacheTTL > 0 {
+		readiness = storage.NewPingCache(s.db.DB, s.config.Assets.ReadyCacheTTL)
+	}
 	s.telemetryServices = []chttp.Service{
-		http.HealthService{},
+		http.HealthService{DB: readiness},
 		http.MetricsService{},
+		http.MaintenanceService{Storage: storage.Maintenance{DB: s.db.DB, Driver: driver}},
+		http.StatsService{Storage: stats},
 	}
 
+	// Annotate the v1 routes with their deprecation, when a sunset is planned,
+	// and cap the concurrent requests of each entity, when configured.
+	middlewares := []mux.MiddlewareFunc{chttp.Metrics, http.IDFormat(s.config.Assets.URNIDs)}
+	if a := s.config.Assets; !a.V1SunsetDate.IsZero() {
+		middlewares = append(middlewares, http.Deprecation(a.V1DeprecationDate, a.V1SunsetDate))
+	}
+	if a := s.config.Assets; a.MaxConcurrentRequests > 0 {
+		middlewares = append(middlewares, http.ConcurrencyLimit(a.MaxConcurrentRequests, a.ConcurrencyRetryAfter, http.RequestWeight))
+	}
+	if s.config.Assets.StaleReads {
+		middlewares = append(middlewares, http.StaleReads())
+	}
+	middleware := chttp.WithMiddleware(middlewares...)
+
 	// Create ths API server.
 	s.apiServer, err = s.Constructors.NewAPIServer(
 		s.config.APIServer,
 		s.config.TLS,
 		s.logger,
-		chttp.WithMiddleware(chttp.Metrics),
+		middleware,
 	)
 	if err != nil {
 		s.logger.Error("msg", "failed to create api server", "error", err)
@@ -263,6 +465,46 @@ func (s *Server) Start(args []string) {
 	}
 }
 
+// openDB opens the database, retrying with a doubling backoff up to the
+// configured number of retries while the database is unavailable.
+func (s *Server) openDB(ctx context.Context) (*sql.DB, error) {
+	backoff := s.config.Assets.DBConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := s.Constructors.NewDB(appDBConfig{DBConfig: s.config.DB, name: s.applicationName()}, s.logger)
+		if err == nil || attempt > s.config.Assets.DBConnectRetries {
+			return db, err
+		}
+		s.logger.Info("msg", "waiting for db", "attempt", attempt, "backoff", backoff.String(), "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// applicationName returns the name labelling the database connections of the
+// server.
+func (s *Server) applicationName() string {
+	if s.config.Assets.DBApplicationName != "" {
+		return s.config.Assets.DBApplicationName
+	}
+	return Name
+}
+
+// appDBConfig sets the application name of the connections of a DBConfig.
+type appDBConfig struct {
+	DBConfig
+	name string
+}
+
+// DSN returns the DSN of the config with its application name.
+func (c appDBConfig) DSN() string {
+	return cockroach.WithApplicationName(c.DBConfig.DSN(), c.name)
+}
+
 // Stop halts the server.
 func (s *Server) Stop() {
 	s.apiWG.Wait()
@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
@@ -117,6 +118,91 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("db application name", func(t *testing.T) {
+		for _, test := range []struct {
+			appName, expected string
+		}{
+			{appName: "", expected: "postgresql://arcadium@cockroach:26257/arcade?application_name=assets"},
+			{appName: "assets-eu", expected: "postgresql://arcadium@cockroach:26257/arcade?application_name=assets-eu"},
+		} {
+			s, _ := setup()
+			s.Constructors.NewConfig = func(...cconfig.Option) (assets.Config, error) {
+				return assets.Config{
+					Logger: mockLoggerConfig{level: "debug", format: "logfmt"},
+					DB:     mockDBConfig{driver: "pgx", dsn: "postgresql://arcadium@cockroach:26257/arcade"},
+					Assets: assets.AssetsConfig{DBApplicationName: test.appName},
+				}, nil
+			}
+
+			var dsn string
+			s.Constructors.NewDB = func(cfg assets.DBConfig, logger log.Logger) (*sql.DB, error) {
+				dsn = cfg.DSN()
+				return nil, errors.New("db construction failure")
+			}
+
+			s.Start(args)
+			if dsn != test.expected {
+				t.Errorf("\nExpected dsn: %s\nActual dsn:   %s", test.expected, dsn)
+			}
+		}
+	})
+
+	t.Run("db connect retry", func(t *testing.T) {
+		s, b := setup()
+		s.Constructors.NewConfig = func(...cconfig.Option) (assets.Config, error) {
+			return assets.Config{
+				Logger: mockLoggerConfig{level: "debug", format: "logfmt"},
+				Assets: assets.AssetsConfig{DBConnectRetries: 3, DBConnectBackoff: time.Millisecond},
+			}, nil
+		}
+
+		s.Constructors.NewLogger = func(cfg assets.LoggerConfig) (log.Logger, error) {
+			return log.New(
+				log.WithLevel(log.ToLevel(cfg.Level())),
+				log.WithFormat(log.ToFormat(cfg.Format())),
+				log.WithOutput(b),
+				log.WithoutTimestamp(),
+			)
+		}
+
+		attempts := 0
+		var m sqlmock.Sqlmock
+		s.Constructors.NewDB = func(cfg assets.DBConfig, logger log.Logger) (*sql.DB, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errors.New("connection refused")
+			}
+			db, mock, err := sqlmock.New()
+			if db == nil || mock == nil || err != nil {
+				t.Fatal("Failed to create sqlmock")
+			}
+			m = mock
+			m.ExpectClose()
+			return &sql.DB{DB: db}, err
+		}
+
+		// Stop the start after the db is opened.
+		s.Constructors.NewAPIServer = func(assets.ServerConfig, assets.TLSConfig, log.Logger, ...http.ServerOption) (*http.Server, error) {
+			return nil, errors.New("api server construction failure")
+		}
+
+		s.Start(args)
+		if attempts != 2 {
+			t.Errorf("Unexpected db open attempts: %d", attempts)
+		}
+		if b.Len() != 4 {
+			t.Fatalf("Unexpected log buffer length: %d", b.Len())
+		}
+		expected := `level=info msg="waiting for db" attempt=1 backoff=1ms error="connection refused"`
+		if !strings.Contains(b.Index(1), expected) {
+			t.Errorf("\nExpected log: %s\nActual log:   %s", expected, b.Index(1))
+		}
+
+		if err := m.ExpectationsWereMet(); err != nil {
+			t.Errorf("Failed to close sqlmock: %s", err)
+		}
+	})
+
 	t.Run("api server construction failure", func(t *testing.T) {
 		s, b := setup()
 		s.Constructors.NewConfig = func(...cconfig.Option) (assets.Config, error) {
@@ -150,12 +236,16 @@ func TestServer(t *testing.T) {
 		}
 
 		s.Start(args)
-		if b.Len() != 2 {
-			t.Errorf("Unexpected error log buffer length: %d", b.Len())
+		if b.Len() != 3 {
+			t.Fatalf("Unexpected error log buffer length: %d", b.Len())
 		}
-		expected := `level=error msg="failed to create api server" error="api server construction failure"`
+		expected := `level=info msg="api services" services=players,rooms,links,items,resolve,export,version`
 		if !strings.Contains(b.Index(1), expected) {
-			t.Errorf("\nExpected error log: %s\nActual error log:   %s", expected, b.Index(1))
+			t.Errorf("\nExpected log: %s\nActual log:   %s", expected, b.Index(1))
+		}
+		expected = `level=error msg="failed to create api server" error="api server construction failure"`
+		if !strings.Contains(b.Index(2), expected) {
+			t.Errorf("\nExpected error log: %s\nActual error log:   %s", expected, b.Index(2))
 		}
 
 		if err := m.ExpectationsWereMet(); err != nil {
@@ -163,6 +253,75 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("disabled services", func(t *testing.T) {
+		s, b := setup()
+		s.Constructors.NewConfig = func(...cconfig.Option) (assets.Config, error) {
+			return assets.Config{
+				Logger: mockLoggerConfig{level: "debug", format: "logfmt"},
+				Assets: assets.AssetsConfig{DisableLinks: true, DisableItems: true},
+			}, nil
+		}
+
+		s.Constructors.NewLogger = func(cfg assets.LoggerConfig) (log.Logger, error) {
+			return log.New(
+				log.WithLevel(log.ToLevel(cfg.Level())),
+				log.WithFormat(log.ToFormat(cfg.Format())),
+				log.WithOutput(b),
+				log.WithoutTimestamp(),
+			)
+		}
+
+		s.Constructors.NewDB = func(cfg assets.DBConfig, logger log.Logger) (*sql.DB, error) {
+			db, mock, err := sqlmock.New()
+			if db == nil || mock == nil || err != nil {
+				t.Fatal("Failed to create sqlmock")
+			}
+			mock.ExpectClose()
+			return &sql.DB{DB: db}, err
+		}
+
+		s.Constructors.NewAPIServer = func(assets.ServerConfig, assets.TLSConfig, log.Logger, ...http.ServerOption) (*http.Server, error) {
+			return nil, errors.New("api server construction failure")
+		}
+
+		s.Start(args)
+		if b.Len() != 3 {
+			t.Fatalf("Unexpected log buffer length: %d", b.Len())
+		}
+		expected := `level=info msg="api services" services=players,rooms,resolve,export,version`
+		if !strings.Contains(b.Index(1), expected) {
+			t.Errorf("\nExpected log: %s\nActual log:   %s", expected, b.Index(1))
+		}
+	})
+
+	t.Run("no services enabled", func(t *testing.T) {
+		s, b := setup()
+		s.Constructors.NewConfig = func(...cconfig.Option) (assets.Config, error) {
+			return assets.Config{
+				Logger: mockLoggerConfig{level: "debug", format: "logfmt"},
+				Assets: assets.AssetsConfig{DisablePlayers: true, DisableRooms: true, DisableLinks: true, DisableItems: true},
+			}, nil
+		}
+
+		s.Constructors.NewLogger = func(cfg assets.LoggerConfig) (log.Logger, error) {
+			return log.New(
+				log.WithLevel(log.ToLevel(cfg.Level())),
+				log.WithFormat(log.ToFormat(cfg.Format())),
+				log.WithOutput(b),
+				log.WithoutTimestamp(),
+			)
+		}
+
+		s.Start(args)
+		if b.Len() != 2 {
+			t.Fatalf("Unexpected log buffer length: %d", b.Len())
+		}
+		expected := `level=error msg="no services enabled" error="all of the players, rooms, links and items services are disabled"`
+		if !strings.Contains(b.Index(1), expected) {
+			t.Errorf("\nExpected error log: %s\nActual error log:   %s", expected, b.Index(1))
+		}
+	})
+
 	t.Run("telemetry server construction failure", func(t *testing.T) {
 		s, b := setup()
 
@@ -199,12 +358,12 @@ func TestServer(t *testing.T) {
 		}
 
 		s.Start(args)
-		if b.Len() != 7 {
+		if b.Len() != 11 {
 			t.Fatalf("Unexpected error log buffer length: %d", b.Len())
 		}
 		expected := `level=error msg="failed to create telemetry server" error="telemetry server construction failure"`
-		if !strings.Contains(b.Index(6), expected) {
-			t.Errorf("\nExpected error log: %s\nActual error log:   %s", expected, b.Index(6))
+		if !strings.Contains(b.Index(10), expected) {
+			t.Errorf("\nExpected error log: %s\nActual error log:   %s", expected, b.Index(10))
 		}
 
 		if err := m.ExpectationsWereMet(); err != nil {
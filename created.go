@@ -0,0 +1,44 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"fmt"
+	"time"
+
+	"arcadium.dev/core/errors"
+)
+
+// newCreatedWithin parses the createdWithin query parameter of a list
+// request, a positive duration such as 15m, returning nil if it is not given.
+func newCreatedWithin(values []string) (*time.Duration, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(values[0])
+	if err != nil || d <= 0 {
+		return nil, fmt.Errorf("%w: invalid createdWithin query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+	}
+	return &d, nil
+}
+
+// recentFirst returns the sort of a list filtered by createdWithin, the
+// given sort when one is given, else newest first.
+func recentFirst(sort Sort) Sort {
+	if sort == (Sort{}) {
+		return Sort{Column: "created", Desc: true}
+	}
+	return sort
+}
@@ -0,0 +1,54 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"arcadium.dev/core/errors"
+)
+
+type (
+	// Cursor is the position of a page in a list read as of a snapshot,
+	// given to a client as an opaque string. Every page of the list is read
+	// as of the same time, so the pages are consistent while the list
+	// changes.
+	Cursor struct {
+		AsOf   time.Time `json:"asOf"`
+		Offset int       `json:"offset"`
+	}
+)
+
+// String returns the opaque encoding of the cursor.
+func (c Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor returns the cursor of the given opaque encoding.
+func ParseCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor: '%s'", errors.ErrInvalidArgument, s)
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.AsOf.IsZero() || c.Offset < 0 {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor: '%s'", errors.ErrInvalidArgument, s)
+	}
+	return c, nil
+}
@@ -0,0 +1,55 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		cursor := arcade.Cursor{AsOf: time.Date(2026, 10, 16, 10, 30, 0, 123456000, time.UTC), Offset: 20}
+
+		parsed, err := arcade.ParseCursor(cursor.String())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !parsed.AsOf.Equal(cursor.AsOf) || parsed.Offset != cursor.Offset {
+			t.Errorf("Unexpected cursor: %+v", parsed)
+		}
+	})
+
+	asOf := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+	for name, s := range map[string]string{
+		"encoding":  "not base64!",
+		"json":      "bm90IGpzb24",
+		"zero asOf": arcade.Cursor{}.String(),
+		"offset":    arcade.Cursor{AsOf: asOf, Offset: -1}.String(),
+	} {
+		s := s
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := arcade.ParseCursor(s)
+
+			expected := "invalid argument: invalid cursor: '" + s + "'"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %v", expected, err)
+			}
+		})
+	}
+}
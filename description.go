@@ -0,0 +1,23 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+var (
+	// RequireDescription, when set, rejects a create or update request of
+	// any asset with an empty description. When not set, an empty
+	// description is stored as is. It is expected to be set once, at
+	// startup.
+	RequireDescription = true
+)
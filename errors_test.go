@@ -0,0 +1,39 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+func TestErrConflict(t *testing.T) {
+	err := fmt.Errorf("failed: %w: at capacity", arcade.ErrConflict)
+
+	if !errors.Is(err, arcade.ErrConflict) {
+		t.Error("Expected a conflict error")
+	}
+	if !errors.Is(err, cerrors.ErrAlreadyExists) {
+		t.Error("Expected a conflict to be reported as an already exists error")
+	}
+	if err.Error() != "failed: conflict: at capacity" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,41 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"context"
+)
+
+type (
+	// ExportChecksum is a checksum of the current state of the world. It
+	// changes if, and only if, an entity is created, updated or removed.
+	ExportChecksum struct {
+		Algorithm string `json:"algorithm"`
+		Checksum  string `json:"checksum"`
+		Entities  int    `json:"entities"`
+	}
+
+	// ExportChecksumResponse is used to json encode an export checksum
+	// response.
+	ExportChecksumResponse struct {
+		Data ExportChecksum `json:"data"`
+	}
+
+	// ExportStorage represents the export of the persistent storage.
+	ExportStorage interface {
+		// Checksum returns a checksum of the current state of the world.
+		Checksum(ctx context.Context) (ExportChecksum, error)
+	}
+)
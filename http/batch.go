@@ -0,0 +1,37 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+// DefaultMaxBatchSize is the most ids a bulk request may give, unless
+// configured otherwise.
+const DefaultMaxBatchSize = 100
+
+// checkBatchSize returns an error when a bulk request gives more than max
+// ids, or more than DefaultMaxBatchSize when max is not set.
+func checkBatchSize(n, max int) error {
+	if max <= 0 {
+		max = DefaultMaxBatchSize
+	}
+	if n > max {
+		return fmt.Errorf("%w: batch exceeds maximum size %d", cerrors.ErrInvalidArgument, max)
+	}
+	return nil
+}
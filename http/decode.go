@@ -0,0 +1,123 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+// decode unmarshals the json body of a create or update request into v. Ids
+// are strings, so an id given as any other json type is reported with a clear
+// error, unless coerceIDs is set and the id is a number, in which case it is
+// converted into its string form. An id given as a typed URN is replaced with
+// its plain id. A field of the body unknown to v is ignored, unless
+// rejectUnknown is set.
+func decode(body []byte, v interface{}, coerceIDs, rejectUnknown bool) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err == nil {
+		replaced := false
+		for key, raw := range fields {
+			if !strings.HasSuffix(key, "ID") {
+				continue
+			}
+
+			var value interface{}
+			d := json.NewDecoder(bytes.NewReader(raw))
+			d.UseNumber()
+			if err := d.Decode(&value); err != nil {
+				continue
+			}
+
+			switch value := value.(type) {
+			case nil:
+			case string:
+				id, err := fromURN(key, value)
+				if err != nil {
+					return err
+				}
+				if id != value {
+					fields[key], _ = json.Marshal(id)
+					replaced = true
+				}
+			case json.Number:
+				if !coerceIDs {
+					return fmt.Errorf("%w: %s must be a string", cerrors.ErrInvalidArgument, key)
+				}
+				fields[key], _ = json.Marshal(value.String())
+				replaced = true
+			default:
+				return fmt.Errorf("%w: %s must be a string", cerrors.ErrInvalidArgument, key)
+			}
+		}
+		if replaced {
+			body, _ = json.Marshal(fields)
+		}
+	}
+
+	if err := unmarshal(body, v, rejectUnknown); err != nil {
+		return fmt.Errorf("%w: invalid body: %s", cerrors.ErrInvalidArgument, err)
+	}
+	return nil
+}
+
+// unmarshal unmarshals the json body of a request into v, as json.Unmarshal,
+// but when rejectUnknown is set a field of the body unknown to v is an error
+// rather than ignored.
+func unmarshal(body []byte, v interface{}, rejectUnknown bool) error {
+	if !rejectUnknown {
+		return json.Unmarshal(body, v)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// checkImmutable returns an error when the json body of an update request
+// gives the created timestamp, or an id under idKey other than the id of the
+// updated asset, either plain or as a typed URN. A body which is not a json
+// object is left for decode to report.
+func checkImmutable(body []byte, idKey, id string) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+	if _, ok := fields["created"]; ok {
+		return fmt.Errorf("%w: created is immutable", cerrors.ErrInvalidArgument)
+	}
+	if raw, ok := fields[idKey]; ok {
+		var given string
+		if err := json.Unmarshal(raw, &given); err != nil {
+			return fmt.Errorf("%w: %s is immutable", cerrors.ErrInvalidArgument, idKey)
+		}
+		if given, err := fromURN(idKey, given); err != nil || given != id {
+			return fmt.Errorf("%w: %s is immutable", cerrors.ErrInvalidArgument, idKey)
+		}
+	}
+	return nil
+}
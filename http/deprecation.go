@@ -0,0 +1,41 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Deprecation returns a middleware which annotates each response with the
+// Deprecation and Sunset headers, warning clients that the v1 routes will be
+// removed at the given sunset date. When the deprecation date is zero, the
+// Deprecation header is simply "true". The response is otherwise unaltered.
+func Deprecation(deprecated, sunset time.Time) mux.MiddlewareFunc {
+	d := "true"
+	if !deprecated.IsZero() {
+		d = deprecated.UTC().Format(http.TimeFormat)
+	}
+	s := sunset.UTC().Format(http.TimeFormat)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", d)
+			w.Header().Set("Sunset", s)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
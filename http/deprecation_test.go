@@ -0,0 +1,67 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestDeprecation(t *testing.T) {
+	const id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+
+	deprecated := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	m := &mockItemsStorage{t: t, itemID: id, item: arcade.Item{ID: id}}
+	router := mux.NewRouter()
+	router.Use(ahttp.Deprecation(deprecated, sunset))
+	ahttp.ItemsService{Storage: m}.Register(router)
+
+	r := httptest.NewRequest(http.MethodGet, ahttp.ItemsRoute+"/"+id, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	resp := w.Result()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected status: %d", resp.StatusCode)
+	}
+	if h := resp.Header.Get("Deprecation"); h != "Wed, 01 Jun 2022 00:00:00 GMT" {
+		t.Errorf("Unexpected deprecation header: %s", h)
+	}
+	if h := resp.Header.Get("Sunset"); h != "Sun, 01 Jan 2023 17:00:00 GMT" {
+		t.Errorf("Unexpected sunset header: %s", h)
+	}
+
+	t.Run("no deprecation date", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.Use(ahttp.Deprecation(time.Time{}, sunset))
+		ahttp.ItemsService{Storage: m}.Register(router)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ahttp.ItemsRoute+"/"+id, nil))
+
+		if h := w.Result().Header.Get("Deprecation"); h != "true" {
+			t.Errorf("Unexpected deprecation header: %s", h)
+		}
+	})
+}
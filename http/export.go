@@ -0,0 +1,71 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+const (
+	// ExportRoute is the route of the export of the world.
+	ExportRoute string = "/export"
+)
+
+type (
+	// ExportService serves the export of the world, so a backup of it may
+	// be verified.
+	ExportService struct {
+		Storage arcade.ExportStorage
+	}
+)
+
+// Register sets up the http handler for this service with the given router.
+func (s ExportService) Register(router *mux.Router) {
+	r := router.PathPrefix(ExportRoute).Subrouter()
+	r.HandleFunc("/checksum", s.Checksum).Methods(http.MethodGet)
+}
+
+// Name returns the name of the service.
+func (ExportService) Name() string {
+	return "export"
+}
+
+// Shutdown is a no-op since there no long running processes for this service.
+func (ExportService) Shutdown() {}
+
+// Checksum handles a request for a checksum of the current state of the world.
+func (s ExportService) Checksum(w http.ResponseWriter, r *http.Request) {
+	checksum, err := s.Storage.Checksum(r.Context())
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.ExportChecksumResponse{Data: checksum})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
@@ -0,0 +1,75 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+type mockExportStorage struct {
+	checksum arcade.ExportChecksum
+	err      error
+}
+
+func (m mockExportStorage) Checksum(context.Context) (arcade.ExportChecksum, error) {
+	return m.checksum, m.err
+}
+
+func TestExportServiceName(t *testing.T) {
+	var s ahttp.ExportService
+	if s.Name() != "export" {
+		t.Errorf("Unexpected service name: %s", s.Name())
+	}
+}
+
+func TestExportServiceChecksum(t *testing.T) {
+	route := ahttp.ExportRoute + "/checksum"
+
+	t.Run("storage error", func(t *testing.T) {
+		s := ahttp.ExportService{Storage: mockExportStorage{err: errors.New("unknown error")}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := mockExportStorage{checksum: arcade.ExportChecksum{Algorithm: "sha256", Checksum: "abc123", Entities: 7}}
+
+		w := invokeService(t, ahttp.ExportService{Storage: m}, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var checksumResp arcade.ExportChecksumResponse
+		if err := json.NewDecoder(resp.Body).Decode(&checksumResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if checksumResp.Data != m.checksum {
+			t.Errorf("Unexpected checksum: %+v", checksumResp.Data)
+		}
+	})
+}
@@ -15,7 +15,7 @@
 package http // import "arcadium.dev/arcade/http"
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -25,17 +25,31 @@ import (
 
 const (
 	route string = "/health"
+
+	// ReadyRoute is the route of the readiness of the service to serve
+	// requests.
+	ReadyRoute string = "/readyz"
 )
 
 type (
 	// HealthService reports on the health of the service as a whole.
-	HealthService struct{}
+	HealthService struct {
+		// DB, when set, is pinged to report the readiness of the service,
+		// which is not ready while the database is unreachable.
+		DB Pinger
+	}
+
+	// Pinger is implemented by a *sql.DB.
+	Pinger interface {
+		PingContext(ctx context.Context) error
+	}
 )
 
 // Register sets up the http handler for this service with the given router.
 func (s HealthService) Register(router *mux.Router) {
 	r := router.PathPrefix(route).Subrouter()
 	r.HandleFunc("", s.get).Methods(http.MethodGet)
+	router.HandleFunc(ReadyRoute, s.ready).Methods(http.MethodGet)
 }
 
 // Name returns the name of the service.
@@ -48,5 +62,17 @@ func (HealthService) Shutdown() {}
 
 func (HealthService) get(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(arcade.HealthResponse{Data: arcade.Health{Status: "up"}})
+	encoder(w, r).Encode(arcade.HealthResponse{Data: arcade.Health{Status: "up"}})
+}
+
+func (s HealthService) ready(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.DB != nil {
+		if err := s.DB.PingContext(r.Context()); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			encoder(w, r).Encode(arcade.HealthResponse{Data: arcade.Health{Status: "down"}})
+			return
+		}
+	}
+	encoder(w, r).Encode(arcade.HealthResponse{Data: arcade.Health{Status: "up"}})
 }
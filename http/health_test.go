@@ -15,17 +15,31 @@
 package http_test
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	ahttp "arcadium.dev/arcade/http"
+	"arcadium.dev/arcade/storage"
 )
 
+type mockPinger struct {
+	pings int
+	err   error
+}
+
+func (m *mockPinger) PingContext(context.Context) error {
+	m.pings++
+	return m.err
+}
+
 func TestHealthServiceRegister(t *testing.T) {
 	method := http.MethodGet
 	route := "/health"
@@ -64,3 +78,61 @@ func TestHealthServiceName(t *testing.T) {
 		t.Errorf("Unexpected service name: %s", s.Name())
 	}
 }
+
+func TestHealthServiceReady(t *testing.T) {
+	probe := func(t *testing.T, s ahttp.HealthService) (int, string) {
+		t.Helper()
+
+		router := mux.NewRouter()
+		s.Register(router)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ahttp.ReadyRoute, nil))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read body")
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		m := &mockPinger{}
+
+		status, body := probe(t, ahttp.HealthService{DB: m})
+
+		if status != http.StatusOK || !strings.Contains(body, "\"up\"") {
+			t.Errorf("Unexpected response: %d %s", status, body)
+		}
+		if m.pings != 1 {
+			t.Errorf("Unexpected pings: %d", m.pings)
+		}
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		m := &mockPinger{err: errors.New("ping error")}
+
+		status, body := probe(t, ahttp.HealthService{DB: m})
+
+		if status != http.StatusServiceUnavailable || !strings.Contains(body, "\"down\"") {
+			t.Errorf("Unexpected response: %d %s", status, body)
+		}
+	})
+
+	t.Run("cached ping", func(t *testing.T) {
+		m := &mockPinger{}
+		s := ahttp.HealthService{DB: storage.NewPingCache(m, time.Hour)}
+
+		probe(t, s)
+		status, _ := probe(t, s)
+
+		if status != http.StatusOK {
+			t.Errorf("Unexpected status: %d", status)
+		}
+		if m.pings != 1 {
+			t.Errorf("Expected the second probe not to ping, actual pings: %d", m.pings)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"arcadium.dev/arcade"
+)
+
+// selfLink returns the hyperlinks of the resource with the given id, found
+// under the given route.
+func selfLink(route, id string) *arcade.Hyperlinks {
+	return &arcade.Hyperlinks{Self: route + "/" + id}
+}
+
+// pageLinks returns the hyperlinks of a page of resources given the request
+// for the page, the offset and limit of the page, and the number of resources
+// returned. The next link is present when the page is full, the prev link
+// when the page is not the first.
+func pageLinks(r *http.Request, offset, limit, count int) *arcade.Hyperlinks {
+	return pages(r, offset, limit, count, func(q url.Values, offset int) {
+		if offset > 0 {
+			q.Set("offset", strconv.Itoa(offset))
+		}
+	})
+}
+
+// snapshotLinks returns the hyperlinks of a page of resources read as of a
+// snapshot, as pageLinks, with the pages given by cursors of the snapshot.
+func snapshotLinks(r *http.Request, asOf time.Time, offset, limit, count int) *arcade.Hyperlinks {
+	return pages(r, offset, limit, count, func(q url.Values, offset int) {
+		q.Del("snapshot")
+		q.Set("cursor", arcade.Cursor{AsOf: asOf, Offset: offset}.String())
+	})
+}
+
+// pages returns the hyperlinks of a page of resources, setting the query of
+// the page at an offset with the given function.
+func pages(r *http.Request, offset, limit, count int, set func(url.Values, int)) *arcade.Hyperlinks {
+	links := &arcade.Hyperlinks{Self: r.URL.RequestURI()}
+
+	page := func(offset int) string {
+		q := r.URL.Query()
+		q.Del("offset")
+		q.Del("cursor")
+		set(q, offset)
+		u := *r.URL
+		u.RawQuery = q.Encode()
+		return u.RequestURI()
+	}
+
+	if limit > 0 && count >= limit {
+		links.Next = page(offset + limit)
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links.Prev = page(prev)
+	}
+	return links
+}
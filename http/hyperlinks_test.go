@@ -0,0 +1,87 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestPageLinks(t *testing.T) {
+	rooms := []arcade.Room{
+		{ID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf"},
+		{ID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b"},
+	}
+
+	tests := []struct {
+		name             string
+		target           string
+		self, next, prev string
+	}{
+		{
+			name:   "first page",
+			target: "/rooms?limit=2",
+			self:   "/rooms?limit=2",
+			next:   "/rooms?limit=2&offset=2",
+		},
+		{
+			name:   "middle page",
+			target: "/rooms?limit=2&offset=3",
+			self:   "/rooms?limit=2&offset=3",
+			next:   "/rooms?limit=2&offset=5",
+			prev:   "/rooms?limit=2&offset=1",
+		},
+		{
+			name:   "last page",
+			target: "/rooms?limit=3&offset=2",
+			self:   "/rooms?limit=3&offset=2",
+			prev:   "/rooms?limit=3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockRoomsStorage{t: t, rooms: rooms}
+
+			w := invokeRoomsService(t, m, http.MethodGet, test.target, nil)
+
+			resp := w.Result()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Unexpected status: %d", resp.StatusCode)
+			}
+			var roomsResp arcade.RoomsResponse
+			if err := json.NewDecoder(resp.Body).Decode(&roomsResp); err != nil {
+				t.Fatalf("Failed to json decode response: %s", err)
+			}
+
+			links := roomsResp.Hyperlinks
+			if links == nil {
+				t.Fatal("Expected hyperlinks")
+			}
+			if links.Self != test.self || links.Next != test.next || links.Prev != test.prev {
+				t.Errorf("Unexpected hyperlinks: %+v", *links)
+			}
+			for _, room := range roomsResp.Data {
+				if room.Hyperlinks == nil || room.Hyperlinks.Self != ahttp.RoomsRoute+"/"+room.ID {
+					t.Errorf("Unexpected room self link: %+v", room.Hyperlinks)
+				}
+			}
+		})
+	}
+}
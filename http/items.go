@@ -15,15 +15,20 @@
 package http // import "arcadium.dev/arcade/http"
 
 import (
-	"encoding/json"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
 	cerrors "arcadium.dev/core/errors"
-	chttp "arcadium.dev/core/http"
 
 	"arcadium.dev/arcade"
 )
@@ -36,6 +41,43 @@ type (
 	// Items is used to manage the item assets.
 	ItemsService struct {
 		Storage arcade.ItemsStorage
+
+		// MaxOffset, when non-zero, is the largest offset a list request
+		// may use. The offset of a cursor is not limited.
+		MaxOffset int
+
+		// DefaultSort is the order of a list when the request does not give
+		// one, the zero value falls back to ascending by creation time.
+		DefaultSort arcade.Sort
+
+		// CoerceNumericIDs, when set, accepts ids given as json numbers in
+		// create and update requests, converting them into strings.
+		CoerceNumericIDs bool
+
+		// RejectUnknownFields, when set, rejects a request body giving a
+		// field the request does not know, rather than ignoring it.
+		RejectUnknownFields bool
+
+		// StrictQueryParams, when set, rejects a list request given a query
+		// parameter it does not know, rather than ignoring it.
+		StrictQueryParams bool
+
+		// TrailingSlash is the handling of a route given with a trailing
+		// slash.
+		TrailingSlash TrailingSlash
+
+		// StrictImmutableFields, when set, rejects an update request giving
+		// the created timestamp or a different itemID, rather than ignoring
+		// them.
+		StrictImmutableFields bool
+
+		// MaxBatchSize is the most itemIDs a bulk request may give, the zero
+		// value falls back to DefaultMaxBatchSize.
+		MaxBatchSize int
+
+		// Names is the item name policy, whose longest name the schema
+		// reports.
+		Names arcade.NamePolicy
 	}
 )
 
@@ -43,10 +85,22 @@ type (
 func (s ItemsService) Register(router *mux.Router) {
 	r := router.PathPrefix(ItemsRoute).Subrouter()
 	r.HandleFunc("", s.List).Methods(http.MethodGet)
+	r.HandleFunc("/search", s.Search).Methods(http.MethodGet)
+	r.HandleFunc("/swap", s.Swap).Methods(http.MethodPost)
+	r.HandleFunc("/batch-delete", s.RemoveMany).Methods(http.MethodPost)
+	r.HandleFunc("/changes", s.Changes).Methods(http.MethodGet)
+	r.HandleFunc("/top-owners", s.TopOwners).Methods(http.MethodGet)
+	r.HandleFunc("/orphans", s.Orphans).Methods(http.MethodGet)
+	r.HandleFunc("/orphans/fix", s.FixOrphans).Methods(http.MethodPost)
+	r.HandleFunc("/schema", s.Schema).Methods(http.MethodGet)
+	r.HandleFunc("/import", s.Import).Methods(http.MethodPost)
 	r.HandleFunc("/{itemID}", s.Get).Methods(http.MethodGet)
+	r.HandleFunc("/{itemID}", s.Head).Methods(http.MethodHead)
 	r.HandleFunc("", s.Create).Methods(http.MethodPost)
 	r.HandleFunc("/{itemID}", s.Update).Methods(http.MethodPut)
 	r.HandleFunc("/{itemID}", s.Remove).Methods(http.MethodDelete)
+	r.HandleFunc("/{itemID}/transfer", s.Transfer).Methods(http.MethodPost)
+	handleTrailingSlash(router, r, s.TrailingSlash)
 }
 
 // Name returns the name of the service.
@@ -61,26 +115,412 @@ func (ItemsService) Shutdown() {}
 func (s ItemsService) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// TODO: parse query params
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "neverUpdated", "ownerIDs", "createdBy", "createdWithin", "sort", "limit", "offset", "cursor", "snapshot"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	// Create the filter.
+	filter, err := arcade.NewItemsFilter(r)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	if filter.Sort == (arcade.Sort{}) {
+		filter.Sort = s.DefaultSort
+	}
+	if s.MaxOffset > 0 && filter.AsOf == nil && filter.Offset > s.MaxOffset {
+		response(w, r, fmt.Errorf(
+			"%w: offset too large, use cursor pagination", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	// A snapshot list reads every page as of the time of its first page.
+	if value := r.URL.Query().Get("snapshot"); value != "" && filter.AsOf == nil {
+		snapshot, err := strconv.ParseBool(value)
+		if err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid snapshot query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+		if snapshot {
+			asOf, err := s.Storage.Snapshot(ctx)
+			if err != nil {
+				response(w, r, err)
+				return
+			}
+			filter.AsOf = &asOf
+		}
+	}
 
 	// Read list of items.
-	items, err := s.Storage.List(ctx, arcade.ItemsFilter{})
+	items, err := s.Storage.List(ctx, filter)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	// Return list as body.
+	resp := arcade.NewItemsResponse(items)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(ItemsRoute, resp.Data[i].ID)
+	}
+	switch {
+	case filter.AsOf != nil:
+		resp.Hyperlinks = snapshotLinks(r, *filter.AsOf, filter.Offset, filter.Limit, len(items))
+	case filter.Limit > 0 || filter.Offset > 0:
+		resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(items))
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.NewItemsResponse(items))
+	err = encoder(w, r).Encode(resp)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to create response: %s", cerrors.ErrInternal, err,
 		))
 		return
 	}
 }
 
+// Search handles a request to search for items, ordered by relevance.
+func (s ItemsService) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "q", "limit", "offset"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	// Create the filter.
+	filter, err := arcade.NewItemsSearchFilter(r)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	// Search for the items.
+	items, err := s.Storage.Search(ctx, filter)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	// Return the items, in order, as body.
+	resp := arcade.NewItemsResponse(items)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(ItemsRoute, resp.Data[i].ID)
+	}
+	resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(items))
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Changes handles a request for the items updated since a watermark, in order
+// of update then itemID.
+func (s ItemsService) Changes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "since", "sinceID", "limit"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	q := r.URL.Query()
+	since, err := time.Parse(time.RFC3339, q.Get("since"))
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid since query parameter: '%s'", cerrors.ErrInvalidArgument, q.Get("since"),
+		))
+		return
+	}
+	sinceID := q.Get("sinceID")
+	if sinceID != "" {
+		if _, err := uuid.Parse(sinceID); err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid sinceID query parameter: '%s'", cerrors.ErrInvalidArgument, sinceID,
+			))
+			return
+		}
+	}
+	limit := arcade.DefaultItemsFilterLimit
+	if value := q.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > arcade.MaxItemsFilterLimit {
+			response(w, r, fmt.Errorf(
+				"%w: invalid limit query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+
+	// List the changed items.
+	watermark := arcade.Timestamp{Time: since}
+	items, err := s.Storage.ChangedSince(ctx, watermark, sinceID, limit)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	// Return the items, in order, as body.
+	resp := arcade.NewItemsChangesResponse(items, watermark, sinceID)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(ItemsRoute, resp.Data[i].ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// TopOwners handles a request for the owners with the most items.
+func (s ItemsService) TopOwners(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "limit"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	limit := arcade.DefaultItemsFilterLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > arcade.MaxItemsFilterLimit {
+			response(w, r, fmt.Errorf(
+				"%w: invalid limit query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+
+	counts, err := s.Storage.TopOwners(ctx, limit)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.OwnerCountsResponse{Data: counts})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Orphans handles a request to retrieve the items whose owner, location or
+// inventory does not exist.
+func (s ItemsService) Orphans(w http.ResponseWriter, r *http.Request) {
+	items, err := s.Storage.FindOrphans(r.Context())
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	s.orphansResponse(w, r, items)
+}
+
+// FixOrphans handles a request to reset the dangling references of the
+// orphaned items, returning the items as they were found.
+func (s ItemsService) FixOrphans(w http.ResponseWriter, r *http.Request) {
+	items, err := s.Storage.FixOrphans(r.Context())
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	s.orphansResponse(w, r, items)
+}
+
+func (s ItemsService) orphansResponse(w http.ResponseWriter, r *http.Request, items []arcade.Item) {
+	resp := arcade.NewItemsResponse(items)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(ItemsRoute, resp.Data[i].ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Schema handles a request for the fields of an item create or update
+// request.
+func (s ItemsService) Schema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := encoder(w, r).Encode(arcade.SchemaResponse{Data: arcade.ItemSchema(s.Names)})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Import handles a request to create items from the rows of a csv body,
+// whose header row names the item field of each column.
+func (s ItemsService) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	strict := false
+	if value := r.URL.Query().Get("strict"); value != "" {
+		var err error
+		strict, err = strconv.ParseBool(value)
+		if err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid strict query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "text/csv" {
+		response(w, r, fmt.Errorf(
+			"%w: invalid content type: a text/csv body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	body := http.MaxBytesReader(w, r.Body, arcade.ItemsImportMaxBytes)
+	rows, malformed, err := readItemsCSV(body, arcade.ItemsImportMaxRows)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	var results []arcade.ItemImportResult
+	if strict && len(malformed) > 0 {
+		for _, row := range rows {
+			results = append(results, arcade.ItemImportResult{Line: row.Line})
+		}
+	} else {
+		results, err = s.Storage.Import(ctx, rows, strict)
+		if err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+	results = append(results, malformed...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.ItemsImportResponse{Data: results})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// readItemsCSV reads the rows of an items import. The header row names the
+// item field of each column. A malformed row, or one with a value which does
+// not parse, is returned as a failed result. More than max rows, malformed or
+// not, is rejected.
+func readItemsCSV(body io.Reader, max int) ([]arcade.ItemImportRow, []arcade.ItemImportResult, error) {
+	fields := map[string]func(*arcade.ItemRequest, string) error{
+		"name":        func(req *arcade.ItemRequest, v string) error { req.Name = v; return nil },
+		"description": func(req *arcade.ItemRequest, v string) error { req.Description = v; return nil },
+		"ownerID":     func(req *arcade.ItemRequest, v string) error { req.OwnerID = v; return nil },
+		"locationID":  func(req *arcade.ItemRequest, v string) error { req.LocationID = v; return nil },
+		"inventoryID": func(req *arcade.ItemRequest, v string) error { req.InventoryID = v; return nil },
+		// An empty weight is the default, weightless.
+		"weight": func(req *arcade.ItemRequest, v string) error {
+			if v == "" {
+				return nil
+			}
+			weight, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%w: invalid weight: '%s'", cerrors.ErrInvalidArgument, v)
+			}
+			req.Weight = weight
+			return nil
+		},
+	}
+
+	reader := csv.NewReader(body)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%w: invalid csv: a header row is required", cerrors.ErrInvalidArgument)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid csv: %s", cerrors.ErrInvalidArgument, err)
+	}
+	setters := make([]func(*arcade.ItemRequest, string) error, len(header))
+	for i, column := range header {
+		set, ok := fields[column]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: invalid csv: unknown column '%s'", cerrors.ErrInvalidArgument, column)
+		}
+		setters[i] = set
+	}
+
+	var (
+		rows      []arcade.ItemImportRow
+		malformed []arcade.ItemImportResult
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows)+len(malformed) == max {
+			return nil, nil, fmt.Errorf("%w: import exceeds maximum rows %d", cerrors.ErrInvalidArgument, max)
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			malformed = append(malformed, arcade.ItemImportResult{
+				Line:  parseErr.StartLine,
+				Error: fmt.Errorf("%w: malformed row: %s", cerrors.ErrInvalidArgument, parseErr.Err).Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: unable to read request: %s", cerrors.ErrInvalidArgument, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := arcade.ItemImportRow{Line: line}
+		var setErr error
+		for i, value := range record {
+			if setErr = setters[i](&row.Request, value); setErr != nil {
+				break
+			}
+		}
+		if setErr != nil {
+			malformed = append(malformed, arcade.ItemImportResult{Line: line, Error: setErr.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, malformed, nil
+}
+
 // Get handles a request to retrieve an item.
 func (s ItemsService) Get(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -90,27 +530,49 @@ func (s ItemsService) Get(w http.ResponseWriter, r *http.Request) {
 
 	item, err := s.Storage.Get(ctx, itemID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	item.Hyperlinks = selfLink(ItemsRoute, item.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.ItemResponse{Data: item})
+	err = encoder(w, r).Encode(arcade.ItemResponse{Data: item})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
 	}
 }
 
+// Head handles a request to check that an item exists.
+func (s ItemsService) Head(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+	itemID := params["itemID"]
+
+	exists, err := s.Storage.Exists(ctx, itemID)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 // Create handles a request to create an item.
 func (s ItemsService) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -118,31 +580,31 @@ func (s ItemsService) Create(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
 	var req arcade.ItemRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	item, err := s.Storage.Create(ctx, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	item.Hyperlinks = selfLink(ItemsRoute, item.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.ItemResponse{Data: item})
+	err = encoder(w, r).Encode(arcade.ItemResponse{Data: item})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -158,7 +620,7 @@ func (s ItemsService) Update(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -166,31 +628,38 @@ func (s ItemsService) Update(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
+	if s.StrictImmutableFields {
+		if err := checkImmutable(body, "itemID", itemID); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
 	var req arcade.ItemRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	item, err := s.Storage.Update(ctx, itemID, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	item.Hyperlinks = selfLink(ItemsRoute, item.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.ItemResponse{Data: item})
+	err = encoder(w, r).Encode(arcade.ItemResponse{Data: item})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -206,9 +675,150 @@ func (s ItemsService) Remove(w http.ResponseWriter, r *http.Request) {
 
 	err := s.Storage.Remove(ctx, itemID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Transfer handles a request to reassign an item to a new owner.
+func (s ItemsService) Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+	itemID := params["itemID"]
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.ItemTransferRequest
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	item, err := s.Storage.Transfer(ctx, itemID, req.OwnerID)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	item.Hyperlinks = selfLink(ItemsRoute, item.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.ItemResponse{Data: item})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Swap handles a request to exchange the locations of two items.
+func (s ItemsService) Swap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.ItemsSwapRequest
+	err = unmarshal(body, &req, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	if len(req.ItemIDs) != 2 {
+		response(w, r, fmt.Errorf(
+			"%w: swap requires exactly two itemIDs", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	err = s.Storage.SwapLocations(ctx, req.ItemIDs[0], req.ItemIDs[1])
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveMany handles a request to remove multiple items.
+func (s ItemsService) RemoveMany(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.ItemsRemoveRequest
+	err = unmarshal(body, &req, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	if err := checkBatchSize(len(req.ItemIDs), s.MaxBatchSize); err != nil {
+		response(w, r, err)
+		return
+	}
+
+	count, err := s.Storage.RemoveMany(ctx, req.ItemIDs)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.ItemsRemoveResponse{Data: arcade.ItemsRemoved{Count: count}})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
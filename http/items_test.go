@@ -19,14 +19,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	cerrors "arcadium.dev/core/errors"
+
 	"arcadium.dev/arcade"
 	ahttp "arcadium.dev/arcade/http"
 )
@@ -38,74 +42,818 @@ func TestItemsServiceName(t *testing.T) {
 	}
 }
 
-func TestItemsServiceShutdown(t *testing.T) {
-	// This is a placeholder for when we have a background monitor service running.
-	s := ahttp.ItemsService{}
-	s.Shutdown()
+func TestItemsServiceShutdown(t *testing.T) {
+	// This is a placeholder for when we have a background monitor service running.
+	s := ahttp.ItemsService{}
+	s.Shutdown()
+}
+
+func TestItemsServiceList(t *testing.T) {
+	t.Run("default sort", func(t *testing.T) {
+		defaultSort := arcade.Sort{Column: "updated", Desc: true}
+		m := &mockItemsStorage{t: t}
+
+		invokeService(t, ahttp.ItemsService{Storage: m, DefaultSort: defaultSort}, http.MethodGet, ahttp.ItemsRoute, nil)
+
+		if m.listFilter.Sort != defaultSort {
+			t.Errorf("Unexpected sort: %+v", m.listFilter.Sort)
+		}
+	})
+
+	t.Run("offset too large", func(t *testing.T) {
+		s := ahttp.ItemsService{Storage: &mockItemsStorage{t: t}, MaxOffset: 100}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"?offset=101", nil),
+			http.StatusBadRequest,
+			"invalid argument: offset too large, use cursor pagination",
+		)
+	})
+
+	t.Run("offset at maximum", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+		s := ahttp.ItemsService{Storage: m, MaxOffset: 100}
+
+		w := invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"?offset=100", nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("cursor offset", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+		s := ahttp.ItemsService{Storage: m, MaxOffset: 100}
+		cursor := arcade.Cursor{AsOf: time.Now(), Offset: 101}.String()
+
+		w := invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"?cursor="+cursor, nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("filter error", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, ahttp.ItemsRoute+"?neverUpdated=maybe", nil),
+			http.StatusBadRequest, "invalid argument: invalid neverUpdated query parameter: 'maybe'",
+		)
+	})
+
+	t.Run("never updated", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"?neverUpdated=true", nil)
+
+		if m.listFilter.NeverUpdated == nil || !*m.listFilter.NeverUpdated {
+			t.Errorf("Unexpected neverUpdated: %v", m.listFilter.NeverUpdated)
+		}
+	})
+
+	t.Run("created by", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"?createdBy=player:42", nil)
+
+		if m.listFilter.CreatedBy == nil || *m.listFilter.CreatedBy != "player:42" {
+			t.Errorf("Unexpected createdBy: %v", m.listFilter.CreatedBy)
+		}
+	})
+
+	t.Run("strict owner ids", func(t *testing.T) {
+		const ownerID = "db81f22a-90ef-43b8-9a4e-0a5ecf3c8c4e"
+		m := &mockItemsStorage{t: t}
+		s := ahttp.ItemsService{Storage: m, StrictQueryParams: true}
+
+		invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"?ownerIDs="+ownerID+"&limit=5", nil)
+
+		if !m.listCalled {
+			t.Fatal("expected list to be called")
+		}
+		if len(m.listFilter.OwnerIDs) != 1 || m.listFilter.OwnerIDs[0].String() != ownerID {
+			t.Errorf("Unexpected ownerIDs: %v", m.listFilter.OwnerIDs)
+		}
+	})
+
+	t.Run("page", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, items: []arcade.Item{{ID: "a"}, {ID: "b"}}}
+
+		w := invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"?createdBy=system&limit=2&offset=4", nil)
+
+		if m.listFilter.Limit != 2 || m.listFilter.Offset != 4 {
+			t.Errorf("Unexpected page: %d %d", m.listFilter.Limit, m.listFilter.Offset)
+		}
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		var itemsResp arcade.ItemsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&itemsResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		h := itemsResp.Hyperlinks
+		if h == nil || h.Next != ahttp.ItemsRoute+"?createdBy=system&limit=2&offset=6" || h.Prev != ahttp.ItemsRoute+"?createdBy=system&limit=2&offset=2" {
+			t.Errorf("Unexpected hyperlinks: %+v", h)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, ahttp.ItemsRoute+"?limit=1000", nil),
+			http.StatusBadRequest, "invalid argument: invalid limit query parameter: '1000'",
+		)
+	})
+
+	t.Run("invalid snapshot", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, ahttp.ItemsRoute+"?snapshot=maybe", nil),
+			http.StatusBadRequest, "invalid argument: invalid snapshot query parameter: 'maybe'",
+		)
+	})
+
+	t.Run("snapshot error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"?snapshot=true", nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.snapshotCalled || m.listCalled {
+			t.Error("expected only snapshot to be called")
+		}
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		asOf := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+		m := &mockItemsStorage{t: t, asOf: asOf, items: []arcade.Item{{ID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf"}}}
+
+		w := invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"?snapshot=true&limit=1", nil)
+
+		if !m.snapshotCalled {
+			t.Error("expected snapshot to be called")
+		}
+		if m.listFilter.AsOf == nil || !m.listFilter.AsOf.Equal(asOf) {
+			t.Errorf("Unexpected asOf: %v", m.listFilter.AsOf)
+		}
+
+		var itemsResp arcade.ItemsResponse
+		if err := json.NewDecoder(w.Result().Body).Decode(&itemsResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		cursor := arcade.Cursor{AsOf: asOf, Offset: 1}.String()
+		h := itemsResp.Hyperlinks
+		if h == nil || h.Next != ahttp.ItemsRoute+"?cursor="+cursor+"&limit=1" || h.Prev != "" {
+			t.Fatalf("Unexpected hyperlinks: %+v", h)
+		}
+
+		m = &mockItemsStorage{t: t, items: []arcade.Item{{ID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b"}}}
+
+		invokeItemsService(t, m, http.MethodGet, h.Next, nil)
+
+		if m.snapshotCalled {
+			t.Error("expected snapshot not to be called")
+		}
+		if m.listFilter.AsOf == nil || !m.listFilter.AsOf.Equal(asOf) || m.listFilter.Offset != 1 {
+			t.Errorf("Unexpected filter: %+v", m.listFilter)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		err := errors.New("unknown error")
+		m := &mockItemsStorage{t: t, err: err}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		items := []arcade.Item{
+			{
+				ID:          "c39761fc-5096-4b1c-9d02-c75730b7b8bf",
+				Name:        "Drunen",
+				Description: "Son of Martin",
+				OwnerID:     "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
+				LocationID:  "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
+				InventoryID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
+			},
+		}
+		m := &mockItemsStorage{t: t, items: items}
+
+		w := invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute, nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Errorf("Failed to read response body")
+		}
+		defer resp.Body.Close()
+
+		var itemsResp arcade.ItemsResponse
+		err = json.Unmarshal(body, &itemsResp)
+		if err != nil {
+			t.Errorf("Failed to json unmarshal response: %s", err)
+		}
+
+		if len(itemsResp.Data) != len(items) {
+			t.Fatalf("Unexpected items response data length: %d", len(itemsResp.Data))
+		}
+
+		item := itemsResp.Data[0]
+		if item.ID != items[0].ID ||
+			item.Name != items[0].Name ||
+			item.Description != items[0].Description ||
+			item.OwnerID != items[0].OwnerID ||
+			item.LocationID != items[0].LocationID ||
+			item.InventoryID != items[0].InventoryID {
+			t.Errorf("Unexpected response data")
+		}
+		if item.Hyperlinks == nil || item.Hyperlinks.Self != "/items/"+items[0].ID {
+			t.Errorf("Unexpected self link: %+v", item.Hyperlinks)
+		}
+	})
+}
+
+func TestItemsServiceChanges(t *testing.T) {
+	since := time.Date(2022, time.October, 16, 16, 0, 0, 0, time.UTC)
+	route := ahttp.ItemsRoute + "/changes?since=" + since.Format(time.RFC3339)
+
+	t.Run("invalid since", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, ahttp.ItemsRoute+"/changes?since=yesterday", nil),
+			http.StatusBadRequest, "invalid argument: invalid since query parameter: 'yesterday'",
+		)
+	})
+
+	t.Run("invalid sinceID", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, route+"&sinceID=42", nil),
+			http.StatusBadRequest, "invalid argument: invalid sinceID query parameter: '42'",
+		)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, route+"&limit=1000", nil),
+			http.StatusBadRequest, "invalid argument: invalid limit query parameter: '1000'",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.changedSinceCalled {
+			t.Error("expected changed since to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		items := []arcade.Item{
+			{ID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf", Updated: arcade.Timestamp{Time: since.Add(time.Second)}},
+			{ID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b", Updated: arcade.Timestamp{Time: since.Add(time.Minute)}},
+		}
+		m := &mockItemsStorage{t: t, items: items, since: since, limit: 2}
+
+		w := invokeItemsService(t, m, http.MethodGet, route+"&limit=2", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var changesResp arcade.ItemsChangesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&changesResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(changesResp.Data) != 2 || changesResp.Data[0].ID != items[0].ID || changesResp.Data[1].ID != items[1].ID {
+			t.Errorf("Unexpected items: %+v", changesResp.Data)
+		}
+		if !changesResp.Watermark.Equal(since.Add(time.Minute)) || changesResp.WatermarkID != items[1].ID {
+			t.Errorf("Unexpected watermark: %s %s", changesResp.Watermark, changesResp.WatermarkID)
+		}
+	})
+
+	t.Run("since id", func(t *testing.T) {
+		sinceID := "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		m := &mockItemsStorage{t: t, since: since, sinceID: sinceID, limit: arcade.DefaultItemsFilterLimit}
+
+		w := invokeItemsService(t, m, http.MethodGet, route+"&sinceID="+sinceID, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		// Without changes, the watermark is the one given.
+		var changesResp arcade.ItemsChangesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&changesResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if !changesResp.Watermark.Equal(since) || changesResp.WatermarkID != sinceID {
+			t.Errorf("Unexpected watermark: %s %s", changesResp.Watermark, changesResp.WatermarkID)
+		}
+	})
+}
+
+func TestItemsServiceSchema(t *testing.T) {
+	w := invokeItemsService(t, &mockItemsStorage{t: t}, http.MethodGet, ahttp.ItemsRoute+"/schema", nil)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	var schemaResp arcade.SchemaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&schemaResp); err != nil {
+		t.Fatalf("Failed to decode response: %s", err)
+	}
+	fields := schemaResp.Data.Fields
+	if len(fields) != 6 || fields[0].Name != "name" || fields[0].MaxLength != arcade.MaxItemNameLen || !fields[0].Required {
+		t.Errorf("Unexpected fields: %+v", fields)
+	}
+
+	t.Run("configured name length", func(t *testing.T) {
+		s := ahttp.ItemsService{Storage: &mockItemsStorage{t: t}, Names: arcade.NamePolicy{MaxLen: 32}}
+		w := invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"/schema", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		var schemaResp arcade.SchemaResponse
+		if err := json.NewDecoder(resp.Body).Decode(&schemaResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if f := schemaResp.Data.Fields[0]; f.Name != "name" || f.MaxLength != 32 {
+			t.Errorf("Unexpected name field: %+v", f)
+		}
+	})
+}
+
+func TestItemsServiceImport(t *testing.T) {
+	const (
+		ownerID = "db81f22a-90ef-43b8-9a4e-0a5ecf3c8c4e"
+		roomID  = "00000000-0000-0000-0000-000000000001"
+	)
+	csvBody := func(rows ...string) io.Reader {
+		return strings.NewReader("name,description,ownerID,locationID,inventoryID\n" + strings.Join(rows, "\n") + "\n")
+	}
+	row := func(name string) string {
+		return fmt.Sprintf("%s,A %s.,%s,%s,%s", name, name, ownerID, roomID, ownerID)
+	}
+	invoke := func(t *testing.T, m *mockItemsStorage, target, contentType string, body io.Reader) arcade.ItemsImportResponse {
+		t.Helper()
+
+		router := mux.NewRouter()
+		ahttp.ItemsService{Storage: m}.Register(router)
+		r := httptest.NewRequest(http.MethodPost, target, body)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		var importResp arcade.ItemsImportResponse
+		if err := json.NewDecoder(resp.Body).Decode(&importResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		return importResp
+	}
+	route := ahttp.ItemsRoute + "/import"
+
+	t.Run("not csv", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, route, strings.NewReader("{}")),
+			http.StatusBadRequest, "invalid argument: invalid content type: a text/csv body is required",
+		)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		router := mux.NewRouter()
+		ahttp.ItemsService{}.Register(router)
+		r := httptest.NewRequest(http.MethodPost, route, strings.NewReader("name,colour\n"))
+		r.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		checkRespError(t, w, http.StatusBadRequest, "invalid argument: invalid csv: unknown column 'colour'")
+	})
+
+	t.Run("clean import", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		resp := invoke(t, m, route, "text/csv; charset=utf-8", csvBody(row("Sword"), row("Shield")))
+
+		if len(m.importRows) != 2 || m.importStrict {
+			t.Fatalf("Unexpected import: %+v strict %t", m.importRows, m.importStrict)
+		}
+		req := m.importRows[0].Request
+		if req.Name != "Sword" || req.Description != "A Sword." || req.OwnerID != ownerID || req.LocationID != roomID || req.InventoryID != ownerID {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+		if len(resp.Data) != 2 || resp.Data[0].Line != 2 || !resp.Data[0].Imported || resp.Data[1].Line != 3 || !resp.Data[1].Imported {
+			t.Errorf("Unexpected results: %+v", resp.Data)
+		}
+	})
+
+	t.Run("bad row", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		resp := invoke(t, m, route, "text/csv", csvBody(row("Sword"), "Shield,too,few", row("Helm")))
+
+		if len(m.importRows) != 2 || m.importRows[1].Line != 4 {
+			t.Fatalf("Unexpected import: %+v", m.importRows)
+		}
+		if len(resp.Data) != 3 {
+			t.Fatalf("Unexpected results: %+v", resp.Data)
+		}
+		bad := resp.Data[1]
+		if bad.Line != 3 || bad.Imported || bad.Error != "invalid argument: malformed row: wrong number of fields" {
+			t.Errorf("Unexpected result: %+v", bad)
+		}
+		if !resp.Data[0].Imported || !resp.Data[2].Imported {
+			t.Errorf("Unexpected results: %+v", resp.Data)
+		}
+	})
+
+	t.Run("weight", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+		body := strings.NewReader("name,description,ownerID,locationID,inventoryID,weight\n" +
+			row("Sword") + ",7\n" +
+			row("Shield") + ",heavy\n" +
+			row("Helm") + ",\n")
+
+		resp := invoke(t, m, route, "text/csv", body)
+
+		if len(m.importRows) != 2 || m.importRows[0].Request.Weight != 7 || m.importRows[1].Line != 4 || m.importRows[1].Request.Weight != 0 {
+			t.Fatalf("Unexpected import: %+v", m.importRows)
+		}
+		if len(resp.Data) != 3 {
+			t.Fatalf("Unexpected results: %+v", resp.Data)
+		}
+		bad := resp.Data[1]
+		if bad.Line != 3 || bad.Imported || bad.Error != "invalid argument: invalid weight: 'heavy'" {
+			t.Errorf("Unexpected result: %+v", bad)
+		}
+	})
+
+	t.Run("bad row strict", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		resp := invoke(t, m, route+"?strict=true", "text/csv", csvBody(row("Sword"), "Shield,too,few", row("Helm")))
+
+		if m.importCalled {
+			t.Error("Unexpected import")
+		}
+		if len(resp.Data) != 3 || resp.Data[1].Error == "" {
+			t.Fatalf("Unexpected results: %+v", resp.Data)
+		}
+		for _, result := range resp.Data {
+			if result.Imported {
+				t.Errorf("Unexpected imported result: %+v", result)
+			}
+		}
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		rows := make([]string, arcade.ItemsImportMaxRows+1)
+		for i := range rows {
+			rows[i] = row("Sword")
+		}
+		router := mux.NewRouter()
+		ahttp.ItemsService{}.Register(router)
+		r := httptest.NewRequest(http.MethodPost, route, csvBody(rows...))
+		r.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		checkRespError(t, w, http.StatusBadRequest, "invalid argument: import exceeds maximum rows 10000")
+	})
+}
+
+func TestItemsServiceTopOwners(t *testing.T) {
+	route := ahttp.ItemsRoute + "/top-owners"
+
+	t.Run("invalid limit", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, route+"?limit=0", nil),
+			http.StatusBadRequest, "invalid argument: invalid limit query parameter: '0'",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.topOwnersCalled {
+			t.Error("expected top owners to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		counts := []arcade.OwnerCount{
+			{OwnerID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf", Count: 7},
+			{OwnerID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b", Count: 3},
+		}
+		m := &mockItemsStorage{t: t, counts: counts, limit: arcade.DefaultItemsFilterLimit}
+
+		w := invokeItemsService(t, m, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var countsResp arcade.OwnerCountsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&countsResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(countsResp.Data) != 2 || countsResp.Data[0] != counts[0] || countsResp.Data[1] != counts[1] {
+			t.Errorf("Unexpected counts: %+v", countsResp.Data)
+		}
+	})
+}
+
+func TestItemsServiceOrphans(t *testing.T) {
+	const (
+		itemID  = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		ownerID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+	route := ahttp.ItemsRoute + "/orphans"
+
+	t.Run("storage error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	for _, test := range []struct {
+		name, method, route string
+		fixed               bool
+	}{
+		{"find", http.MethodGet, route, false},
+		{"fix", http.MethodPost, route + "/fix", true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockItemsStorage{t: t, items: []arcade.Item{{ID: itemID, OwnerID: ownerID}}}
+
+			w := invokeItemsService(t, m, test.method, test.route, nil)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Unexpected status: %d", resp.StatusCode)
+			}
+			if m.getCalled || m.orphansCalled == test.fixed || m.fixOrphansCalled != test.fixed {
+				t.Errorf("Unexpected calls: orphans %t, fix %t, get %t", m.orphansCalled, m.fixOrphansCalled, m.getCalled)
+			}
+
+			var itemsResp arcade.ItemsResponse
+			if err := json.NewDecoder(resp.Body).Decode(&itemsResp); err != nil {
+				t.Fatalf("Failed to decode response: %s", err)
+			}
+			if len(itemsResp.Data) != 1 || itemsResp.Data[0].ID != itemID || itemsResp.Data[0].Hyperlinks == nil {
+				t.Errorf("Unexpected items: %+v", itemsResp.Data)
+			}
+		})
+	}
+}
+
+func TestItemsServiceSearch(t *testing.T) {
+	t.Run("filter error", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodGet, ahttp.ItemsRoute+"/search", nil),
+			http.StatusBadRequest, "invalid argument: empty q query parameter",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"/search?q=sword", nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.searchCalled {
+			t.Error("expected search to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		items := []arcade.Item{
+			{ID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf", Name: "Sword of Martin"},
+			{ID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b", Name: "Dagger", Description: "Smaller than a sword"},
+		}
+		m := &mockItemsStorage{
+			t:            t,
+			items:        items,
+			searchFilter: arcade.ItemsSearchFilter{Query: "sword", Limit: 2, Offset: 4},
+		}
+
+		w := invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute+"/search?q=sword&limit=2&offset=4", nil)
+
+		if !m.searchCalled {
+			t.Error("expected search to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var itemsResp arcade.ItemsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&itemsResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		if len(itemsResp.Data) != len(items) {
+			t.Fatalf("Unexpected items response data length: %d", len(itemsResp.Data))
+		}
+		for i := range items {
+			if itemsResp.Data[i].ID != items[i].ID {
+				t.Errorf("Unexpected item order: %d: %s", i, itemsResp.Data[i].ID)
+			}
+		}
+		if itemsResp.Hyperlinks == nil || itemsResp.Hyperlinks.Next != "/items/search?limit=2&offset=6&q=sword" {
+			t.Errorf("Unexpected hyperlinks: %+v", itemsResp.Hyperlinks)
+		}
+	})
+}
+
+func TestItemsServiceHead(t *testing.T) {
+	const id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: fmt.Errorf("failed to check item exists: %w: invalid item id: '42'", cerrors.ErrInvalidArgument)}
+
+		w := invokeItemsService(t, m, http.MethodHead, ahttp.ItemsRoute+"/42", nil)
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	for _, exists := range []bool{true, false} {
+		m := &mockItemsStorage{t: t, itemID: id, exists: exists}
+
+		w := invokeItemsService(t, m, http.MethodHead, ahttp.ItemsRoute+"/"+id, nil)
+
+		if !m.existsCalled {
+			t.Error("expected exists to be called")
+		}
+		expected := http.StatusOK
+		if !exists {
+			expected = http.StatusNotFound
+		}
+		if w.Result().StatusCode != expected {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	}
+}
+
+func TestItemsServiceSwap(t *testing.T) {
+	const (
+		itemID  = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		otherID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+
+	t.Run("empty body", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, ahttp.ItemsRoute+"/swap", nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("wrong number of items", func(t *testing.T) {
+		body := strings.NewReader(`{"itemIDs": ["` + itemID + `"]}`)
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, ahttp.ItemsRoute+"/swap", body),
+			http.StatusBadRequest, "invalid argument: swap requires exactly two itemIDs",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{
+			t:   t,
+			err: fmt.Errorf("failed to swap item locations: %w: cannot swap an item with itself", cerrors.ErrInvalidArgument),
+		}
+
+		body := strings.NewReader(`{"itemIDs": ["` + itemID + `", "` + itemID + `"]}`)
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodPost, ahttp.ItemsRoute+"/swap", body),
+			http.StatusBadRequest, "failed to swap item locations: invalid argument: cannot swap an item with itself",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, itemID: itemID, otherID: otherID}
+
+		body := strings.NewReader(`{"itemIDs": ["` + itemID + `", "` + otherID + `"]}`)
+		w := invokeItemsService(t, m, http.MethodPost, ahttp.ItemsRoute+"/swap", body)
+
+		if !m.swapCalled {
+			t.Error("expected swap to be called")
+		}
+		if w.Result().StatusCode != http.StatusNoContent {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
 }
 
-func TestItemsServiceList(t *testing.T) {
+func TestItemsServiceRemoveMany(t *testing.T) {
+	const (
+		itemID  = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		otherID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+
+	t.Run("empty body", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, ahttp.ItemsRoute+"/batch-delete", nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		ids := make([]string, ahttp.DefaultMaxBatchSize+1)
+		for i := range ids {
+			ids[i] = `"` + itemID + `"`
+		}
+		body := strings.NewReader(`{"itemIDs": [` + strings.Join(ids, ",") + `]}`)
+
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, ahttp.ItemsRoute+"/batch-delete", body),
+			http.StatusBadRequest, "invalid argument: batch exceeds maximum size 100",
+		)
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		err := errors.New("unknown error")
-		m := &mockItemsStorage{t: t, err: err}
+		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
 
+		body := strings.NewReader(`{"itemIDs": ["` + itemID + `"]}`)
 		checkRespError(
-			t, invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute, nil),
+			t, invokeItemsService(t, m, http.MethodPost, ahttp.ItemsRoute+"/batch-delete", body),
 			http.StatusInternalServerError, "unknown error",
 		)
 
-		if !m.listCalled {
-			t.Error("expected list to be called")
+		if !m.removeManyCalled {
+			t.Error("expected remove many to be called")
 		}
 	})
 
 	t.Run("success", func(t *testing.T) {
-		items := []arcade.Item{
-			{
-				ID:          "c39761fc-5096-4b1c-9d02-c75730b7b8bf",
-				Name:        "Drunen",
-				Description: "Son of Martin",
-				OwnerID:     "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
-				LocationID:  "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
-				InventoryID: "2564cd4e-ae30-42a9-aaea-a1203ef0414b",
-			},
-		}
-		m := &mockItemsStorage{t: t, items: items}
+		m := &mockItemsStorage{t: t, itemIDs: []string{itemID, otherID}, count: 1}
 
-		w := invokeItemsService(t, m, http.MethodGet, ahttp.ItemsRoute, nil)
+		body := strings.NewReader(`{"itemIDs": ["` + itemID + `", "` + otherID + `"]}`)
+		w := invokeItemsService(t, m, http.MethodPost, ahttp.ItemsRoute+"/batch-delete", body)
 
-		if !m.listCalled {
-			t.Error("expected list to be called")
+		if !m.removeManyCalled {
+			t.Error("expected remove many to be called")
 		}
 		resp := w.Result()
 		if resp.StatusCode != http.StatusOK {
 			t.Errorf("Unexpected status: %d", resp.StatusCode)
 		}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			t.Errorf("Failed to read response body")
-		}
-		defer resp.Body.Close()
-
-		var itemsResp arcade.ItemsResponse
-		err = json.Unmarshal(body, &itemsResp)
-		if err != nil {
-			t.Errorf("Failed to json unmarshal response: %s", err)
-		}
-
-		if len(itemsResp.Data) != len(items) {
-			t.Fatalf("Unexpected items response data length: %d", len(itemsResp.Data))
+		var removeResp arcade.ItemsRemoveResponse
+		if err := json.NewDecoder(resp.Body).Decode(&removeResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
 		}
-
-		item := itemsResp.Data[0]
-		if item.ID != items[0].ID ||
-			item.Name != items[0].Name ||
-			item.Description != items[0].Description ||
-			item.OwnerID != items[0].OwnerID ||
-			item.LocationID != items[0].LocationID ||
-			item.InventoryID != items[0].InventoryID {
-			t.Errorf("Unexpected response data")
+		if removeResp.Data.Count != 1 {
+			t.Errorf("Unexpected count: %d", removeResp.Data.Count)
 		}
 	})
 }
@@ -175,6 +923,9 @@ func TestItemsServiceGet(t *testing.T) {
 			r.InventoryID != inventoryID {
 			t.Errorf("Unexpected response data")
 		}
+		if r.Hyperlinks == nil || r.Hyperlinks.Self != "/items/"+id {
+			t.Errorf("Unexpected self link: %+v", r.Hyperlinks)
+		}
 	})
 }
 
@@ -202,6 +953,97 @@ func TestItemsServiceCreate(t *testing.T) {
 		)
 	})
 
+	t.Run("numeric id", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+				`{"name":"`+name+`","description":"`+description+`","ownerID":42,"locationID":"`+locationID+`","inventoryID":"`+inventoryID+`"}`,
+			)),
+			http.StatusBadRequest, "invalid argument: ownerID must be a string",
+		)
+	})
+
+	t.Run("non-coercible id", func(t *testing.T) {
+		svc := ahttp.ItemsService{CoerceNumericIDs: true}
+		checkRespError(
+			t, invokeService(t, svc, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+				`{"name":"`+name+`","description":"`+description+`","ownerID":"`+ownerID+`","locationID":true,"inventoryID":"`+inventoryID+`"}`,
+			)),
+			http.StatusBadRequest, "invalid argument: locationID must be a string",
+		)
+	})
+
+	t.Run("coerced numeric id", func(t *testing.T) {
+		req := arcade.ItemRequest{
+			Name:        name,
+			Description: description,
+			OwnerID:     "42",
+			LocationID:  locationID,
+			InventoryID: inventoryID,
+		}
+		m := &mockItemsStorage{t: t, req: req}
+		svc := ahttp.ItemsService{Storage: m, CoerceNumericIDs: true}
+
+		w := invokeService(t, svc, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+			`{"name":"`+name+`","description":"`+description+`","ownerID":42,"locationID":"`+locationID+`","inventoryID":"`+inventoryID+`"}`,
+		))
+
+		if !m.createCalled {
+			t.Errorf("expected create to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("unknown field ignored", func(t *testing.T) {
+		req := arcade.ItemRequest{
+			Name:        name,
+			Description: description,
+			OwnerID:     ownerID,
+			LocationID:  locationID,
+			InventoryID: inventoryID,
+		}
+		m := &mockItemsStorage{t: t, req: req}
+
+		w := invokeService(t, ahttp.ItemsService{Storage: m}, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+			`{"name":"`+name+`","description":"`+description+`","ownerID":"`+ownerID+`","locationID":"`+locationID+`","inventoryID":"`+inventoryID+`","colour":"red"}`,
+		))
+
+		if !m.createCalled {
+			t.Errorf("expected create to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("unknown field rejected", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+		svc := ahttp.ItemsService{Storage: m, RejectUnknownFields: true}
+
+		checkRespError(
+			t, invokeService(t, svc, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+				`{"name":"`+name+`","description":"`+description+`","ownerID":"`+ownerID+`","locationID":"`+locationID+`","inventoryID":"`+inventoryID+`","colour":"red"}`,
+			)),
+			http.StatusBadRequest, `invalid argument: invalid body: json: unknown field "colour"`,
+		)
+
+		if m.createCalled {
+			t.Errorf("expected create not to be called")
+		}
+	})
+
+	t.Run("trailing data rejected", func(t *testing.T) {
+		svc := ahttp.ItemsService{RejectUnknownFields: true}
+
+		checkRespError(
+			t, invokeService(t, svc, http.MethodPost, ahttp.ItemsRoute, strings.NewReader(
+				`{"name":"`+name+`","description":"`+description+`","ownerID":"`+ownerID+`"} {}`,
+			)),
+			http.StatusBadRequest, "invalid argument: invalid body: invalid character after top-level value",
+		)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
 		body := bytes.NewBufferString(
@@ -234,8 +1076,8 @@ func TestItemsServiceCreate(t *testing.T) {
 			OwnerID:     ownerID,
 			LocationID:  locationID,
 			InventoryID: inventoryID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockItemsStorage{t: t, req: req, item: item}
 		body := bytes.NewBufferString(
@@ -300,6 +1142,37 @@ func TestItemsServiceUpdate(t *testing.T) {
 		)
 	})
 
+	t.Run("immutable fields", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		fields := `"name":"` + name + `","description":"` + description + `","ownerID": "` + ownerID + `","locationID":"` + locationID + `","inventoryID":"` + inventoryID + `"`
+		created := `,"created":"2020-01-01T00:00:00Z"`
+
+		checkRespError(
+			t, invokeService(t, ahttp.ItemsService{StrictImmutableFields: true}, http.MethodPut, ahttp.ItemsRoute+"/"+id, bytes.NewBufferString("{"+fields+created+"}")),
+			http.StatusBadRequest, "invalid argument: created is immutable",
+		)
+		checkRespError(
+			t, invokeService(t, ahttp.ItemsService{StrictImmutableFields: true}, http.MethodPut, ahttp.ItemsRoute+"/"+id, bytes.NewBufferString(`{"itemID":"42",`+fields+"}")),
+			http.StatusBadRequest, "invalid argument: itemID is immutable",
+		)
+
+		// The unchanged id is allowed when strict, and both are ignored when lenient.
+		for _, strict := range []bool{true, false} {
+			body := fields + `,"itemID":"` + id + `"`
+			if !strict {
+				body += created
+			}
+			m := &mockItemsStorage{t: t, itemID: id, req: req, item: arcade.Item{ID: id}}
+			s := ahttp.ItemsService{Storage: m, StrictImmutableFields: strict}
+
+			w := invokeService(t, s, http.MethodPut, ahttp.ItemsRoute+"/"+id, bytes.NewBufferString("{"+body+"}"))
+
+			if w.Result().StatusCode != http.StatusOK || !m.updateCalled {
+				t.Errorf("Unexpected status for strict %t: %d", strict, w.Result().StatusCode)
+			}
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		m := &mockItemsStorage{t: t, err: errors.New("unknown error")}
 		body := bytes.NewBufferString(
@@ -332,8 +1205,8 @@ func TestItemsServiceUpdate(t *testing.T) {
 			OwnerID:     ownerID,
 			LocationID:  locationID,
 			InventoryID: inventoryID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockItemsStorage{t: t, req: req, itemID: id, item: item}
 		body := bytes.NewBufferString(
@@ -374,6 +1247,73 @@ func TestItemsServiceUpdate(t *testing.T) {
 	})
 }
 
+func TestItemsServiceTransfer(t *testing.T) {
+	const (
+		id      = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		ownerID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		route   = ahttp.ItemsRoute + "/" + id + "/transfer"
+	)
+
+	t.Run("missing body", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, route, nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		checkRespError(
+			t, invokeItemsService(t, nil, http.MethodPost, route, bytes.NewBufferString(`invalid json`)),
+			http.StatusBadRequest, "invalid argument: invalid body: ",
+		)
+	})
+
+	t.Run("current owner", func(t *testing.T) {
+		m := &mockItemsStorage{
+			t: t, itemID: id, req: arcade.ItemRequest{OwnerID: ownerID},
+			err: fmt.Errorf("failed to transfer item: %w: item is already owned by '%s'", cerrors.ErrInvalidArgument, ownerID),
+		}
+
+		checkRespError(
+			t, invokeItemsService(t, m, http.MethodPost, route, bytes.NewBufferString(`{"ownerID":"`+ownerID+`"}`)),
+			http.StatusBadRequest, "failed to transfer item: invalid argument: item is already owned by '"+ownerID+"'",
+		)
+
+		if !m.transferCalled {
+			t.Errorf("expected transfer to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockItemsStorage{
+			t: t, itemID: id, req: arcade.ItemRequest{OwnerID: ownerID},
+			item: arcade.Item{ID: id, OwnerID: ownerID},
+		}
+
+		w := invokeItemsService(t, m, http.MethodPost, route, bytes.NewBufferString(`{"ownerID":"`+ownerID+`"}`))
+
+		if !m.transferCalled {
+			t.Errorf("expected transfer to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		defer resp.Body.Close()
+
+		var itemResp arcade.ItemResponse
+		if err := json.NewDecoder(resp.Body).Decode(&itemResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		if itemResp.Data.ID != id || itemResp.Data.OwnerID != ownerID {
+			t.Errorf("Unexpected response data: %+v", itemResp.Data)
+		}
+		if itemResp.Data.Hyperlinks == nil {
+			t.Errorf("Expected hyperlinks")
+		}
+	})
+}
+
 func TestItemsServiceRemove(t *testing.T) {
 	const (
 		id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
@@ -432,12 +1372,36 @@ type (
 		item  arcade.Item
 		items []arcade.Item
 
+		getManyIDs []string
+
+		listFilter   arcade.ItemsFilter
+		searchFilter arcade.ItemsSearchFilter
+		otherID      string
+		since        time.Time
+		sinceID      string
+		limit        int
+		counts       []arcade.OwnerCount
+		importRows   []arcade.ItemImportRow
+		importStrict bool
+		asOf         time.Time
+		itemIDs      []string
+		count        int
+		playerID     string
+		weight       int
+		grouped      map[arcade.ItemLocationID]int
+
 		listCalled, getCalled, createCalled, updateCalled, removeCalled bool
+		searchCalled, swapCalled, existsCalled, changedSinceCalled      bool
+		topOwnersCalled, importCalled, snapshotCalled, removeManyCalled bool
+		orphansCalled, fixOrphansCalled, transferCalled                 bool
+		totalWeightCalled, groupedCalled                                bool
+		exists                                                          bool
 	}
 )
 
-func (m *mockItemsStorage) List(context.Context, arcade.ItemsFilter) ([]arcade.Item, error) {
+func (m *mockItemsStorage) List(ctx context.Context, filter arcade.ItemsFilter) ([]arcade.Item, error) {
 	m.listCalled = true
+	m.listFilter = filter
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -455,6 +1419,22 @@ func (m *mockItemsStorage) Get(ctx context.Context, itemID string) (arcade.Item,
 	return m.item, nil
 }
 
+func (m *mockItemsStorage) GetMany(ctx context.Context, itemIDs []string) ([]arcade.Item, error) {
+	m.getManyIDs = itemIDs
+	if m.err != nil {
+		return nil, m.err
+	}
+	items := make([]arcade.Item, 0)
+	for _, item := range append(m.items, m.item) {
+		for _, id := range itemIDs {
+			if item.ID == id {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
 func (m *mockItemsStorage) Create(ctx context.Context, req arcade.ItemRequest) (arcade.Item, error) {
 	m.createCalled = true
 	if m.err != nil {
@@ -490,3 +1470,142 @@ func (m *mockItemsStorage) Remove(ctx context.Context, itemID string) error {
 	}
 	return nil
 }
+
+func (m *mockItemsStorage) RemoveMany(ctx context.Context, itemIDs []string) (int, error) {
+	m.removeManyCalled = true
+	if m.err != nil {
+		return 0, m.err
+	}
+	if strings.Join(m.itemIDs, ",") != strings.Join(itemIDs, ",") {
+		m.t.Fatalf("remove many: expected itemIDs %v, actual itemIDs %v", m.itemIDs, itemIDs)
+	}
+	return m.count, nil
+}
+
+func (m *mockItemsStorage) Search(ctx context.Context, filter arcade.ItemsSearchFilter) ([]arcade.Item, error) {
+	m.searchCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.searchFilter != filter {
+		m.t.Fatalf("search: expected filter %+v, actual filter %+v", m.searchFilter, filter)
+	}
+	return m.items, nil
+}
+
+func (m *mockItemsStorage) SwapLocations(ctx context.Context, itemID, otherID string) error {
+	m.swapCalled = true
+	if m.err != nil {
+		return m.err
+	}
+	if m.itemID != itemID || m.otherID != otherID {
+		m.t.Fatalf("swap: expected itemIDs %s %s, actual itemIDs %s %s", m.itemID, m.otherID, itemID, otherID)
+	}
+	return nil
+}
+
+func (m *mockItemsStorage) ChangedSince(ctx context.Context, since arcade.Timestamp, sinceID string, limit int) ([]arcade.Item, error) {
+	m.changedSinceCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	if !m.since.Equal(since.Time) || m.sinceID != sinceID || m.limit != limit {
+		m.t.Fatalf("changed since: expected %s %s %d, actual %s %s %d", m.since, m.sinceID, m.limit, since, sinceID, limit)
+	}
+	return m.items, nil
+}
+
+func (m *mockItemsStorage) Import(ctx context.Context, rows []arcade.ItemImportRow, strict bool) ([]arcade.ItemImportResult, error) {
+	m.importCalled = true
+	m.importRows, m.importStrict = rows, strict
+	if m.err != nil {
+		return nil, m.err
+	}
+	results := make([]arcade.ItemImportResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, arcade.ItemImportResult{Line: row.Line, Imported: true, ItemID: row.Request.Name})
+	}
+	return results, nil
+}
+
+func (m *mockItemsStorage) TopOwners(ctx context.Context, limit int) ([]arcade.OwnerCount, error) {
+	m.topOwnersCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.limit != limit {
+		m.t.Fatalf("top owners: expected limit %d, actual limit %d", m.limit, limit)
+	}
+	return m.counts, nil
+}
+
+func (m *mockItemsStorage) FindOrphans(ctx context.Context) ([]arcade.Item, error) {
+	m.orphansCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.items, nil
+}
+
+func (m *mockItemsStorage) FixOrphans(ctx context.Context) ([]arcade.Item, error) {
+	m.fixOrphansCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.items, nil
+}
+
+func (m *mockItemsStorage) Transfer(ctx context.Context, itemID, ownerID string) (arcade.Item, error) {
+	m.transferCalled = true
+	if m.itemID != itemID {
+		m.t.Errorf("\nExpected itemID: %s\nActual itemID:   %s", m.itemID, itemID)
+	}
+	if m.req.OwnerID != ownerID {
+		m.t.Errorf("\nExpected ownerID: %s\nActual ownerID:   %s", m.req.OwnerID, ownerID)
+	}
+	if m.err != nil {
+		return arcade.Item{}, m.err
+	}
+	return m.item, nil
+}
+
+func (m *mockItemsStorage) TotalWeight(ctx context.Context, playerID string) (int, error) {
+	m.totalWeightCalled = true
+	if m.playerID != playerID {
+		m.t.Errorf("\nExpected playerID: %s\nActual playerID:   %s", m.playerID, playerID)
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.weight, nil
+}
+
+func (m *mockItemsStorage) ListGroupedByLocation(ctx context.Context, ownerID string) (map[arcade.ItemLocationID]int, error) {
+	m.groupedCalled = true
+	if m.playerID != ownerID {
+		m.t.Errorf("\nExpected ownerID: %s\nActual ownerID:   %s", m.playerID, ownerID)
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.grouped, nil
+}
+
+func (m *mockItemsStorage) Snapshot(ctx context.Context) (time.Time, error) {
+	m.snapshotCalled = true
+	if m.err != nil {
+		return time.Time{}, m.err
+	}
+	return m.asOf, nil
+}
+
+func (m *mockItemsStorage) Exists(ctx context.Context, itemID string) (bool, error) {
+	m.existsCalled = true
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.itemID != itemID {
+		m.t.Fatalf("exists: expected itemID %s, actual itemID %s", m.itemID, itemID)
+	}
+	return m.exists, nil
+}
@@ -0,0 +1,132 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	chttp "arcadium.dev/core/http"
+)
+
+// ConcurrencyLimit returns a middleware which caps the total weight of the
+// requests served at once for each entity, e.g. players or rooms, at the
+// given capacity. A request which would exceed the capacity of its entity is
+// refused with a 503 Service Unavailable and a Retry-After of retryAfter,
+// rather than piling onto the connection pool. A request of zero weight is
+// exempt.
+func ConcurrencyLimit(capacity int64, retryAfter time.Duration, weight func(*http.Request) int64) mux.MiddlewareFunc {
+	var (
+		mu         sync.Mutex
+		semaphores = make(map[string]*semaphore)
+	)
+	semaphoreFor := func(entity string) *semaphore {
+		mu.Lock()
+		defer mu.Unlock()
+		s, ok := semaphores[entity]
+		if !ok {
+			s = &semaphore{size: capacity}
+			semaphores[entity] = s
+		}
+		return s
+	}
+	seconds := strconv.Itoa(int((retryAfter + time.Second - 1) / time.Second))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := weight(r)
+			if n <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entity := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+			s := semaphoreFor(entity)
+			if n > capacity {
+				n = capacity
+			}
+			if !s.tryAcquire(n) {
+				w.Header().Set("Retry-After", seconds)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_ = json.NewEncoder(w).Encode(struct {
+					Error chttp.ResponseError `json:"error"`
+				}{chttp.ResponseError{
+					Status: http.StatusServiceUnavailable,
+					Detail: "too many concurrent " + entity + " requests",
+				}})
+				return
+			}
+			defer s.release(n)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequestWeight is the weight of a request against the concurrency limit of
+// its entity, given the template of its route. Single row requests, such as
+// a get of /items/{itemID} or a create of /items, are exempt. A list, a
+// search, or any other route weighs 1, and the traversals of the room graph,
+// the route between rooms and the nearby rooms, weigh 2.
+func RequestWeight(r *http.Request) int64 {
+	template := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if t, err := route.GetPathTemplate(); err == nil {
+			template = t
+		}
+	}
+
+	segments := strings.Split(strings.Trim(template, "/"), "/")
+	switch {
+	case strings.Contains(template, "/route/") || strings.HasSuffix(template, "/nearby"):
+		return 2
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		return 1
+	case len(segments) == 1:
+		return 0
+	case len(segments) == 2 && strings.HasPrefix(segments[1], "{"):
+		return 0
+	}
+	return 1
+}
+
+// semaphore is a weighted semaphore, which is never waited upon.
+type semaphore struct {
+	mu         sync.Mutex
+	size, used int64
+}
+
+func (s *semaphore) tryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.used+n > s.size {
+		return false
+	}
+	s.used += n
+	return true
+}
+
+func (s *semaphore) release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used -= n
+}
@@ -0,0 +1,122 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	var (
+		entered = make(chan struct{})
+		release = make(chan struct{})
+	)
+	router := mux.NewRouter()
+	router.Use(ahttp.ConcurrencyLimit(2, 1500*time.Millisecond, ahttp.RequestWeight))
+	router.HandleFunc("/items", func(http.ResponseWriter, *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/rooms", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet)
+	router.HandleFunc("/items/{itemID}", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet)
+
+	serve := func(target string) *http.Response {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w.Result()
+	}
+
+	// Saturate the items semaphore with two lists.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp := serve("/items"); resp.StatusCode != http.StatusOK {
+				t.Errorf("Unexpected status: %d", resp.StatusCode)
+			}
+		}()
+		<-entered
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		resp := serve("/items")
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		if h := resp.Header.Get("Retry-After"); h != "2" {
+			t.Errorf("Unexpected retry after: %s", h)
+		}
+	})
+
+	t.Run("exempt get", func(t *testing.T) {
+		if resp := serve("/items/42"); resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("other entity", func(t *testing.T) {
+		if resp := serve("/rooms"); resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+	})
+
+	close(release)
+	wg.Wait()
+
+	t.Run("released", func(t *testing.T) {
+		done := make(chan *http.Response)
+		go func() { done <- serve("/items") }()
+		<-entered
+		if resp := <-done; resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestRequestWeight(t *testing.T) {
+	router := mux.NewRouter()
+	var weight int64
+	handler := func(w http.ResponseWriter, r *http.Request) { weight = ahttp.RequestWeight(r) }
+	for _, route := range []string{"/items", "/items/search", "/items/{itemID}", "/rooms/{roomID}/route/{toID}", "/rooms/{roomID}/nearby"} {
+		router.HandleFunc(route, handler)
+	}
+
+	for _, test := range []struct {
+		method, target string
+		expected       int64
+	}{
+		{http.MethodGet, "/items", 1},
+		{http.MethodPost, "/items", 0},
+		{http.MethodGet, "/items/search", 1},
+		{http.MethodGet, "/items/42", 0},
+		{http.MethodDelete, "/items/42", 0},
+		{http.MethodGet, "/rooms/1/route/2", 2},
+		{http.MethodGet, "/rooms/1/nearby", 2},
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.target, nil))
+		if weight != test.expected {
+			t.Errorf("Unexpected weight of %s %s: %d", test.method, test.target, weight)
+		}
+	}
+}
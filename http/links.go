@@ -15,7 +15,6 @@
 package http // import "arcadium.dev/arcade/http"
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,7 +22,6 @@ import (
 	"github.com/gorilla/mux"
 
 	cerrors "arcadium.dev/core/errors"
-	chttp "arcadium.dev/core/http"
 
 	"arcadium.dev/arcade"
 )
@@ -36,17 +34,49 @@ type (
 	// Links is used to manage the link assets.
 	LinksService struct {
 		Storage arcade.LinksStorage
+
+		// MaxOffset, when non-zero, is the largest offset a list request
+		// may use.
+		MaxOffset int
+
+		// DefaultSort is the order of a list when the request does not give
+		// one, the zero value falls back to ascending by creation time.
+		DefaultSort arcade.Sort
+
+		// CoerceNumericIDs, when set, accepts ids given as json numbers in
+		// create and update requests, converting them into strings.
+		CoerceNumericIDs bool
+
+		// RejectUnknownFields, when set, rejects a request body giving a
+		// field the request does not know, rather than ignoring it.
+		RejectUnknownFields bool
+
+		// StrictQueryParams, when set, rejects a list request given a query
+		// parameter it does not know, rather than ignoring it.
+		StrictQueryParams bool
+
+		// TrailingSlash is the handling of a route given with a trailing
+		// slash.
+		TrailingSlash TrailingSlash
+
+		// StrictImmutableFields, when set, rejects an update request giving
+		// the created timestamp or a different linkID, rather than ignoring
+		// them.
+		StrictImmutableFields bool
 	}
 )
 
 // Register sets up the http handler for this service with the given router.
 func (s LinksService) Register(router *mux.Router) {
 	r := router.PathPrefix(LinksRoute).Subrouter()
+	r.HandleFunc("/dangling", s.Dangling).Methods(http.MethodGet)
 	r.HandleFunc("", s.List).Methods(http.MethodGet)
 	r.HandleFunc("/{linkID}", s.Get).Methods(http.MethodGet)
+	r.HandleFunc("/{linkID}/reverse", s.GetReverse).Methods(http.MethodGet)
 	r.HandleFunc("", s.Create).Methods(http.MethodPost)
 	r.HandleFunc("/{linkID}", s.Update).Methods(http.MethodPut)
 	r.HandleFunc("/{linkID}", s.Remove).Methods(http.MethodDelete)
+	handleTrailingSlash(router, r, s.TrailingSlash)
 }
 
 // Name returns the name of the service.
@@ -61,20 +91,68 @@ func (LinksService) Shutdown() {}
 func (s LinksService) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// TODO: parse query params
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "ownerID", "createdBy", "createdWithin", "traversalCountAtLeast", "limit", "offset"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	// Create the filter.
+	filter, err := arcade.NewLinksFilter(r, s.DefaultSort)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	if s.MaxOffset > 0 && filter.Offset > s.MaxOffset {
+		response(w, r, fmt.Errorf(
+			"%w: offset too large, use cursor pagination", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
 
 	// Read list of links.
-	links, err := s.Storage.List(ctx, arcade.LinksFilter{})
+	links, err := s.Storage.List(ctx, filter)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	// Return list as body.
+	resp := arcade.NewLinksResponse(links)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(LinksRoute, resp.Data[i].ID)
+	}
+	resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(links))
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Dangling handles a request to retrieve the links whose location or
+// destination room does not exist.
+func (s LinksService) Dangling(w http.ResponseWriter, r *http.Request) {
+	links, err := s.Storage.FindDangling(r.Context())
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	resp := arcade.NewLinksResponse(links)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(LinksRoute, resp.Data[i].ID)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.NewLinksResponse(links))
+	err = encoder(w, r).Encode(resp)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to create response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -88,16 +166,54 @@ func (s LinksService) Get(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	link, err := s.Storage.Get(ctx, linkID)
+	// Expanding the rooms adds their names to the link.
+	get := s.Storage.Get
+	if values := r.URL.Query()["expand"]; len(values) > 0 {
+		if values[0] != "rooms" {
+			response(w, r, fmt.Errorf(
+				"%w: invalid expand query parameter: '%s'", cerrors.ErrInvalidArgument, values[0],
+			))
+			return
+		}
+		get = s.Storage.GetWithRooms
+	}
+
+	link, err := get(ctx, linkID)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	link.Hyperlinks = selfLink(LinksRoute, link.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.LinkResponse{Data: link})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// GetReverse handles a request to get the link leading back from the
+// destination of a link to its location.
+func (s LinksService) GetReverse(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	linkID := params["linkID"]
+
+	link, err := s.Storage.GetReverse(r.Context(), linkID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	link.Hyperlinks = selfLink(LinksRoute, link.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.LinkResponse{Data: link})
+	err = encoder(w, r).Encode(arcade.LinkResponse{Data: link})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -110,7 +226,7 @@ func (s LinksService) Create(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -118,31 +234,31 @@ func (s LinksService) Create(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
 	var req arcade.LinkRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	link, err := s.Storage.Create(ctx, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	link.Hyperlinks = selfLink(LinksRoute, link.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.LinkResponse{Data: link})
+	err = encoder(w, r).Encode(arcade.LinkResponse{Data: link})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -158,7 +274,7 @@ func (s LinksService) Update(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -166,31 +282,38 @@ func (s LinksService) Update(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
+	if s.StrictImmutableFields {
+		if err := checkImmutable(body, "linkID", linkID); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
 	var req arcade.LinkRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	link, err := s.Storage.Update(ctx, linkID, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	link.Hyperlinks = selfLink(LinksRoute, link.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.LinkResponse{Data: link})
+	err = encoder(w, r).Encode(arcade.LinkResponse{Data: link})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -206,7 +329,7 @@ func (s LinksService) Remove(w http.ResponseWriter, r *http.Request) {
 
 	err := s.Storage.Remove(ctx, linkID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
@@ -19,14 +19,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	cerrors "arcadium.dev/core/errors"
+
 	"arcadium.dev/arcade"
 	ahttp "arcadium.dev/arcade/http"
 )
@@ -45,6 +49,36 @@ func TestLinksServiceShutdown(t *testing.T) {
 }
 
 func TestLinksServiceList(t *testing.T) {
+	t.Run("paging", func(t *testing.T) {
+		m := &mockLinksStorage{t: t}
+
+		invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?limit=25&offset=50", nil)
+
+		if m.listFilter.Limit != 25 || m.listFilter.Offset != 50 {
+			t.Errorf("Unexpected limit and offset: %d, %d", m.listFilter.Limit, m.listFilter.Offset)
+		}
+	})
+
+	t.Run("bad limit", func(t *testing.T) {
+		m := &mockLinksStorage{t: t}
+
+		checkRespError(
+			t, invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?limit=101", nil),
+			http.StatusBadRequest,
+			"invalid argument: invalid limit query parameter: '101'",
+		)
+	})
+
+	t.Run("offset too large", func(t *testing.T) {
+		s := ahttp.LinksService{Storage: &mockLinksStorage{t: t}, MaxOffset: 100}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, ahttp.LinksRoute+"?offset=101", nil),
+			http.StatusBadRequest,
+			"invalid argument: offset too large, use cursor pagination",
+		)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		err := errors.New("unknown error")
 		m := &mockLinksStorage{t: t, err: err}
@@ -108,6 +142,132 @@ func TestLinksServiceList(t *testing.T) {
 			t.Errorf("Unexpected response data")
 		}
 	})
+
+	t.Run("traversal count filter", func(t *testing.T) {
+		checkRespError(
+			t, invokeLinksService(t, nil, http.MethodGet, ahttp.LinksRoute+"?traversalCountAtLeast=-1", nil),
+			http.StatusBadRequest, "invalid argument: invalid traversalCountAtLeast query parameter: '-1'",
+		)
+
+		m := &mockLinksStorage{t: t}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?traversalCountAtLeast=100", nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if c := m.listFilter.TraversalCountAtLeast; c == nil || *c != 100 {
+			t.Errorf("Unexpected filter: %+v", m.listFilter)
+		}
+	})
+
+	t.Run("owner filter", func(t *testing.T) {
+		checkRespError(
+			t, invokeLinksService(t, nil, http.MethodGet, ahttp.LinksRoute+"?ownerID=42", nil),
+			http.StatusBadRequest, "invalid argument: invalid ownerID query parameter: '42'",
+		)
+
+		const ownerID = "db81f22a-90ef-43b8-9a4e-0a5ecf3c8c4e"
+		m := &mockLinksStorage{t: t}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?ownerID="+ownerID, nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if o := m.listFilter.OwnerID; o == nil || o.String() != ownerID {
+			t.Errorf("Unexpected filter: %+v", m.listFilter)
+		}
+	})
+
+	t.Run("created by filter", func(t *testing.T) {
+		checkRespError(
+			t, invokeLinksService(t, nil, http.MethodGet, ahttp.LinksRoute+"?createdBy=", nil),
+			http.StatusBadRequest, "invalid argument: empty createdBy query parameter",
+		)
+
+		m := &mockLinksStorage{t: t}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?createdBy=system", nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if c := m.listFilter.CreatedBy; c == nil || *c != "system" {
+			t.Errorf("Unexpected filter: %+v", m.listFilter)
+		}
+	})
+
+	t.Run("created within filter", func(t *testing.T) {
+		checkRespError(
+			t, invokeLinksService(t, nil, http.MethodGet, ahttp.LinksRoute+"?createdWithin=fortnight", nil),
+			http.StatusBadRequest, "invalid argument: invalid createdWithin query parameter: 'fortnight'",
+		)
+
+		m := &mockLinksStorage{t: t}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"?createdWithin=15m", nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if d := m.listFilter.CreatedWithin; d == nil || *d != 15*time.Minute {
+			t.Errorf("Unexpected filter: %+v", m.listFilter)
+		}
+		if m.listFilter.Sort != (arcade.Sort{Column: "created", Desc: true}) {
+			t.Errorf("Unexpected sort: %+v", m.listFilter.Sort)
+		}
+
+		// A configured default sort is kept.
+		defaultSort := arcade.Sort{Column: "name"}
+		m = &mockLinksStorage{t: t}
+
+		invokeService(t, ahttp.LinksService{Storage: m, DefaultSort: defaultSort}, http.MethodGet, ahttp.LinksRoute+"?createdWithin=15m", nil)
+
+		if m.listFilter.Sort != defaultSort {
+			t.Errorf("Unexpected sort: %+v", m.listFilter.Sort)
+		}
+	})
+}
+
+func TestLinksServiceDangling(t *testing.T) {
+	const (
+		linkID        = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		destinationID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+	route := ahttp.LinksRoute + "/dangling"
+
+	t.Run("storage error", func(t *testing.T) {
+		m := &mockLinksStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeLinksService(t, m, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockLinksStorage{t: t, links: []arcade.Link{{ID: linkID, DestinationID: destinationID}}}
+
+		w := invokeLinksService(t, m, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if !m.danglingCalled || m.getCalled {
+			t.Error("Expected dangling links, not a link named dangling")
+		}
+
+		var linksResp arcade.LinksResponse
+		if err := json.NewDecoder(resp.Body).Decode(&linksResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(linksResp.Data) != 1 || linksResp.Data[0].ID != linkID || linksResp.Data[0].Hyperlinks == nil {
+			t.Errorf("Unexpected links: %+v", linksResp.Data)
+		}
+	})
 }
 
 func TestLinksServiceGet(t *testing.T) {
@@ -175,6 +335,99 @@ func TestLinksServiceGet(t *testing.T) {
 			r.DestinationID != destinationID {
 			t.Errorf("Unexpected response data")
 		}
+		if strings.Contains(string(body), "locationName") || strings.Contains(string(body), "destinationName") {
+			t.Errorf("Unexpected room names: %s", body)
+		}
+	})
+
+	t.Run("invalid expand", func(t *testing.T) {
+		m := &mockLinksStorage{t: t, linkID: id}
+
+		checkRespError(
+			t, invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"/"+id+"?expand=owner", nil),
+			http.StatusBadRequest, "invalid argument: invalid expand query parameter: 'owner'",
+		)
+
+		if m.getCalled || m.getWithRoomsCalled {
+			t.Error("expected get not to be called")
+		}
+	})
+
+	t.Run("expand rooms", func(t *testing.T) {
+		link := arcade.Link{ID: id, Name: name, LocationID: locationID, DestinationID: destinationID}
+		m := &mockLinksStorage{t: t, linkID: id, link: link, locationName: "Hall", destinationName: "Garden"}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"/"+id+"?expand=rooms", nil)
+
+		if !m.getWithRoomsCalled || m.getCalled {
+			t.Error("expected get with rooms to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		defer resp.Body.Close()
+
+		var linkResp arcade.LinkResponse
+		if err := json.NewDecoder(resp.Body).Decode(&linkResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+
+		r := linkResp.Data
+		if r.ID != id || r.LocationID != locationID || r.DestinationID != destinationID {
+			t.Errorf("Unexpected response data: %+v", r)
+		}
+		if r.LocationName != "Hall" || r.DestinationName != "Garden" {
+			t.Errorf("Unexpected room names: %s, %s", r.LocationName, r.DestinationName)
+		}
+	})
+}
+
+func TestLinksServiceGetReverse(t *testing.T) {
+	const (
+		id            = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		reverseID     = "9d5e4e8f-0d57-4bd4-8b1c-1ab0e3a8c2f1"
+		locationID    = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		destinationID = "6a0c1e5b-3a0f-4a43-9b8e-5a7d2c1f4e90"
+	)
+
+	t.Run("not found", func(t *testing.T) {
+		m := &mockLinksStorage{t: t, err: fmt.Errorf("failed to get reverse link: %w", cerrors.ErrNotFound)}
+
+		checkRespError(
+			t, invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"/"+id+"/reverse", nil),
+			http.StatusNotFound, "failed to get reverse link: not found",
+		)
+
+		if !m.getReverseCalled {
+			t.Error("expected get reverse to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		link := arcade.Link{ID: reverseID, Name: "Archway", LocationID: destinationID, DestinationID: locationID}
+		m := &mockLinksStorage{t: t, linkID: id, link: link}
+
+		w := invokeLinksService(t, m, http.MethodGet, ahttp.LinksRoute+"/"+id+"/reverse", nil)
+
+		if !m.getReverseCalled || m.getCalled {
+			t.Error("expected get reverse to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		defer resp.Body.Close()
+
+		var linkResp arcade.LinkResponse
+		if err := json.NewDecoder(resp.Body).Decode(&linkResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+
+		r := linkResp.Data
+		if r.ID != reverseID || r.LocationID != destinationID || r.DestinationID != locationID {
+			t.Errorf("Unexpected response data: %+v", r)
+		}
 	})
 }
 
@@ -234,8 +487,8 @@ func TestLinksServiceCreate(t *testing.T) {
 			OwnerID:       ownerID,
 			LocationID:    locationID,
 			DestinationID: destinationID,
-			Created:       now,
-			Updated:       now,
+			Created:       arcade.Timestamp{Time: now},
+			Updated:       arcade.Timestamp{Time: now},
 		}
 		m := &mockLinksStorage{t: t, req: req, link: link}
 		body := bytes.NewBufferString(
@@ -332,8 +585,8 @@ func TestLinksServiceUpdate(t *testing.T) {
 			OwnerID:       ownerID,
 			LocationID:    locationID,
 			DestinationID: destinationID,
-			Created:       now,
-			Updated:       now,
+			Created:       arcade.Timestamp{Time: now},
+			Updated:       arcade.Timestamp{Time: now},
 		}
 		m := &mockLinksStorage{t: t, req: req, linkID: id, link: link}
 		body := bytes.NewBufferString(
@@ -432,12 +685,25 @@ type (
 		link  arcade.Link
 		links []arcade.Link
 
+		getManyIDs []string
+
 		listCalled, getCalled, createCalled, updateCalled, removeCalled bool
+		traverseCalled, releaseCalled, incrementTraversalCalled         bool
+		danglingCalled, getWithRoomsCalled, getReverseCalled            bool
+
+		listFilter arcade.LinksFilter
+
+		locationName, destinationName string
+
+		hops     int
+		nearby   []arcade.RoomHops
+		hopsRoom string
 	}
 )
 
-func (m *mockLinksStorage) List(context.Context, arcade.LinksFilter) ([]arcade.Link, error) {
+func (m *mockLinksStorage) List(ctx context.Context, filter arcade.LinksFilter) ([]arcade.Link, error) {
 	m.listCalled = true
+	m.listFilter = filter
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -455,6 +721,46 @@ func (m *mockLinksStorage) Get(ctx context.Context, linkID string) (arcade.Link,
 	return m.link, nil
 }
 
+func (m *mockLinksStorage) GetMany(ctx context.Context, linkIDs []string) ([]arcade.Link, error) {
+	m.getManyIDs = linkIDs
+	if m.err != nil {
+		return nil, m.err
+	}
+	links := make([]arcade.Link, 0)
+	for _, link := range append(m.links, m.link) {
+		for _, id := range linkIDs {
+			if link.ID == id {
+				links = append(links, link)
+			}
+		}
+	}
+	return links, nil
+}
+
+func (m *mockLinksStorage) GetWithRooms(ctx context.Context, linkID string) (arcade.Link, error) {
+	m.getWithRoomsCalled = true
+	if m.err != nil {
+		return arcade.Link{}, m.err
+	}
+	if m.linkID != linkID {
+		m.t.Fatalf("get with rooms: expected linkID %s, actual linkID %s", m.linkID, linkID)
+	}
+	link := m.link
+	link.LocationName, link.DestinationName = m.locationName, m.destinationName
+	return link, nil
+}
+
+func (m *mockLinksStorage) GetReverse(ctx context.Context, linkID string) (arcade.Link, error) {
+	m.getReverseCalled = true
+	if m.err != nil {
+		return arcade.Link{}, m.err
+	}
+	if m.linkID != linkID {
+		m.t.Fatalf("get reverse: expected linkID %s, actual linkID %s", m.linkID, linkID)
+	}
+	return m.link, nil
+}
+
 func (m *mockLinksStorage) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link, error) {
 	m.createCalled = true
 	if m.err != nil {
@@ -490,3 +796,54 @@ func (m *mockLinksStorage) Remove(ctx context.Context, linkID string) error {
 	}
 	return nil
 }
+
+func (m *mockLinksStorage) TryTraverse(ctx context.Context, linkID string) error {
+	m.traverseCalled = true
+	if m.err != nil {
+		return m.err
+	}
+	if m.linkID != linkID {
+		m.t.Fatalf("try traverse: expected linkID %s, actual linkID %s", m.linkID, linkID)
+	}
+	return nil
+}
+
+func (m *mockLinksStorage) Release(ctx context.Context, linkID string) error {
+	m.releaseCalled = true
+	if m.err != nil {
+		return m.err
+	}
+	if m.linkID != linkID {
+		m.t.Fatalf("release: expected linkID %s, actual linkID %s", m.linkID, linkID)
+	}
+	return nil
+}
+
+func (m *mockLinksStorage) IncrementTraversal(ctx context.Context, linkID string) error {
+	m.incrementTraversalCalled = true
+	return m.err
+}
+
+func (m *mockLinksStorage) WithinHops(ctx context.Context, roomID string, hops int) ([]arcade.RoomHops, error) {
+	m.hopsRoom, m.hops = roomID, hops
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.nearby, nil
+}
+
+func (m *mockLinksStorage) Route(ctx context.Context, fromID, toID string, hops int) (arcade.Route, error) {
+	m.hops = hops
+	if m.err != nil {
+		return arcade.Route{}, m.err
+	}
+	return arcade.NewRoute(m.links, fromID, toID)
+}
+
+func (m *mockLinksStorage) FindDangling(ctx context.Context) ([]arcade.Link, error) {
+	m.danglingCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.links, nil
+}
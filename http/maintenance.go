@@ -0,0 +1,71 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+const (
+	MaintenanceRoute string = "/maintenance"
+)
+
+type (
+	// MaintenanceService is used to maintain the storage of the assets. It
+	// is meant for operators, and is registered alongside the telemetry
+	// services rather than the api.
+	MaintenanceService struct {
+		Storage arcade.MaintenanceStorage
+	}
+)
+
+// Register sets up the http handler for this service with the given router.
+func (s MaintenanceService) Register(router *mux.Router) {
+	r := router.PathPrefix(MaintenanceRoute).Subrouter()
+	r.HandleFunc("/analyze", s.Analyze).Methods(http.MethodPost)
+}
+
+// Name returns the name of the service.
+func (MaintenanceService) Name() string {
+	return "maintenance"
+}
+
+// Shutdown is a no-op since there no long running processes for this service.
+func (MaintenanceService) Shutdown() {}
+
+// Analyze handles a request to refresh the statistics of the entity tables.
+func (s MaintenanceService) Analyze(w http.ResponseWriter, r *http.Request) {
+	tables, err := s.Storage.Analyze(r.Context())
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.TablesAnalysisResponse{Data: tables})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
@@ -0,0 +1,78 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+type mockMaintenanceStorage struct {
+	tables []arcade.TableAnalysis
+	err    error
+}
+
+func (m mockMaintenanceStorage) Analyze(context.Context) ([]arcade.TableAnalysis, error) {
+	return m.tables, m.err
+}
+
+func TestMaintenanceServiceName(t *testing.T) {
+	var s ahttp.MaintenanceService
+	if s.Name() != "maintenance" {
+		t.Errorf("Unexpected service name: %s", s.Name())
+	}
+}
+
+func TestMaintenanceServiceAnalyze(t *testing.T) {
+	route := ahttp.MaintenanceRoute + "/analyze"
+
+	t.Run("storage error", func(t *testing.T) {
+		s := ahttp.MaintenanceService{Storage: mockMaintenanceStorage{err: errors.New("unknown error")}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodPost, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := mockMaintenanceStorage{tables: []arcade.TableAnalysis{
+			{Table: "players", Analyzed: true},
+			{Table: "rooms", Error: "analyze error"},
+		}}
+
+		w := invokeService(t, ahttp.MaintenanceService{Storage: m}, http.MethodPost, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var analysisResp arcade.TablesAnalysisResponse
+		if err := json.NewDecoder(resp.Body).Decode(&analysisResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(analysisResp.Data) != 2 || analysisResp.Data[0] != m.tables[0] || analysisResp.Data[1] != m.tables[1] {
+			t.Errorf("Unexpected tables: %+v", analysisResp.Data)
+		}
+	})
+}
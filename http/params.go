@@ -0,0 +1,49 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+// checkQueryParams returns an invalid argument error naming a query
+// parameter of the request which is not one of the known parameters. The
+// pretty parameter, accepted by every route, is always known.
+func checkQueryParams(r *http.Request, known ...string) error {
+	var unknown []string
+	for name := range r.URL.Query() {
+		if name != "pretty" && !contains(known, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%w: unknown query parameter '%s'", cerrors.ErrInvalidArgument, unknown[0])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
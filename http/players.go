@@ -15,15 +15,17 @@
 package http // import "arcadium.dev/arcade/http"
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
 	cerrors "arcadium.dev/core/errors"
-	chttp "arcadium.dev/core/http"
 
 	"arcadium.dev/arcade"
 )
@@ -36,6 +38,47 @@ type (
 	// Players is used to manage the player assets.
 	PlayersService struct {
 		Storage arcade.PlayersStorage
+
+		// Rooms is used to check the existence of the location referenced by
+		// a list filter, and to get the current room of a player.
+		Rooms arcade.RoomsStorage
+
+		// Items is used to get the inventory of a player.
+		Items arcade.ItemsStorage
+
+		// MaxOffset, when non-zero, is the largest offset a list request
+		// may use.
+		MaxOffset int
+
+		// DefaultSort is the order of a list when the request does not give
+		// one, the zero value falls back to ascending by creation time.
+		DefaultSort arcade.Sort
+
+		// NotFoundForMissingFilter, when set, causes a list request filtered
+		// by a location that does not exist to fail with a not found error,
+		// rather than returning an empty list.
+		NotFoundForMissingFilter bool
+
+		// CoerceNumericIDs, when set, accepts ids given as json numbers in
+		// create and update requests, converting them into strings.
+		CoerceNumericIDs bool
+
+		// RejectUnknownFields, when set, rejects a request body giving a
+		// field the request does not know, rather than ignoring it.
+		RejectUnknownFields bool
+
+		// StrictQueryParams, when set, rejects a list request given a query
+		// parameter it does not know, rather than ignoring it.
+		StrictQueryParams bool
+
+		// TrailingSlash is the handling of a route given with a trailing
+		// slash.
+		TrailingSlash TrailingSlash
+
+		// StrictImmutableFields, when set, rejects an update request giving
+		// the created timestamp or a different playerID, rather than ignoring
+		// them.
+		StrictImmutableFields bool
 	}
 )
 
@@ -44,9 +87,14 @@ func (s PlayersService) Register(router *mux.Router) {
 	r := router.PathPrefix(PlayersRoute).Subrouter()
 	r.HandleFunc("", s.List).Methods(http.MethodGet)
 	r.HandleFunc("/{playerID}", s.Get).Methods(http.MethodGet)
+	r.HandleFunc("/{playerID}/state", s.State).Methods(http.MethodGet)
+	r.HandleFunc("/{playerID}/weight", s.Weight).Methods(http.MethodGet)
+	r.HandleFunc("/{playerID}/inventory/summary", s.InventorySummary).Methods(http.MethodGet)
 	r.HandleFunc("", s.Create).Methods(http.MethodPost)
 	r.HandleFunc("/{playerID}", s.Update).Methods(http.MethodPut)
+	r.HandleFunc("/{playerID}/spawn", s.Spawn).Methods(http.MethodPost)
 	r.HandleFunc("/{playerID}", s.Remove).Methods(http.MethodDelete)
+	handleTrailingSlash(router, r, s.TrailingSlash)
 }
 
 // Name returns the name of the service.
@@ -61,25 +109,53 @@ func (PlayersService) Shutdown() {}
 func (s PlayersService) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "locationID", "lastSeenBefore", "createdBy", "createdWithin", "sort", "limit", "offset"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
 	// Create the filter.
 	filter, err := arcade.NewPlayersFilter(r)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
+		return
+	}
+	if filter.Sort == (arcade.Sort{}) {
+		filter.Sort = s.DefaultSort
+	}
+	if s.MaxOffset > 0 && filter.Offset > s.MaxOffset {
+		response(w, r, fmt.Errorf(
+			"%w: offset too large, use cursor pagination", cerrors.ErrInvalidArgument,
+		))
 		return
 	}
+	if s.NotFoundForMissingFilter && filter.LocationID != nil && s.Rooms != nil {
+		if _, err := s.Rooms.Get(ctx, filter.LocationID.String()); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
 
 	// Read list of players.
 	players, err := s.Storage.List(ctx, filter)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	// Return list as body.
+	resp := arcade.NewPlayersResponse(players)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(PlayersRoute, resp.Data[i].ID)
+	}
+	resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(players))
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.NewPlayersResponse(players))
+	err = encoder(w, r).Encode(resp)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to create response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -95,14 +171,160 @@ func (s PlayersService) Get(w http.ResponseWriter, r *http.Request) {
 
 	player, err := s.Storage.Get(ctx, playerID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
+		return
+	}
+
+	player.Hyperlinks = selfLink(PlayersRoute, player.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.PlayerResponse{Data: player})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// State handles a request to retrieve a player with their inventory and
+// current room in one response.
+func (s PlayersService) State(w http.ResponseWriter, r *http.Request) {
+	playerID := mux.Vars(r)["playerID"]
+
+	pid, err := uuid.Parse(playerID)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid player id: '%s'", cerrors.ErrInvalidArgument, playerID,
+		))
+		return
+	}
+
+	// The first failure cancels the fetches still running.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var (
+		state  arcade.PlayerState
+		wg     sync.WaitGroup
+		once   sync.Once
+		failed error
+	)
+	fail := func(part string, err error) {
+		once.Do(func() {
+			failed = fmt.Errorf("failed to get player state: %s: %w", part, err)
+			cancel()
+		})
+	}
+
+	// The room is fetched once the player's location is known, concurrently
+	// with the inventory.
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		player, err := s.Storage.Get(ctx, playerID)
+		if err != nil {
+			fail("player", err)
+			return
+		}
+		room, err := s.Rooms.Get(ctx, player.LocationID)
+		if err != nil {
+			fail("room", err)
+			return
+		}
+		state.Player, state.Room = player, room
+	}()
+	go func() {
+		defer wg.Done()
+		items, err := s.Items.List(ctx, arcade.ItemsFilter{InventoryID: &pid})
+		if err != nil {
+			fail("inventory", err)
+			return
+		}
+		state.Inventory = items
+	}()
+	wg.Wait()
+
+	if failed != nil {
+		response(w, r, failed)
+		return
+	}
+
+	state.Player.Hyperlinks = selfLink(PlayersRoute, state.Player.ID)
+	state.Room.Hyperlinks = selfLink(RoomsRoute, state.Room.ID)
+	for i := range state.Inventory {
+		state.Inventory[i].Hyperlinks = selfLink(ItemsRoute, state.Inventory[i].ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.PlayerStateResponse{Data: state})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Weight handles a request to get the total weight of the items a player
+// carries.
+func (s PlayersService) Weight(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+	playerID := params["playerID"]
+
+	weight, err := s.Items.TotalWeight(ctx, playerID)
+	if err != nil {
+		response(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.PlayerResponse{Data: player})
+	err = encoder(w, r).Encode(arcade.PlayerWeightResponse{Data: arcade.PlayerWeight{PlayerID: playerID, Weight: weight}})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// InventorySummary handles a request for the number of items of a player in
+// each location, ordered by type and then id.
+func (s PlayersService) InventorySummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+	playerID := params["playerID"]
+
+	grouped, err := s.Items.ListGroupedByLocation(ctx, playerID)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	counts := make([]arcade.ItemLocationCount, 0, len(grouped))
+	for location, count := range grouped {
+		c := arcade.ItemLocationCount{Type: location.Type, Count: count}
+		if location.Type == arcade.ItemLocationPlayer {
+			c.InventoryID = location.ID
+		} else {
+			c.LocationID = location.ID
+		}
+		counts = append(counts, c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Type != counts[j].Type {
+			return counts[i].Type < counts[j].Type
+		}
+		return counts[i].LocationID+counts[i].InventoryID < counts[j].LocationID+counts[j].InventoryID
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.ItemLocationCountsResponse{Data: counts})
+	if err != nil {
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -115,7 +337,7 @@ func (s PlayersService) Create(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -123,31 +345,31 @@ func (s PlayersService) Create(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
 	var req arcade.PlayerRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	player, err := s.Storage.Create(ctx, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	player.Hyperlinks = selfLink(PlayersRoute, player.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.PlayerResponse{Data: player})
+	err = encoder(w, r).Encode(arcade.PlayerResponse{Data: player})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -163,7 +385,7 @@ func (s PlayersService) Update(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -171,31 +393,88 @@ func (s PlayersService) Update(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
+	if s.StrictImmutableFields {
+		if err := checkImmutable(body, "playerID", playerID); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
 	var req arcade.PlayerRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	player, err := s.Storage.Update(ctx, playerID, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
+		return
+	}
+
+	player.Hyperlinks = selfLink(PlayersRoute, player.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.PlayerResponse{Data: player})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
 		return
 	}
+}
+
+// Spawn handles a request to create a room, given by the body, and move the
+// player into it.
+func (s PlayersService) Spawn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+	playerID := params["playerID"]
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.RoomRequest
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	spawn, err := s.Storage.Spawn(ctx, playerID, req)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	spawn.Player.Hyperlinks = selfLink(PlayersRoute, spawn.Player.ID)
+	spawn.Room.Hyperlinks = selfLink(RoomsRoute, spawn.Room.ID)
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.PlayerResponse{Data: player})
+	err = encoder(w, r).Encode(arcade.PlayerSpawnResponse{Data: spawn})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -211,7 +490,7 @@ func (s PlayersService) Remove(w http.ResponseWriter, r *http.Request) {
 
 	err := s.Storage.Remove(ctx, playerID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
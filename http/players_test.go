@@ -28,6 +28,8 @@ import (
 
 	"github.com/gorilla/mux"
 
+	cerrors "arcadium.dev/core/errors"
+
 	"arcadium.dev/arcade"
 	ahttp "arcadium.dev/arcade/http"
 )
@@ -46,6 +48,15 @@ func TestPlayersServiceShutdown(t *testing.T) {
 }
 
 func TestPlayersServiceList(t *testing.T) {
+	t.Run("strict unknown query param", func(t *testing.T) {
+		s := ahttp.PlayersService{Storage: &mockPlayersStorage{t: t}, StrictQueryParams: true}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, ahttp.PlayersRoute+"?locationId=42", nil),
+			http.StatusBadRequest, "invalid argument: unknown query parameter 'locationId'",
+		)
+	})
+
 	t.Run("filter error", func(t *testing.T) {
 		route := fmt.Sprintf("%s?locationID=42", ahttp.PlayersRoute)
 		checkRespError(
@@ -55,6 +66,59 @@ func TestPlayersServiceList(t *testing.T) {
 		)
 	})
 
+	t.Run("offset too large", func(t *testing.T) {
+		s := ahttp.PlayersService{Storage: &mockPlayersStorage{t: t}, MaxOffset: 100}
+
+		route := fmt.Sprintf("%s?offset=101", ahttp.PlayersRoute)
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusBadRequest,
+			"invalid argument: offset too large, use cursor pagination",
+		)
+	})
+
+	t.Run("offset at maximum", func(t *testing.T) {
+		m := &mockPlayersStorage{t: t}
+		s := ahttp.PlayersService{Storage: m, MaxOffset: 100}
+
+		route := fmt.Sprintf("%s?offset=100", ahttp.PlayersRoute)
+		w := invokeService(t, s, http.MethodGet, route, nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("nonexistent location", func(t *testing.T) {
+		const locationID = "ad3bf6ad-1a80-4d47-9e56-a1b4e2cc6d4d"
+		route := ahttp.PlayersRoute + "?locationID=" + locationID
+
+		for _, notFound := range []bool{false, true} {
+			m := &mockPlayersStorage{t: t}
+			rooms := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to get room: %w", cerrors.ErrNotFound)}
+			s := ahttp.PlayersService{Storage: m, Rooms: rooms, NotFoundForMissingFilter: notFound}
+
+			w := invokeService(t, s, http.MethodGet, route, nil)
+
+			if notFound {
+				checkRespError(t, w, http.StatusNotFound, "failed to get room: not found")
+				if m.listCalled {
+					t.Error("expected list not to be called")
+				}
+				continue
+			}
+			if rooms.getCalled {
+				t.Error("expected room get not to be called")
+			}
+			if w.Result().StatusCode != http.StatusOK {
+				t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+			}
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		err := errors.New("unknown error")
 		m := &mockPlayersStorage{t: t, err: err}
@@ -118,6 +182,203 @@ func TestPlayersServiceList(t *testing.T) {
 	})
 }
 
+func TestPlayersServiceState(t *testing.T) {
+	const (
+		playerID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		roomID   = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		itemID   = "5a0e9b72-7ac5-4a0b-9c1c-7f4d0a7f0c65"
+	)
+	route := ahttp.PlayersRoute + "/" + playerID + "/state"
+
+	t.Run("invalid player id", func(t *testing.T) {
+		checkRespError(
+			t, invokeService(t, ahttp.PlayersService{}, http.MethodGet, ahttp.PlayersRoute+"/42/state", nil),
+			http.StatusBadRequest, "invalid argument: invalid player id: '42'",
+		)
+	})
+
+	t.Run("inventory error", func(t *testing.T) {
+		s := ahttp.PlayersService{
+			Storage: &mockPlayersStorage{t: t, playerID: playerID, player: arcade.Player{ID: playerID, LocationID: roomID}},
+			Rooms:   &mockRoomsStorage{t: t, roomID: roomID, room: arcade.Room{ID: roomID}},
+			Items:   &mockItemsStorage{t: t, err: fmt.Errorf("failed to list items: %w: connection lost", cerrors.ErrInternal)},
+		}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "failed to get player state: inventory: failed to list items: internal error: connection lost",
+		)
+	})
+
+	t.Run("room error", func(t *testing.T) {
+		s := ahttp.PlayersService{
+			Storage: &mockPlayersStorage{t: t, playerID: playerID, player: arcade.Player{ID: playerID, LocationID: roomID}},
+			Rooms:   &mockRoomsStorage{t: t, err: fmt.Errorf("failed to get room: %w", cerrors.ErrNotFound)},
+			Items:   &mockItemsStorage{t: t},
+		}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusNotFound, "failed to get player state: room: failed to get room: not found",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		items := &mockItemsStorage{t: t, items: []arcade.Item{{ID: itemID, InventoryID: playerID}}}
+		s := ahttp.PlayersService{
+			Storage: &mockPlayersStorage{t: t, playerID: playerID, player: arcade.Player{ID: playerID, LocationID: roomID}},
+			Rooms:   &mockRoomsStorage{t: t, roomID: roomID, room: arcade.Room{ID: roomID}},
+			Items:   items,
+		}
+
+		w := invokeService(t, s, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if items.listFilter.InventoryID == nil || items.listFilter.InventoryID.String() != playerID {
+			t.Errorf("Unexpected inventory filter: %+v", items.listFilter)
+		}
+
+		var stateResp arcade.PlayerStateResponse
+		if err := json.NewDecoder(resp.Body).Decode(&stateResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		state := stateResp.Data
+		if state.Player.ID != playerID || state.Room.ID != roomID {
+			t.Errorf("Unexpected state: %+v", state)
+		}
+		if len(state.Inventory) != 1 || state.Inventory[0].ID != itemID {
+			t.Errorf("Unexpected inventory: %+v", state.Inventory)
+		}
+	})
+}
+
+func TestPlayersServiceWeight(t *testing.T) {
+	const playerID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+	route := ahttp.PlayersRoute + "/" + playerID + "/weight"
+
+	t.Run("player not found", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, playerID: playerID, err: fmt.Errorf("failed to total item weight: %w", cerrors.ErrNotFound)}
+
+		checkRespError(
+			t, invokeService(t, ahttp.PlayersService{Items: m}, http.MethodGet, route, nil),
+			http.StatusNotFound, "failed to total item weight: not found",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, playerID: playerID, weight: 42}
+
+		w := invokeService(t, ahttp.PlayersService{Items: m}, http.MethodGet, route, nil)
+
+		if !m.totalWeightCalled {
+			t.Error("expected total weight to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		defer resp.Body.Close()
+
+		var weightResp arcade.PlayerWeightResponse
+		if err := json.NewDecoder(resp.Body).Decode(&weightResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		if weightResp.Data != (arcade.PlayerWeight{PlayerID: playerID, Weight: 42}) {
+			t.Errorf("Unexpected response data: %+v", weightResp.Data)
+		}
+	})
+}
+
+func TestPlayersServiceInventorySummary(t *testing.T) {
+	const (
+		playerID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		roomID   = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		otherID  = "00000000-0000-0000-0000-000000000001"
+	)
+	route := ahttp.PlayersRoute + "/" + playerID + "/inventory/summary"
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, playerID: playerID, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeService(t, ahttp.PlayersService{Items: m}, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, playerID: playerID, grouped: map[arcade.ItemLocationID]int{
+			{Type: arcade.ItemLocationRoom, ID: roomID}:     2,
+			{Type: arcade.ItemLocationPlayer, ID: playerID}: 3,
+			{Type: arcade.ItemLocationRoom, ID: otherID}:    1,
+		}}
+
+		w := invokeService(t, ahttp.PlayersService{Items: m}, http.MethodGet, route, nil)
+
+		if !m.groupedCalled {
+			t.Error("expected list grouped by location to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+		defer resp.Body.Close()
+
+		var countsResp arcade.ItemLocationCountsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&countsResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		expected := []arcade.ItemLocationCount{
+			{Type: arcade.ItemLocationPlayer, InventoryID: playerID, Count: 3},
+			{Type: arcade.ItemLocationRoom, LocationID: otherID, Count: 1},
+			{Type: arcade.ItemLocationRoom, LocationID: roomID, Count: 2},
+		}
+		if len(countsResp.Data) != len(expected) {
+			t.Fatalf("Unexpected response data: %+v", countsResp.Data)
+		}
+		for i := range expected {
+			if countsResp.Data[i] != expected[i] {
+				t.Errorf("\nExpected count: %+v\nActual count:   %+v", expected[i], countsResp.Data[i])
+			}
+		}
+	})
+
+	t.Run("urn ids", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, playerID: playerID, grouped: map[arcade.ItemLocationID]int{
+			{Type: arcade.ItemLocationRoom, ID: roomID}:     2,
+			{Type: arcade.ItemLocationPlayer, ID: playerID}: 3,
+		}}
+		router := mux.NewRouter()
+		router.Use(ahttp.IDFormat(true))
+		ahttp.PlayersService{Items: m}.Register(router)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, route, nil))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		var countsResp arcade.ItemLocationCountsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&countsResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		expected := []arcade.ItemLocationCount{
+			{Type: arcade.ItemLocationPlayer, InventoryID: "urn:arcade:player:" + playerID, Count: 3},
+			{Type: arcade.ItemLocationRoom, LocationID: "urn:arcade:room:" + roomID, Count: 2},
+		}
+		if len(countsResp.Data) != len(expected) {
+			t.Fatalf("Unexpected response data: %+v", countsResp.Data)
+		}
+		for i := range expected {
+			if countsResp.Data[i] != expected[i] {
+				t.Errorf("\nExpected count: %+v\nActual count:   %+v", expected[i], countsResp.Data[i])
+			}
+		}
+	})
+}
+
 func TestPlayersServiceGet(t *testing.T) {
 	const (
 		id          = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
@@ -236,8 +497,8 @@ func TestPlayersServiceCreate(t *testing.T) {
 			Description: description,
 			HomeID:      homeID,
 			LocationID:  locationID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockPlayersStorage{t: t, req: req, player: player}
 		body := bytes.NewBufferString(
@@ -330,8 +591,8 @@ func TestPlayersServiceUpdate(t *testing.T) {
 			Description: description,
 			HomeID:      homeID,
 			LocationID:  locationID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockPlayersStorage{t: t, req: req, playerID: id, player: player}
 		body := bytes.NewBufferString(
@@ -371,6 +632,66 @@ func TestPlayersServiceUpdate(t *testing.T) {
 	})
 }
 
+func TestPlayersServiceSpawn(t *testing.T) {
+	const (
+		id      = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		roomID  = "6c4d0a7e-32e4-4fd5-a1d8-4a3e2f58c5b0"
+		ownerID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+	route := ahttp.PlayersRoute + "/" + id + "/spawn"
+	body := func() *bytes.Buffer {
+		return bytes.NewBufferString(`{"name":"Den","description":"A new den.","ownerID":"` + ownerID + `","parentID":"` + ownerID + `"}`)
+	}
+
+	t.Run("missing body", func(t *testing.T) {
+		checkRespError(
+			t, invokePlayersService(t, nil, http.MethodPost, route, nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockPlayersStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokePlayersService(t, m, http.MethodPost, route, body()),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.spawnCalled {
+			t.Errorf("expected spawn to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockPlayersStorage{
+			t:        t,
+			playerID: id,
+			roomReq:  arcade.RoomRequest{Name: "Den", Description: "A new den.", OwnerID: ownerID, ParentID: ownerID},
+			player:   arcade.Player{ID: id, LocationID: roomID},
+			room:     arcade.Room{ID: roomID, Name: "Den"},
+		}
+
+		w := invokePlayersService(t, m, http.MethodPost, route, body())
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var spawnResp arcade.PlayerSpawnResponse
+		if err := json.NewDecoder(resp.Body).Decode(&spawnResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		spawn := spawnResp.Data
+		if spawn.Player.ID != id || spawn.Player.LocationID != roomID || spawn.Room.ID != roomID ||
+			spawn.Player.Hyperlinks == nil || spawn.Room.Hyperlinks == nil {
+			t.Errorf("Unexpected spawn: %+v", spawn)
+		}
+	})
+}
+
 func TestPlayersServiceRemove(t *testing.T) {
 	const (
 		id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
@@ -429,12 +750,21 @@ type (
 		player  arcade.Player
 		players []arcade.Player
 
+		getManyIDs []string
+
 		listCalled, getCalled, createCalled, updateCalled, removeCalled bool
+		updateLastSeenCalled, spawnCalled                               bool
+
+		roomReq arcade.RoomRequest
+		room    arcade.Room
+
+		listFilter arcade.PlayersFilter
 	}
 )
 
-func (m *mockPlayersStorage) List(context.Context, arcade.PlayersFilter) ([]arcade.Player, error) {
+func (m *mockPlayersStorage) List(ctx context.Context, filter arcade.PlayersFilter) ([]arcade.Player, error) {
 	m.listCalled = true
+	m.listFilter = filter
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -452,6 +782,22 @@ func (m *mockPlayersStorage) Get(ctx context.Context, playerID string) (arcade.P
 	return m.player, nil
 }
 
+func (m *mockPlayersStorage) GetMany(ctx context.Context, playerIDs []string) ([]arcade.Player, error) {
+	m.getManyIDs = playerIDs
+	if m.err != nil {
+		return nil, m.err
+	}
+	players := make([]arcade.Player, 0)
+	for _, player := range append(m.players, m.player) {
+		for _, id := range playerIDs {
+			if player.ID == id {
+				players = append(players, player)
+			}
+		}
+	}
+	return players, nil
+}
+
 func (m *mockPlayersStorage) Create(ctx context.Context, req arcade.PlayerRequest) (arcade.Player, error) {
 	m.createCalled = true
 	if m.err != nil {
@@ -487,3 +833,28 @@ func (m *mockPlayersStorage) Remove(ctx context.Context, playerID string) error
 	}
 	return nil
 }
+
+func (m *mockPlayersStorage) Spawn(ctx context.Context, playerID string, req arcade.RoomRequest) (arcade.PlayerSpawn, error) {
+	m.spawnCalled = true
+	if m.err != nil {
+		return arcade.PlayerSpawn{}, m.err
+	}
+	if m.playerID != playerID {
+		m.t.Fatalf("spawn: expected playerID %s, actual playerID %s", m.playerID, playerID)
+	}
+	if m.roomReq != req {
+		m.t.Fatalf("spawn: expected room request %+v, actual room request %+v", m.roomReq, req)
+	}
+	return arcade.PlayerSpawn{Player: m.player, Room: m.room}, nil
+}
+
+func (m *mockPlayersStorage) UpdateLastSeen(ctx context.Context, playerID string) error {
+	m.updateLastSeenCalled = true
+	if m.err != nil {
+		return m.err
+	}
+	if m.playerID != playerID {
+		m.t.Fatalf("update last seen: expected playerID %s, actual playerID %s", m.playerID, playerID)
+	}
+	return nil
+}
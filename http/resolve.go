@@ -0,0 +1,256 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+const (
+	ResolveRoute string = "/resolve"
+)
+
+type (
+	// ResolveService is used to resolve references to assets of mixed types
+	// in a single request.
+	ResolveService struct {
+		Players arcade.PlayersStorage
+		Rooms   arcade.RoomsStorage
+		Links   arcade.LinksStorage
+		Items   arcade.ItemsStorage
+
+		// MaxBatchSize is the most references a request may give, the zero
+		// value falls back to DefaultMaxBatchSize.
+		MaxBatchSize int
+
+		// TrailingSlash is the handling of a route given with a trailing
+		// slash.
+		TrailingSlash TrailingSlash
+
+		// RejectUnknownFields, when set, rejects a reference giving a field
+		// other than its type and id, rather than ignoring it.
+		RejectUnknownFields bool
+	}
+)
+
+// Register sets up the http handler for this service with the given router.
+func (s ResolveService) Register(router *mux.Router) {
+	r := router.PathPrefix(ResolveRoute).Subrouter()
+	r.HandleFunc("", s.Resolve).Methods(http.MethodPost)
+	handleTrailingSlash(router, r, s.TrailingSlash)
+}
+
+// Name returns the name of the service.
+func (ResolveService) Name() string {
+	return "resolve"
+}
+
+// Shutdown is a no-op since there no long running processes for this service.
+func (ResolveService) Shutdown() {}
+
+// Resolve handles a request to get the assets of multiple references. The
+// references of each type are resolved concurrently, in a single get of the
+// storage of that type. A reference to an asset which does not exist is
+// omitted, and noted as unresolved.
+func (s ResolveService) Resolve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var refs []arcade.Reference
+	err = unmarshal(body, &refs, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	if err := checkBatchSize(len(refs), s.MaxBatchSize); err != nil {
+		response(w, r, err)
+		return
+	}
+
+	// Group the references by type, in the order given.
+	getters := s.getters()
+	byType := make(map[string][]arcade.Reference)
+	for _, ref := range refs {
+		if _, ok := getters[ref.Type]; !ok {
+			response(w, r, fmt.Errorf(
+				"%w: unknown reference type: '%s'", cerrors.ErrInvalidArgument, ref.Type,
+			))
+			return
+		}
+		byType[ref.Type] = append(byType[ref.Type], ref)
+	}
+
+	// The first failure cancels the gets still running.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var (
+		resp   = arcade.ResolveResponse{Data: make(map[string]interface{})}
+		notes  = make(map[string]string)
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		once   sync.Once
+		failed error
+	)
+	for typ, refs := range byType {
+		wg.Add(1)
+		go func(typ string, get func(context.Context, []string) (map[string]interface{}, error), refs []arcade.Reference) {
+			defer wg.Done()
+
+			// An invalid id is noted rather than failing the get of the
+			// other ids of the type.
+			ids := make([]string, len(refs))
+			valid := make([]string, 0, len(refs))
+			for i, ref := range refs {
+				id, err := uuid.Parse(ref.ID)
+				if err != nil {
+					mu.Lock()
+					notes[ref.Key()] = fmt.Sprintf("failed to get %s: %s: invalid %s id: '%s'", typ, cerrors.ErrInvalidArgument, typ, ref.ID)
+					mu.Unlock()
+					continue
+				}
+				ids[i] = id.String()
+				valid = append(valid, ids[i])
+			}
+			if len(valid) == 0 {
+				return
+			}
+
+			assets, err := get(ctx, valid)
+			if err != nil {
+				once.Do(func() {
+					failed = fmt.Errorf("failed to resolve references: %w", err)
+					cancel()
+				})
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i, ref := range refs {
+				if ids[i] == "" {
+					continue
+				}
+				asset, ok := assets[ids[i]]
+				if !ok {
+					notes[ref.Key()] = fmt.Sprintf("failed to get %s: %s", typ, cerrors.ErrNotFound)
+					continue
+				}
+				resp.Data[ref.Key()] = asset
+			}
+		}(typ, getters[typ], refs)
+	}
+	wg.Wait()
+
+	if failed != nil {
+		response(w, r, failed)
+		return
+	}
+
+	// Note the unresolved references in the order given.
+	for _, ref := range refs {
+		if note, ok := notes[ref.Key()]; ok {
+			resp.Unresolved = append(resp.Unresolved, arcade.UnresolvedReference{Reference: ref, Note: note})
+			delete(notes, ref.Key())
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// getters returns a function getting the assets of the given ids of each
+// reference type, keyed by id. A type without storage, as its service is not
+// served, cannot be resolved.
+func (s ResolveService) getters() map[string]func(context.Context, []string) (map[string]interface{}, error) {
+	getters := make(map[string]func(context.Context, []string) (map[string]interface{}, error))
+	if s.Players != nil {
+		getters[arcade.PlayerReference] = func(ctx context.Context, ids []string) (map[string]interface{}, error) {
+			players, err := s.Players.GetMany(ctx, ids)
+			assets := make(map[string]interface{}, len(players))
+			for _, player := range players {
+				player.Hyperlinks = selfLink(PlayersRoute, player.ID)
+				assets[player.ID] = player
+			}
+			return assets, err
+		}
+	}
+	if s.Rooms != nil {
+		getters[arcade.RoomReference] = func(ctx context.Context, ids []string) (map[string]interface{}, error) {
+			rooms, err := s.Rooms.GetMany(ctx, ids)
+			assets := make(map[string]interface{}, len(rooms))
+			for _, room := range rooms {
+				room.Hyperlinks = selfLink(RoomsRoute, room.ID)
+				assets[room.ID] = room
+			}
+			return assets, err
+		}
+	}
+	if s.Links != nil {
+		getters[arcade.LinkReference] = func(ctx context.Context, ids []string) (map[string]interface{}, error) {
+			links, err := s.Links.GetMany(ctx, ids)
+			assets := make(map[string]interface{}, len(links))
+			for _, link := range links {
+				link.Hyperlinks = selfLink(LinksRoute, link.ID)
+				assets[link.ID] = link
+			}
+			return assets, err
+		}
+	}
+	if s.Items != nil {
+		getters[arcade.ItemReference] = func(ctx context.Context, ids []string) (map[string]interface{}, error) {
+			items, err := s.Items.GetMany(ctx, ids)
+			assets := make(map[string]interface{}, len(items))
+			for _, item := range items {
+				item.Hyperlinks = selfLink(ItemsRoute, item.ID)
+				assets[item.ID] = item
+			}
+			return assets, err
+		}
+	}
+	return getters
+}
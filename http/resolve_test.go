@@ -0,0 +1,150 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestResolveServiceName(t *testing.T) {
+	var s ahttp.ResolveService
+	if s.Name() != "resolve" {
+		t.Errorf("Unexpected service name: %s", s.Name())
+	}
+}
+
+func TestResolveService(t *testing.T) {
+	const (
+		itemID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		roomID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		gone   = "5a0e9b72-7ac5-4a0b-9c1c-7f4d0a7f0c65"
+	)
+
+	t.Run("unknown type", func(t *testing.T) {
+		checkRespError(
+			t, invokeService(t, ahttp.ResolveService{}, http.MethodPost, ahttp.ResolveRoute, strings.NewReader(`[{"type": "spell", "id": "42"}]`)),
+			http.StatusBadRequest, "invalid argument: unknown reference type: 'spell'",
+		)
+	})
+
+	t.Run("type not served", func(t *testing.T) {
+		s := ahttp.ResolveService{Rooms: &mockRoomsStorage{t: t}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodPost, ahttp.ResolveRoute, strings.NewReader(`[{"type": "item", "id": "`+itemID+`"}]`)),
+			http.StatusBadRequest, "invalid argument: unknown reference type: 'item'",
+		)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		s := ahttp.ResolveService{Items: &mockItemsStorage{t: t, err: errors.New("unknown error")}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodPost, ahttp.ResolveRoute, strings.NewReader(`[{"type": "item", "id": "`+itemID+`"}]`)),
+			http.StatusInternalServerError, "failed to resolve references: unknown error",
+		)
+	})
+
+	t.Run("mixed types", func(t *testing.T) {
+		s := ahttp.ResolveService{
+			Items: &mockItemsStorage{t: t, itemID: itemID, item: arcade.Item{ID: itemID, Name: "Sword"}},
+			Rooms: &mockRoomsStorage{t: t, roomID: roomID, room: arcade.Room{ID: roomID, Name: "Hall"}},
+			Links: &mockLinksStorage{t: t},
+		}
+		body := fmt.Sprintf(
+			`[{"type": "item", "id": "%s"}, {"type": "link", "id": "%s"}, {"type": "room", "id": "%s"}]`,
+			itemID, gone, roomID,
+		)
+
+		w := invokeService(t, s, http.MethodPost, ahttp.ResolveRoute, strings.NewReader(body))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var resolveResp struct {
+			Data       map[string]json.RawMessage   `json:"data"`
+			Unresolved []arcade.UnresolvedReference `json:"unresolved"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&resolveResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+
+		var item arcade.Item
+		if err := json.Unmarshal(resolveResp.Data["item:"+itemID], &item); err != nil || item.Name != "Sword" {
+			t.Errorf("Unexpected item: %+v", item)
+		}
+		var room arcade.Room
+		if err := json.Unmarshal(resolveResp.Data["room:"+roomID], &room); err != nil || room.Name != "Hall" {
+			t.Errorf("Unexpected room: %+v", room)
+		}
+		if _, ok := resolveResp.Data["link:"+gone]; ok || len(resolveResp.Data) != 2 {
+			t.Errorf("Unexpected data: %v", resolveResp.Data)
+		}
+
+		if len(resolveResp.Unresolved) != 1 {
+			t.Fatalf("Unexpected unresolved: %+v", resolveResp.Unresolved)
+		}
+		unresolved := resolveResp.Unresolved[0]
+		if unresolved.Type != "link" || unresolved.ID != gone || unresolved.Note != "failed to get link: not found" {
+			t.Errorf("Unexpected unresolved: %+v", unresolved)
+		}
+	})
+
+	t.Run("one get per type", func(t *testing.T) {
+		const otherID = "00000000-0000-0000-0000-000000000001"
+		m := &mockItemsStorage{t: t, items: []arcade.Item{{ID: itemID, Name: "Sword"}, {ID: otherID, Name: "Shield"}}}
+		body := fmt.Sprintf(
+			`[{"type": "item", "id": "%s"}, {"type": "item", "id": "42"}, {"type": "item", "id": "%s"}]`,
+			itemID, strings.ToUpper(otherID),
+		)
+
+		w := invokeService(t, ahttp.ResolveService{Items: m}, http.MethodPost, ahttp.ResolveRoute, strings.NewReader(body))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if len(m.getManyIDs) != 2 || m.getManyIDs[0] != itemID || m.getManyIDs[1] != otherID {
+			t.Errorf("Unexpected ids: %v", m.getManyIDs)
+		}
+
+		var resolveResp struct {
+			Data       map[string]json.RawMessage   `json:"data"`
+			Unresolved []arcade.UnresolvedReference `json:"unresolved"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&resolveResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(resolveResp.Data) != 2 {
+			t.Errorf("Unexpected data: %v", resolveResp.Data)
+		}
+		expected := "failed to get item: invalid argument: invalid item id: '42'"
+		if len(resolveResp.Unresolved) != 1 || resolveResp.Unresolved[0].ID != "42" || resolveResp.Unresolved[0].Note != expected {
+			t.Errorf("Unexpected unresolved: %+v", resolveResp.Unresolved)
+		}
+	})
+}
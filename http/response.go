@@ -0,0 +1,156 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	cerrors "arcadium.dev/core/errors"
+	chttp "arcadium.dev/core/http"
+)
+
+// messages is the catalog of localized domain error messages, keyed by
+// language, in the order the domain errors are matched, that of the status
+// of the response. English is the language of the domain errors themselves,
+// so it has no entry.
+var messages = map[string][]message{
+	"de": {
+		{cerrors.ErrInvalidArgument, "ungültiges Argument"},
+		{cerrors.ErrNotFound, "nicht gefunden"},
+		{cerrors.ErrAlreadyExists, "existiert bereits"},
+		{cerrors.ErrInternal, "interner Fehler"},
+	},
+	"es": {
+		{cerrors.ErrInvalidArgument, "argumento inválido"},
+		{cerrors.ErrNotFound, "no encontrado"},
+		{cerrors.ErrAlreadyExists, "ya existe"},
+		{cerrors.ErrInternal, "error interno"},
+	},
+	"fr": {
+		{cerrors.ErrInvalidArgument, "argument invalide"},
+		{cerrors.ErrNotFound, "introuvable"},
+		{cerrors.ErrAlreadyExists, "existe déjà"},
+		{cerrors.ErrInternal, "erreur interne"},
+	},
+}
+
+type (
+	// message is the localized message of a domain error.
+	message struct {
+		err error
+		msg string
+	}
+
+	// localizedError replaces the message of an error while retaining the
+	// error chain, so the status of the response is unchanged.
+	localizedError struct {
+		err error
+		msg string
+	}
+)
+
+func (e localizedError) Error() string { return e.msg }
+func (e localizedError) Unwrap() error { return e.err }
+
+// encoder returns a json encoder of the response body, indenting the json
+// when the request asks for it to be pretty printed, with either the pretty
+// query parameter or the X-Pretty header. The json is compact by default.
+// The ids are rendered as typed URNs when the request asks for them.
+func encoder(w http.ResponseWriter, r *http.Request) interface{ Encode(interface{}) error } {
+	enc := json.NewEncoder(w)
+	if pretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	if urnIDs(r) {
+		return urnEncoder{enc: enc}
+	}
+	return enc
+}
+
+func pretty(r *http.Request) bool {
+	value := r.URL.Query().Get("pretty")
+	if value == "" {
+		value = r.Header.Get("X-Pretty")
+	}
+	p, _ := strconv.ParseBool(value)
+	return p
+}
+
+// response writes an error response, localizing the domain error message
+// using the request's Accept-Language header. Any details interpolated into
+// the message are left as is.
+func response(w http.ResponseWriter, r *http.Request, err error) {
+	chttp.Response(r.Context(), w, localize(r, err))
+}
+
+func localize(r *http.Request, err error) error {
+	catalog := catalogFor(r.Header.Get("Accept-Language"))
+	if catalog == nil {
+		return err
+	}
+	for _, m := range catalog {
+		if errors.Is(err, m.err) {
+			return localizedError{
+				err: err,
+				msg: strings.Replace(err.Error(), m.err.Error(), m.msg, 1),
+			}
+		}
+	}
+	return err
+}
+
+// catalogFor returns the message catalog of the most preferred supported
+// language in the given Accept-Language header value, or nil for English.
+func catalogFor(acceptLanguage string) []message {
+	type lang struct {
+		tag string
+		q   float64
+	}
+
+	var langs []lang
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		l := lang{tag: strings.ToLower(strings.TrimSpace(fields[0])), q: 1}
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+				l.q = q
+			}
+		}
+		if l.tag != "" && l.q > 0 {
+			langs = append(langs, l)
+		}
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	for _, l := range langs {
+		primary := strings.SplitN(l.tag, "-", 2)[0]
+		if primary == "en" {
+			return nil
+		}
+		if catalog, ok := messages[primary]; ok {
+			return catalog
+		}
+	}
+	return nil
+}
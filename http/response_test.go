@@ -0,0 +1,140 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestLocalizedErrorResponse(t *testing.T) {
+	const id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+
+	invoke := func(t *testing.T, m *mockItemsStorage, target, acceptLanguage string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		router := mux.NewRouter()
+		ahttp.ItemsService{Storage: m}.Register(router)
+
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if acceptLanguage != "" {
+			r.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	notFound := fmt.Errorf("failed to get item: %w", cerrors.ErrNotFound)
+
+	tests := []struct {
+		acceptLanguage string
+		detail         string
+	}{
+		{"", "failed to get item: not found"},
+		{"en-US,en;q=0.9", "failed to get item: not found"},
+		{"es-MX,es;q=0.9", "failed to get item: no encontrado"},
+		{"fr", "failed to get item: introuvable"},
+		{"xx, de;q=0.5, fr;q=0.2", "failed to get item: nicht gefunden"},
+		{"xx", "failed to get item: not found"},
+	}
+	for _, test := range tests {
+		t.Run(test.acceptLanguage, func(t *testing.T) {
+			m := &mockItemsStorage{t: t, err: notFound}
+
+			checkRespError(
+				t, invoke(t, m, ahttp.ItemsRoute+"/"+id, test.acceptLanguage),
+				http.StatusNotFound, test.detail,
+			)
+		})
+	}
+
+	t.Run("first matching domain error", func(t *testing.T) {
+		// An error of two domain errors is localized as the first of the
+		// catalog, every time, as is its status.
+		err := fmt.Errorf("failed to get item: %w", twoDomainErrors{
+			err: fmt.Errorf("%w: owner", cerrors.ErrNotFound),
+			is:  cerrors.ErrInvalidArgument,
+		})
+		for i := 0; i < 20; i++ {
+			m := &mockItemsStorage{t: t, err: err}
+
+			checkRespError(
+				t, invoke(t, m, ahttp.ItemsRoute+"/"+id, "es"),
+				http.StatusBadRequest, "failed to get item: argumento inválido: not found: owner",
+			)
+		}
+	})
+
+	t.Run("details are not localized", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, err: fmt.Errorf("failed to get item: %w: invalid item id: '42'", cerrors.ErrInvalidArgument)}
+
+		checkRespError(
+			t, invoke(t, m, ahttp.ItemsRoute+"/42", "es"),
+			http.StatusBadRequest, "failed to get item: argumento inválido: invalid item id: '42'",
+		)
+	})
+}
+
+// twoDomainErrors is an error wrapping one domain error while also being
+// another, its message that of the other followed by the wrapped error's.
+type twoDomainErrors struct {
+	err, is error
+}
+
+func (e twoDomainErrors) Error() string        { return e.is.Error() + ": " + e.err.Error() }
+func (e twoDomainErrors) Unwrap() error        { return e.err }
+func (e twoDomainErrors) Is(target error) bool { return target == e.is }
+
+func TestPrettyResponse(t *testing.T) {
+	const (
+		compact = `{"data":{"status":"up"}}` + "\n"
+		pretty  = "{\n  \"data\": {\n    \"status\": \"up\"\n  }\n}\n"
+	)
+
+	tests := []struct {
+		name, target, header, expected string
+	}{
+		{"default", "/health", "", compact},
+		{"query", "/health?pretty=true", "", pretty},
+		{"query false", "/health?pretty=false", "", compact},
+		{"header", "/health", "true", pretty},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			ahttp.HealthService{}.Register(router)
+
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			if test.header != "" {
+				r.Header.Set("X-Pretty", test.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if body := w.Body.String(); body != test.expected {
+				t.Errorf("\nExpected body: %q\nActual body:   %q", test.expected, body)
+			}
+		})
+	}
+}
@@ -15,38 +15,118 @@
 package http // import "arcadium.dev/arcade/http"
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
 	cerrors "arcadium.dev/core/errors"
-	chttp "arcadium.dev/core/http"
 
 	"arcadium.dev/arcade"
 )
 
 const (
 	RoomsRoute string = "/rooms"
+
+	// DefaultMaxNearbyHops is the most links a nearby rooms request may
+	// follow, unless configured otherwise.
+	DefaultMaxNearbyHops = 5
+
+	// DefaultMaxRouteHops is the most links a route may follow, unless
+	// configured otherwise.
+	DefaultMaxRouteHops = 50
 )
 
 type (
 	// Rooms is used to manage the room assets.
 	RoomsService struct {
 		Storage arcade.RoomsStorage
+
+		// Players is used to check the existence of the owner referenced by
+		// a list filter, and to list the players in a room.
+		Players arcade.PlayersStorage
+
+		// Links is used to find the route between two rooms, and the rooms
+		// near a room.
+		Links arcade.LinksStorage
+
+		// Items is used to list the items located in a room.
+		Items arcade.ItemsStorage
+
+		// MaxNearbyHops is the most links a nearby rooms request may follow,
+		// more are capped to it. The zero value falls back to
+		// DefaultMaxNearbyHops.
+		MaxNearbyHops int
+
+		// MaxRouteHops is the most links a route may follow, a longer route
+		// is not found. The zero value falls back to DefaultMaxRouteHops.
+		MaxRouteHops int
+
+		// MaxOffset, when non-zero, is the largest offset a list request
+		// may use.
+		MaxOffset int
+
+		// DefaultSort is the order of a list when the request does not give
+		// one, the zero value falls back to ascending by creation time.
+		DefaultSort arcade.Sort
+
+		// NotFoundForMissingFilter, when set, causes a list request filtered
+		// by an owner or parent that does not exist to fail with a not found
+		// error, rather than returning an empty list.
+		NotFoundForMissingFilter bool
+
+		// CoerceNumericIDs, when set, accepts ids given as json numbers in
+		// create and update requests, converting them into strings.
+		CoerceNumericIDs bool
+
+		// RejectUnknownFields, when set, rejects a request body giving a
+		// field the request does not know, rather than ignoring it.
+		RejectUnknownFields bool
+
+		// StrictQueryParams, when set, rejects a list request given a query
+		// parameter it does not know, rather than ignoring it.
+		StrictQueryParams bool
+
+		// MaxBatchSize is the most roomIDs a bulk request may give, the zero
+		// value falls back to DefaultMaxBatchSize.
+		MaxBatchSize int
+
+		// TrailingSlash is the handling of a route given with a trailing
+		// slash.
+		TrailingSlash TrailingSlash
+
+		// StrictImmutableFields, when set, rejects an update request giving
+		// the created timestamp or a different roomID, rather than ignoring
+		// them.
+		StrictImmutableFields bool
 	}
 )
 
 // Register sets up the http handler for this service with the given router.
 func (s RoomsService) Register(router *mux.Router) {
 	r := router.PathPrefix(RoomsRoute).Subrouter()
+	r.HandleFunc("/tags", s.AddTag).Methods(http.MethodPost)
+	r.HandleFunc("/tags", s.RemoveTag).Methods(http.MethodDelete)
+	r.HandleFunc("/exists", s.Exists).Methods(http.MethodPost)
+	r.HandleFunc("/{roomID}/merge-into/{intoID}", s.Merge).Methods(http.MethodPost)
+	r.HandleFunc("/{roomID}/rename", s.Rename).Methods(http.MethodPost)
+	r.HandleFunc("/{roomID}/route/{toID}", s.Route).Methods(http.MethodGet)
+	r.HandleFunc("/{roomID}/nearby", s.Nearby).Methods(http.MethodGet)
+	r.HandleFunc("/{roomID}/contents", s.Contents).Methods(http.MethodGet)
+	r.HandleFunc("/{roomID}/occupants", s.Occupants).Methods(http.MethodGet)
+	r.HandleFunc("/{roomID}/cascade-preview", s.CascadePreview).Methods(http.MethodGet)
 	r.HandleFunc("", s.List).Methods(http.MethodGet)
 	r.HandleFunc("/{roomID}", s.Get).Methods(http.MethodGet)
 	r.HandleFunc("", s.Create).Methods(http.MethodPost)
 	r.HandleFunc("/{roomID}", s.Update).Methods(http.MethodPut)
 	r.HandleFunc("/{roomID}", s.Remove).Methods(http.MethodDelete)
+	handleTrailingSlash(router, r, s.TrailingSlash)
 }
 
 // Name returns the name of the service.
@@ -61,25 +141,72 @@ func (RoomsService) Shutdown() {}
 func (s RoomsService) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// A client may ask for a fresh list, bypassing any cache.
+	if strings.Contains(r.Header.Get("Cache-Control"), "no-cache") {
+		ctx = arcade.WithoutCache(ctx)
+	}
+
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "ownerID", "parentID", "name", "createdBy", "createdWithin", "sort", "limit", "offset"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	// A name as the sole filter resolves to a single room.
+	if q := r.URL.Query(); len(q) == 1 && q.Has("name") {
+		s.getByName(w, r, q.Get("name"))
+		return
+	}
+
 	// Create the filter.
 	filter, err := arcade.NewRoomsFilter(r)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
+		return
+	}
+	if filter.Sort == (arcade.Sort{}) {
+		filter.Sort = s.DefaultSort
+	}
+	if s.MaxOffset > 0 && filter.Offset > s.MaxOffset {
+		response(w, r, fmt.Errorf(
+			"%w: offset too large, use cursor pagination", cerrors.ErrInvalidArgument,
+		))
 		return
 	}
+	if s.NotFoundForMissingFilter {
+		if filter.OwnerID != nil && s.Players != nil {
+			if _, err := s.Players.Get(ctx, filter.OwnerID.String()); err != nil {
+				response(w, r, err)
+				return
+			}
+		}
+		if filter.ParentID != nil {
+			if _, err := s.Storage.Get(ctx, filter.ParentID.String()); err != nil {
+				response(w, r, err)
+				return
+			}
+		}
+	}
 
 	// Read list of rooms.
 	rooms, err := s.Storage.List(ctx, filter)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	// Return list as body.
+	resp := arcade.NewRoomsResponse(rooms)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(RoomsRoute, resp.Data[i].ID)
+	}
+	resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(rooms))
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.NewRoomsResponse(rooms))
+	err = encoder(w, r).Encode(resp)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to create response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -95,14 +222,36 @@ func (s RoomsService) Get(w http.ResponseWriter, r *http.Request) {
 
 	room, err := s.Storage.Get(ctx, roomID)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	room.Hyperlinks = selfLink(RoomsRoute, room.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.RoomResponse{Data: room})
+	err = encoder(w, r).Encode(arcade.RoomResponse{Data: room})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// getByName handles a request to retrieve a room by name.
+func (s RoomsService) getByName(w http.ResponseWriter, r *http.Request, name string) {
+	room, err := s.Storage.GetByName(r.Context(), name)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	room.Hyperlinks = selfLink(RoomsRoute, room.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomResponse{Data: room})
+	if err != nil {
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -115,7 +264,7 @@ func (s RoomsService) Create(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -123,31 +272,31 @@ func (s RoomsService) Create(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
 	var req arcade.RoomRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	room, err := s.Storage.Create(ctx, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	room.Hyperlinks = selfLink(RoomsRoute, room.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.RoomResponse{Data: room})
+	err = encoder(w, r).Encode(arcade.RoomResponse{Data: room})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -163,7 +312,7 @@ func (s RoomsService) Update(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
 		))
 		return
@@ -171,31 +320,38 @@ func (s RoomsService) Update(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	if len(body) == 0 {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
 		))
 		return
 	}
 
+	if s.StrictImmutableFields {
+		if err := checkImmutable(body, "roomID", roomID); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
 	var req arcade.RoomRequest
-	err = json.Unmarshal(body, &req)
+	err = decode(body, &req, s.CoerceNumericIDs, s.RejectUnknownFields)
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
-			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
-		))
+		response(w, r, err)
 		return
 	}
 
 	room, err := s.Storage.Update(ctx, roomID, req)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
+	room.Hyperlinks = selfLink(RoomsRoute, room.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(arcade.RoomResponse{Data: room})
+	err = encoder(w, r).Encode(arcade.RoomResponse{Data: room})
 	if err != nil {
-		chttp.Response(ctx, w, fmt.Errorf(
+		response(w, r, fmt.Errorf(
 			"%w: unable to write response: %s", cerrors.ErrInternal, err,
 		))
 		return
@@ -209,11 +365,448 @@ func (s RoomsService) Remove(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	roomID := params["roomID"]
 
-	err := s.Storage.Remove(ctx, roomID)
+	cascade := false
+	if value := r.URL.Query().Get("cascade"); value != "" {
+		var err error
+		cascade, err = strconv.ParseBool(value)
+		if err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid cascade query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+
+	err := s.Storage.Remove(ctx, roomID, cascade)
 	if err != nil {
-		chttp.Response(ctx, w, err)
+		response(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// AddTag handles a request to add a tag to multiple rooms.
+func (s RoomsService) AddTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTag(w, r, s.Storage.AddTag)
+}
+
+// RemoveTag handles a request to remove a tag from multiple rooms.
+func (s RoomsService) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTag(w, r, s.Storage.RemoveTag)
+}
+
+// Merge handles a request to move the contents of a room into another room.
+// The merged room is removed when the remove query param is true.
+func (s RoomsService) Merge(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	remove := false
+	if value := r.URL.Query().Get("remove"); value != "" {
+		var err error
+		remove, err = strconv.ParseBool(value)
+		if err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid remove query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+
+	err := s.Storage.Merge(r.Context(), params["roomID"], params["intoID"], remove)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CascadePreview handles a request for the items and links a removal of a
+// room would affect, without removing it.
+func (s RoomsService) CascadePreview(w http.ResponseWriter, r *http.Request) {
+	preview, err := s.Storage.CascadePreview(r.Context(), mux.Vars(r)["roomID"])
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomCascadePreviewResponse{Data: preview})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Rename handles a request to rename a room.
+func (s RoomsService) Rename(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.RoomRenameRequest
+	err = unmarshal(body, &req, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+
+	room, err := s.Storage.Rename(ctx, params["roomID"], req)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomResponse{Data: room})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Route handles a request for the shortest chain of links from one room to
+// another.
+func (s RoomsService) Route(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	for _, roomID := range []string{params["roomID"], params["toID"]} {
+		if _, err := uuid.Parse(roomID); err != nil {
+			response(w, r, fmt.Errorf(
+				"%w: invalid room id: '%s'", cerrors.ErrInvalidArgument, roomID,
+			))
+			return
+		}
+	}
+
+	max := s.MaxRouteHops
+	if max <= 0 {
+		max = DefaultMaxRouteHops
+	}
+	route, err := s.Links.Route(r.Context(), params["roomID"], params["toID"], max)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RouteResponse{Data: route})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Nearby handles a request for the rooms reachable from a room by following a
+// few links.
+func (s RoomsService) Nearby(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	max := s.MaxNearbyHops
+	if max <= 0 {
+		max = DefaultMaxNearbyHops
+	}
+	hops := 1
+	if value := r.URL.Query().Get("hops"); value != "" {
+		var err error
+		hops, err = strconv.Atoi(value)
+		if err != nil || hops <= 0 {
+			response(w, r, fmt.Errorf(
+				"%w: invalid hops query parameter: '%s'", cerrors.ErrInvalidArgument, value,
+			))
+			return
+		}
+	}
+	if hops > max {
+		hops = max
+	}
+
+	rooms, err := s.Links.WithinHops(r.Context(), params["roomID"], hops)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomsHopsResponse{Data: rooms})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Occupants handles a request to retrieve a page of the players located in a
+// room.
+func (s RoomsService) Occupants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	roomID := mux.Vars(r)["roomID"]
+
+	rid, err := uuid.Parse(roomID)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid room id: '%s'", cerrors.ErrInvalidArgument, roomID,
+		))
+		return
+	}
+
+	if s.StrictQueryParams {
+		if err := checkQueryParams(r, "lastSeenBefore", "createdBy", "createdWithin", "sort", "limit", "offset"); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	filter, err := arcade.NewPlayersFilter(r)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	filter.LocationID = &rid
+	if s.MaxOffset > 0 && filter.Offset > s.MaxOffset {
+		response(w, r, fmt.Errorf(
+			"%w: offset too large, use cursor pagination", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+	if s.NotFoundForMissingFilter {
+		if _, err := s.Storage.Get(ctx, roomID); err != nil {
+			response(w, r, err)
+			return
+		}
+	}
+
+	players, err := s.Players.List(ctx, filter)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	resp := arcade.NewPlayersResponse(players)
+	for i := range resp.Data {
+		resp.Data[i].Hyperlinks = selfLink(PlayersRoute, resp.Data[i].ID)
+	}
+	resp.Hyperlinks = pageLinks(r, filter.Offset, filter.Limit, len(players))
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(resp)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to create response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Contents handles a request to retrieve a room with a page of its items and
+// its outgoing links in one response. The room must exist before its items
+// and links are fetched, concurrently.
+func (s RoomsService) Contents(w http.ResponseWriter, r *http.Request) {
+	roomID := mux.Vars(r)["roomID"]
+
+	rid, err := uuid.Parse(roomID)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid room id: '%s'", cerrors.ErrInvalidArgument, roomID,
+		))
+		return
+	}
+
+	filter, err := arcade.NewRoomContentsFilter(r)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+	filter.LocationID = &roomID
+
+	room, err := s.Storage.Get(r.Context(), roomID)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	// The first failure cancels the fetches still running.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var (
+		contents = arcade.RoomContents{Room: room}
+		wg       sync.WaitGroup
+		once     sync.Once
+		failed   error
+	)
+	fail := func(part string, err error) {
+		once.Do(func() {
+			failed = fmt.Errorf("failed to get room contents: %s: %w", part, err)
+			cancel()
+		})
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		items, err := s.Items.List(ctx, filter)
+		if err != nil {
+			fail("items", err)
+			return
+		}
+		contents.Items = items
+	}()
+	go func() {
+		defer wg.Done()
+		links, err := s.Links.List(ctx, arcade.LinksFilter{LocationID: &rid})
+		if err != nil {
+			fail("links", err)
+			return
+		}
+		contents.Links = links
+	}()
+	wg.Wait()
+
+	if failed != nil {
+		response(w, r, failed)
+		return
+	}
+
+	contents.Room.Hyperlinks = selfLink(RoomsRoute, contents.Room.ID)
+	for i := range contents.Items {
+		contents.Items[i].Hyperlinks = selfLink(ItemsRoute, contents.Items[i].ID)
+	}
+	for i := range contents.Links {
+		contents.Links[i].Hyperlinks = selfLink(LinksRoute, contents.Links[i].ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomContentsResponse{
+		Data:       contents,
+		Hyperlinks: pageLinks(r, filter.Offset, filter.Limit, len(contents.Items)),
+	})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+// Exists handles a request to check which of multiple rooms exist.
+func (s RoomsService) Exists(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.RoomsExistsRequest
+	err = unmarshal(body, &req, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	if err := checkBatchSize(len(req.RoomIDs), s.MaxBatchSize); err != nil {
+		response(w, r, err)
+		return
+	}
+
+	exists, err := s.Storage.Exists(ctx, req.RoomIDs)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomsExistsResponse{Data: exists})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
+
+func (s RoomsService) updateTag(w http.ResponseWriter, r *http.Request, update func(context.Context, []string, string) (int, error)) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to read request: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		response(w, r, fmt.Errorf(
+			"%w: invalid json: a json encoded body is required", cerrors.ErrInvalidArgument,
+		))
+		return
+	}
+
+	var req arcade.RoomsTagRequest
+	err = unmarshal(body, &req, s.RejectUnknownFields)
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: invalid body: %s", cerrors.ErrInvalidArgument, err,
+		))
+		return
+	}
+	if err := checkBatchSize(len(req.RoomIDs), s.MaxBatchSize); err != nil {
+		response(w, r, err)
+		return
+	}
+
+	count, err := update(ctx, req.RoomIDs, req.Tag)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.RoomsTagResponse{Data: arcade.RoomsTag{Count: count}})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
@@ -23,11 +23,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	cerrors "arcadium.dev/core/errors"
+
 	"arcadium.dev/arcade"
 	ahttp "arcadium.dev/arcade/http"
 )
@@ -55,6 +58,162 @@ func TestRoomsServiceList(t *testing.T) {
 		)
 	})
 
+	t.Run("strict unknown query param", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+		s := ahttp.RoomsService{Storage: m, StrictQueryParams: true}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, ahttp.RoomsRoute+"?ownerId=42&limit=5", nil),
+			http.StatusBadRequest, "invalid argument: unknown query parameter 'ownerId'",
+		)
+
+		if m.listCalled {
+			t.Error("expected list not to be called")
+		}
+	})
+
+	t.Run("strict known query params", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+		s := ahttp.RoomsService{Storage: m, StrictQueryParams: true}
+
+		invokeService(t, s, http.MethodGet, ahttp.RoomsRoute+"?sort=-name&limit=5&offset=5", nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+	})
+
+	t.Run("lenient unknown query param", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+
+		invokeRoomsService(t, m, http.MethodGet, ahttp.RoomsRoute+"?ownerId=42", nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if m.listFilter.OwnerID != nil {
+			t.Errorf("Unexpected owner filter: %s", m.listFilter.OwnerID)
+		}
+	})
+
+	t.Run("bypass cache", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+
+		router := mux.NewRouter()
+		ahttp.RoomsService{Storage: m}.Register(router)
+		r := httptest.NewRequest(http.MethodGet, ahttp.RoomsRoute, nil)
+		r.Header.Set("Cache-Control", "no-cache")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+
+		if !m.listBypassed {
+			t.Error("expected list to bypass the cache")
+		}
+	})
+
+	t.Run("offset too large", func(t *testing.T) {
+		s := ahttp.RoomsService{Storage: &mockRoomsStorage{t: t}, MaxOffset: 100}
+
+		route := fmt.Sprintf("%s?offset=101", ahttp.RoomsRoute)
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusBadRequest,
+			"invalid argument: offset too large, use cursor pagination",
+		)
+	})
+
+	t.Run("offset at maximum", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+		s := ahttp.RoomsService{Storage: m, MaxOffset: 100}
+
+		route := fmt.Sprintf("%s?offset=100", ahttp.RoomsRoute)
+		w := invokeService(t, s, http.MethodGet, route, nil)
+
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, &mockRoomsStorage{t: t}, http.MethodGet, ahttp.RoomsRoute+"?sort=owner", nil),
+			http.StatusBadRequest,
+			"invalid argument: invalid sort query parameter: 'owner'",
+		)
+	})
+
+	t.Run("default sort", func(t *testing.T) {
+		defaultSort := arcade.Sort{Column: "name"}
+
+		m := &mockRoomsStorage{t: t}
+		invokeService(t, ahttp.RoomsService{Storage: m, DefaultSort: defaultSort}, http.MethodGet, ahttp.RoomsRoute, nil)
+		if m.listFilter.Sort != defaultSort {
+			t.Errorf("Unexpected sort: %+v", m.listFilter.Sort)
+		}
+
+		m = &mockRoomsStorage{t: t}
+		invokeService(t, ahttp.RoomsService{Storage: m, DefaultSort: defaultSort}, http.MethodGet, ahttp.RoomsRoute+"?sort=-updated", nil)
+		if expected := (arcade.Sort{Column: "updated", Desc: true}); m.listFilter.Sort != expected {
+			t.Errorf("Unexpected sort: %+v", m.listFilter.Sort)
+		}
+	})
+
+	t.Run("nonexistent owner, empty list", func(t *testing.T) {
+		const ownerID = "ad3bf6ad-1a80-4d47-9e56-a1b4e2cc6d4d"
+		m := &mockRoomsStorage{t: t}
+		p := &mockPlayersStorage{t: t, err: fmt.Errorf("failed to get player: %w", cerrors.ErrNotFound)}
+		s := ahttp.RoomsService{Storage: m, Players: p}
+
+		w := invokeService(t, s, http.MethodGet, ahttp.RoomsRoute+"?ownerID="+ownerID, nil)
+
+		if p.getCalled {
+			t.Error("expected player get not to be called")
+		}
+		if !m.listCalled {
+			t.Error("expected list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
+	t.Run("nonexistent owner, not found", func(t *testing.T) {
+		const ownerID = "ad3bf6ad-1a80-4d47-9e56-a1b4e2cc6d4d"
+		m := &mockRoomsStorage{t: t}
+		p := &mockPlayersStorage{t: t, err: fmt.Errorf("failed to get player: %w", cerrors.ErrNotFound)}
+		s := ahttp.RoomsService{Storage: m, Players: p, NotFoundForMissingFilter: true}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, ahttp.RoomsRoute+"?ownerID="+ownerID, nil),
+			http.StatusNotFound, "failed to get player: not found",
+		)
+
+		if !p.getCalled {
+			t.Error("expected player get to be called")
+		}
+		if m.listCalled {
+			t.Error("expected list not to be called")
+		}
+	})
+
+	t.Run("existing owner, not found enabled", func(t *testing.T) {
+		const ownerID = "ad3bf6ad-1a80-4d47-9e56-a1b4e2cc6d4d"
+		m := &mockRoomsStorage{t: t}
+		p := &mockPlayersStorage{t: t, playerID: ownerID}
+		s := ahttp.RoomsService{Storage: m, Players: p, NotFoundForMissingFilter: true}
+
+		w := invokeService(t, s, http.MethodGet, ahttp.RoomsRoute+"?ownerID="+ownerID, nil)
+
+		if !p.getCalled || !m.listCalled {
+			t.Error("expected player get and list to be called")
+		}
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		err := errors.New("unknown error")
 		m := &mockRoomsStorage{t: t, err: err}
@@ -118,6 +277,589 @@ func TestRoomsServiceList(t *testing.T) {
 	})
 }
 
+func TestRoomsServiceGetByName(t *testing.T) {
+	const (
+		id   = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		name = "Nowhere"
+	)
+
+	t.Run("not found", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to get room by name: %w", cerrors.ErrNotFound)}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodGet, ahttp.RoomsRoute+"?name="+name, nil),
+			http.StatusNotFound, "failed to get room by name: not found",
+		)
+
+		if !m.getByNameCalled {
+			t.Error("expected get by name to be called")
+		}
+	})
+
+	t.Run("not the sole filter", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t}
+
+		invokeRoomsService(t, m, http.MethodGet, ahttp.RoomsRoute+"?name="+name+"&limit=5", nil)
+
+		if m.getByNameCalled || !m.listCalled {
+			t.Error("expected list to be called")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, name: name, room: arcade.Room{ID: id, Name: name}}
+
+		w := invokeRoomsService(t, m, http.MethodGet, ahttp.RoomsRoute+"?name="+name, nil)
+
+		if !m.getByNameCalled {
+			t.Error("expected get by name to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var roomResp arcade.RoomResponse
+		if err := json.NewDecoder(resp.Body).Decode(&roomResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		if roomResp.Data.ID != id || roomResp.Data.Name != name {
+			t.Errorf("Unexpected response data: %+v", roomResp.Data)
+		}
+	})
+}
+
+func TestRoomsServiceExists(t *testing.T) {
+	var (
+		present = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		absent  = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+
+	t.Run("empty body", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodPost, ahttp.RoomsRoute+"/exists", nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to check rooms exist: %w: too many roomIDs, the maximum is 100", cerrors.ErrInvalidArgument)}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodPost, ahttp.RoomsRoute+"/exists", strings.NewReader(`{"roomIDs": ["`+present+`"]}`)),
+			http.StatusBadRequest, "failed to check rooms exist: invalid argument: too many roomIDs, the maximum is 100",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockRoomsStorage{
+			t:       t,
+			roomIDs: []string{present, absent},
+			exists:  map[string]bool{present: true, absent: false},
+		}
+
+		w := invokeRoomsService(t, m, http.MethodPost, ahttp.RoomsRoute+"/exists", strings.NewReader(`{"roomIDs": ["`+present+`", "`+absent+`"]}`))
+
+		if !m.existsCalled {
+			t.Error("expected exists to be called")
+		}
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var existsResp arcade.RoomsExistsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&existsResp); err != nil {
+			t.Fatalf("Failed to json decode response: %s", err)
+		}
+		if len(existsResp.Data) != 2 || !existsResp.Data[present] || existsResp.Data[absent] {
+			t.Errorf("Unexpected response data: %v", existsResp.Data)
+		}
+	})
+}
+
+func TestRoomsServiceMerge(t *testing.T) {
+	const (
+		roomID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		intoID = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+	)
+	route := ahttp.RoomsRoute + "/" + roomID + "/merge-into/" + intoID
+
+	t.Run("invalid remove", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodPost, route+"?remove=maybe", nil),
+			http.StatusBadRequest, "invalid argument: invalid remove query parameter: 'maybe'",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to merge room: %w: cannot merge a room into itself", cerrors.ErrInvalidArgument)}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodPost, ahttp.RoomsRoute+"/"+roomID+"/merge-into/"+roomID, nil),
+			http.StatusBadRequest, "failed to merge room: invalid argument: cannot merge a room into itself",
+		)
+	})
+
+	for _, remove := range []bool{false, true} {
+		t.Run(fmt.Sprintf("success remove %t", remove), func(t *testing.T) {
+			m := &mockRoomsStorage{t: t, roomID: roomID, intoID: intoID, remove: remove}
+
+			w := invokeRoomsService(t, m, http.MethodPost, fmt.Sprintf("%s?remove=%t", route, remove), nil)
+
+			if !m.mergeCalled {
+				t.Error("expected merge to be called")
+			}
+			if w.Result().StatusCode != http.StatusNoContent {
+				t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+			}
+		})
+	}
+}
+
+func TestRoomsServiceCascadePreview(t *testing.T) {
+	const roomID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+	route := ahttp.RoomsRoute + "/" + roomID + "/cascade-preview"
+
+	t.Run("not found", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to preview room cascade: %w", cerrors.ErrNotFound)}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodGet, route, nil),
+			http.StatusNotFound, "failed to preview room cascade: not found",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockRoomsStorage{
+			t:      t,
+			roomID: roomID,
+			preview: arcade.RoomCascadePreview{
+				RoomID: roomID, ItemsCount: 1, LinksCount: 2, ItemIDs: []string{"item-1"}, LinkIDs: []string{"link-1", "link-2"},
+			},
+		}
+
+		w := invokeRoomsService(t, m, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if !m.previewCalled || m.removeCalled {
+			t.Error("Expected a preview without a removal")
+		}
+
+		var previewResp arcade.RoomCascadePreviewResponse
+		if err := json.NewDecoder(resp.Body).Decode(&previewResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if p := previewResp.Data; p.RoomID != roomID || p.ItemsCount != 1 || p.LinksCount != 2 ||
+			len(p.ItemIDs) != 1 || len(p.LinkIDs) != 2 || p.LinkIDs[1] != "link-2" {
+			t.Errorf("Unexpected preview: %+v", p)
+		}
+	})
+}
+
+func TestRoomsServiceRename(t *testing.T) {
+	const roomID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+	route := ahttp.RoomsRoute + "/" + roomID + "/rename"
+
+	t.Run("empty body", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodPost, route, nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: fmt.Errorf("failed to rename room: %w: room name is taken", cerrors.ErrAlreadyExists)}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodPost, route, strings.NewReader(`{"name": "Hall"}`)),
+			http.StatusConflict, "failed to rename room: already exists: room name is taken",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &mockRoomsStorage{
+			t:         t,
+			roomID:    roomID,
+			renameReq: arcade.RoomRenameRequest{Name: "Hall", AutoSuffix: true},
+			room:      arcade.Room{ID: roomID, Name: "Hall (2)"},
+		}
+
+		w := invokeRoomsService(t, m, http.MethodPost, route, strings.NewReader(`{"name": "Hall", "autoSuffix": true}`))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var roomResp arcade.RoomResponse
+		if err := json.NewDecoder(resp.Body).Decode(&roomResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if roomResp.Data.Name != "Hall (2)" {
+			t.Errorf("Unexpected name: %s", roomResp.Data.Name)
+		}
+	})
+}
+
+func TestRoomsServiceMaxBatchSize(t *testing.T) {
+	roomIDs := []string{
+		"c39761fc-5096-4b1c-9d02-c75730b7b8bf",
+		"2564cd4e-ae30-42a9-aaea-a1203ef0414b",
+		"5a0e9b72-7ac5-4a0b-9c1c-7f4d0a7f0c65",
+	}
+	endpoints := []struct {
+		method, route string
+		called        func(*mockRoomsStorage) bool
+	}{
+		{http.MethodPost, ahttp.RoomsRoute + "/tags", func(m *mockRoomsStorage) bool { return m.addTagCalled }},
+		{http.MethodDelete, ahttp.RoomsRoute + "/tags", func(m *mockRoomsStorage) bool { return m.removeTagCalled }},
+		{http.MethodPost, ahttp.RoomsRoute + "/exists", func(m *mockRoomsStorage) bool { return m.existsCalled }},
+	}
+
+	for _, e := range endpoints {
+		t.Run(e.method+" "+e.route, func(t *testing.T) {
+			body := func(ids []string) io.Reader {
+				b, _ := json.Marshal(map[string]interface{}{"roomIDs": ids, "tag": "dark"})
+				return bytes.NewReader(b)
+			}
+
+			m := &mockRoomsStorage{t: t, roomIDs: roomIDs[:2], tag: "dark"}
+			s := ahttp.RoomsService{Storage: m, MaxBatchSize: 2}
+
+			w := invokeService(t, s, e.method, e.route, body(roomIDs[:2]))
+			if w.Result().StatusCode != http.StatusOK || !e.called(m) {
+				t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+			}
+
+			m = &mockRoomsStorage{t: t}
+			s.Storage = m
+			checkRespError(
+				t, invokeService(t, s, e.method, e.route, body(roomIDs)),
+				http.StatusBadRequest, "invalid argument: batch exceeds maximum size 2",
+			)
+			if e.called(m) {
+				t.Error("expected storage not to be called")
+			}
+		})
+	}
+}
+
+func TestRoomsServiceRoute(t *testing.T) {
+	const (
+		fromID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		viaID  = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		toID   = "5a0e9b72-7ac5-4a0b-9c1c-7f4d0a7f0c65"
+	)
+	route := ahttp.RoomsRoute + "/" + fromID + "/route/" + toID
+
+	t.Run("invalid room id", func(t *testing.T) {
+		l := &mockLinksStorage{t: t}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, ahttp.RoomsRoute+"/"+fromID+"/route/42", nil),
+			http.StatusBadRequest, "invalid argument: invalid room id: '42'",
+		)
+	})
+
+	t.Run("max hops", func(t *testing.T) {
+		l := &mockLinksStorage{t: t, links: []arcade.Link{{ID: "link-1", LocationID: fromID, DestinationID: toID}}}
+
+		invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil)
+		if l.hops != ahttp.DefaultMaxRouteHops {
+			t.Errorf("Unexpected hops: %d", l.hops)
+		}
+
+		invokeService(t, ahttp.RoomsService{Links: l, MaxRouteHops: 3}, http.MethodGet, route, nil)
+		if l.hops != 3 {
+			t.Errorf("Unexpected hops: %d", l.hops)
+		}
+	})
+
+	t.Run("links error", func(t *testing.T) {
+		l := &mockLinksStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		l := &mockLinksStorage{t: t}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil),
+			http.StatusNotFound, fmt.Sprintf("not found: no route from room '%s' to room '%s'", fromID, toID),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l := &mockLinksStorage{t: t, links: []arcade.Link{
+			{ID: "link-2", LocationID: viaID, DestinationID: toID},
+			{ID: "link-1", LocationID: fromID, DestinationID: viaID},
+		}}
+
+		w := invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var routeResp arcade.RouteResponse
+		if err := json.NewDecoder(resp.Body).Decode(&routeResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		rooms, links := routeResp.Data.Rooms, routeResp.Data.Links
+		if len(rooms) != 3 || rooms[0] != fromID || rooms[1] != viaID || rooms[2] != toID {
+			t.Errorf("Unexpected rooms: %v", rooms)
+		}
+		if len(links) != 2 || links[0] != "link-1" || links[1] != "link-2" {
+			t.Errorf("Unexpected links: %v", links)
+		}
+	})
+}
+
+func TestRoomsServiceNearby(t *testing.T) {
+	const roomID = "0a4bbf0a-6d0c-4bbd-a5c4-0c5c4ac1b1c9"
+	route := ahttp.RoomsRoute + "/" + roomID + "/nearby"
+
+	t.Run("invalid hops", func(t *testing.T) {
+		l := &mockLinksStorage{t: t}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route+"?hops=0", nil),
+			http.StatusBadRequest, "invalid argument: invalid hops query parameter: '0'",
+		)
+	})
+
+	t.Run("links error", func(t *testing.T) {
+		l := &mockLinksStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("default hops", func(t *testing.T) {
+		l := &mockLinksStorage{t: t}
+
+		invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route, nil)
+
+		if l.hopsRoom != roomID || l.hops != 1 {
+			t.Errorf("Unexpected room and hops: %s %d", l.hopsRoom, l.hops)
+		}
+	})
+
+	t.Run("hops capped", func(t *testing.T) {
+		l := &mockLinksStorage{t: t}
+
+		invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route+"?hops=50", nil)
+		if l.hops != ahttp.DefaultMaxNearbyHops {
+			t.Errorf("Unexpected hops: %d", l.hops)
+		}
+
+		invokeService(t, ahttp.RoomsService{Links: l, MaxNearbyHops: 2}, http.MethodGet, route+"?hops=3", nil)
+		if l.hops != 2 {
+			t.Errorf("Unexpected hops: %d", l.hops)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l := &mockLinksStorage{t: t, nearby: []arcade.RoomHops{
+			{RoomID: "room-1", Hops: 1},
+			{RoomID: "room-2", Hops: 2},
+		}}
+
+		w := invokeService(t, ahttp.RoomsService{Links: l}, http.MethodGet, route+"?hops=2", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+
+		var hopsResp arcade.RoomsHopsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&hopsResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(hopsResp.Data) != 2 || hopsResp.Data[0] != l.nearby[0] || hopsResp.Data[1] != l.nearby[1] {
+			t.Errorf("Unexpected rooms: %+v", hopsResp.Data)
+		}
+	})
+}
+
+func TestRoomsServiceOccupants(t *testing.T) {
+	const (
+		roomID   = "0a4bbf0a-6d0c-4bbd-a5c4-0c5c4ac1b1c9"
+		playerID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+	)
+	route := ahttp.RoomsRoute + "/" + roomID + "/occupants"
+
+	t.Run("invalid room id", func(t *testing.T) {
+		p := &mockPlayersStorage{t: t}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Players: p}, http.MethodGet, ahttp.RoomsRoute+"/42/occupants", nil),
+			http.StatusBadRequest, "invalid argument: invalid room id: '42'",
+		)
+		if p.listCalled {
+			t.Error("Unexpected players list")
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{}, http.MethodGet, route+"?limit=0", nil),
+			http.StatusBadRequest, "invalid argument: invalid limit query parameter: '0'",
+		)
+	})
+
+	t.Run("players error", func(t *testing.T) {
+		p := &mockPlayersStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{Players: p}, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p := &mockPlayersStorage{t: t, players: []arcade.Player{{ID: playerID, LocationID: roomID}}}
+
+		// A locationID query parameter does not override the room.
+		w := invokeService(t, ahttp.RoomsService{Players: p}, http.MethodGet,
+			route+"?locationID=2564cd4e-ae30-42a9-aaea-a1203ef0414b&limit=5&offset=10", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if p.listFilter.LocationID == nil || p.listFilter.LocationID.String() != roomID {
+			t.Errorf("Unexpected location filter: %v", p.listFilter.LocationID)
+		}
+		if p.listFilter.Limit != 5 || p.listFilter.Offset != 10 {
+			t.Errorf("Unexpected page: %d %d", p.listFilter.Limit, p.listFilter.Offset)
+		}
+
+		var playersResp arcade.PlayersResponse
+		if err := json.NewDecoder(resp.Body).Decode(&playersResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(playersResp.Data) != 1 || playersResp.Data[0].ID != playerID || playersResp.Data[0].Hyperlinks == nil {
+			t.Errorf("Unexpected players: %+v", playersResp.Data)
+		}
+	})
+}
+
+func TestRoomsServiceContents(t *testing.T) {
+	const (
+		roomID = "0a4bbf0a-6d0c-4bbd-a5c4-0c5c4ac1b1c9"
+		itemID = "5a0e9b72-7ac5-4a0b-9c1c-7f4d0a7f0c65"
+		linkID = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+	)
+	route := ahttp.RoomsRoute + "/" + roomID + "/contents"
+
+	t.Run("invalid room id", func(t *testing.T) {
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{}, http.MethodGet, ahttp.RoomsRoute+"/42/contents", nil),
+			http.StatusBadRequest, "invalid argument: invalid room id: '42'",
+		)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		checkRespError(
+			t, invokeService(t, ahttp.RoomsService{}, http.MethodGet, route+"?limit=0", nil),
+			http.StatusBadRequest, "invalid argument: invalid limit query parameter: '0'",
+		)
+	})
+
+	t.Run("room not found", func(t *testing.T) {
+		items := &mockItemsStorage{t: t}
+		links := &mockLinksStorage{t: t}
+		s := ahttp.RoomsService{
+			Storage: &mockRoomsStorage{t: t, err: fmt.Errorf("failed to get room: %w", cerrors.ErrNotFound)},
+			Items:   items,
+			Links:   links,
+		}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusNotFound, "failed to get room: not found",
+		)
+		if items.listCalled || links.listCalled {
+			t.Error("Unexpected contents fetched for a missing room")
+		}
+	})
+
+	t.Run("links error", func(t *testing.T) {
+		s := ahttp.RoomsService{
+			Storage: &mockRoomsStorage{t: t, roomID: roomID, room: arcade.Room{ID: roomID}},
+			Items:   &mockItemsStorage{t: t},
+			Links:   &mockLinksStorage{t: t, err: fmt.Errorf("failed to list links: %w: connection lost", cerrors.ErrInternal)},
+		}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route, nil),
+			http.StatusInternalServerError, "failed to get room contents: links: failed to list links: internal error: connection lost",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		items := &mockItemsStorage{t: t, items: []arcade.Item{{ID: itemID, LocationID: roomID}}}
+		links := &mockLinksStorage{t: t, links: []arcade.Link{{ID: linkID, LocationID: roomID}}}
+		s := ahttp.RoomsService{
+			Storage: &mockRoomsStorage{t: t, roomID: roomID, room: arcade.Room{ID: roomID}},
+			Items:   items,
+			Links:   links,
+		}
+
+		w := invokeService(t, s, http.MethodGet, route+"?limit=1&offset=2", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if items.listFilter.LocationID == nil || *items.listFilter.LocationID != roomID {
+			t.Errorf("Unexpected items location filter: %+v", items.listFilter)
+		}
+		if items.listFilter.Limit != 1 || items.listFilter.Offset != 2 {
+			t.Errorf("Unexpected items page: %d %d", items.listFilter.Limit, items.listFilter.Offset)
+		}
+		if links.listFilter.LocationID == nil || links.listFilter.LocationID.String() != roomID {
+			t.Errorf("Unexpected links location filter: %+v", links.listFilter)
+		}
+
+		var contentsResp arcade.RoomContentsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&contentsResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		contents := contentsResp.Data
+		if contents.Room.ID != roomID || contents.Room.Hyperlinks == nil {
+			t.Errorf("Unexpected room: %+v", contents.Room)
+		}
+		if len(contents.Items) != 1 || contents.Items[0].ID != itemID || contents.Items[0].Hyperlinks == nil {
+			t.Errorf("Unexpected items: %+v", contents.Items)
+		}
+		if len(contents.Links) != 1 || contents.Links[0].ID != linkID || contents.Links[0].Hyperlinks == nil {
+			t.Errorf("Unexpected links: %+v", contents.Links)
+		}
+		if contentsResp.Hyperlinks == nil || contentsResp.Hyperlinks.Next == "" || contentsResp.Hyperlinks.Prev == "" {
+			t.Errorf("Unexpected hyperlinks: %+v", contentsResp.Hyperlinks)
+		}
+	})
+}
+
 func TestRoomsServiceGet(t *testing.T) {
 	const (
 		id          = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
@@ -236,8 +978,8 @@ func TestRoomsServiceCreate(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			ParentID:    parentID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockRoomsStorage{t: t, req: req, room: room}
 		body := bytes.NewBufferString(
@@ -330,8 +1072,8 @@ func TestRoomsServiceUpdate(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			ParentID:    parentID,
-			Created:     now,
-			Updated:     now,
+			Created:     arcade.Timestamp{Time: now},
+			Updated:     arcade.Timestamp{Time: now},
 		}
 		m := &mockRoomsStorage{t: t, req: req, roomID: id, room: room}
 		body := bytes.NewBufferString(
@@ -401,9 +1143,99 @@ func TestRoomsServiceRemove(t *testing.T) {
 		if resp.StatusCode != http.StatusNoContent {
 			t.Errorf("Unexpected status: %d", resp.StatusCode)
 		}
+		if m.cascade {
+			t.Error("expected remove not to cascade")
+		}
+	})
+
+	t.Run("invalid cascade", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodDelete, ahttp.RoomsRoute+"/"+id+"?cascade=maybe", nil),
+			http.StatusBadRequest, "invalid argument: invalid cascade query parameter: 'maybe'",
+		)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, roomID: id}
+
+		w := invokeRoomsService(t, m, http.MethodDelete, ahttp.RoomsRoute+"/"+id+"?cascade=true", nil)
+
+		if w.Result().StatusCode != http.StatusNoContent {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if !m.cascade {
+			t.Error("expected remove to cascade")
+		}
 	})
 }
 
+func TestRoomsServiceTags(t *testing.T) {
+	const (
+		tag = "dungeon"
+	)
+	var (
+		roomIDs = []string{"c39761fc-5096-4b1c-9d02-c75730b7b8bf", "2564cd4e-ae30-42a9-aaea-a1203ef0414b"}
+		body    = `{"roomIDs":["` + strings.Join(roomIDs, `","`) + `"],"tag":"` + tag + `"}`
+	)
+
+	t.Run("missing body", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodPost, ahttp.RoomsRoute+"/tags", nil),
+			http.StatusBadRequest, "invalid argument: invalid json: a json encoded body is required",
+		)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		checkRespError(
+			t, invokeRoomsService(t, nil, http.MethodDelete, ahttp.RoomsRoute+"/tags", bytes.NewBufferString(`invalid json`)),
+			http.StatusBadRequest, "invalid argument: invalid body: ",
+		)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		m := &mockRoomsStorage{t: t, err: errors.New("unknown error")}
+
+		checkRespError(
+			t, invokeRoomsService(t, m, http.MethodPost, ahttp.RoomsRoute+"/tags", bytes.NewBufferString(body)),
+			http.StatusInternalServerError, "unknown error",
+		)
+
+		if !m.addTagCalled {
+			t.Error("expected add tag to be called")
+		}
+	})
+
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		t.Run("success "+method, func(t *testing.T) {
+			m := &mockRoomsStorage{t: t, roomIDs: roomIDs, tag: tag, count: 2}
+
+			w := invokeRoomsService(t, m, method, ahttp.RoomsRoute+"/tags", bytes.NewBufferString(body))
+
+			if method == http.MethodPost && !m.addTagCalled {
+				t.Error("expected add tag to be called")
+			}
+			if method == http.MethodDelete && !m.removeTagCalled {
+				t.Error("expected remove tag to be called")
+			}
+			if m.removeCalled {
+				t.Error("unexpected remove call")
+			}
+			resp := w.Result()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Unexpected status: %d", resp.StatusCode)
+			}
+
+			var tagResp arcade.RoomsTagResponse
+			if err := json.NewDecoder(resp.Body).Decode(&tagResp); err != nil {
+				t.Errorf("Failed to json decode response: %s", err)
+			}
+			if tagResp.Data.Count != 2 {
+				t.Errorf("Unexpected count: %d", tagResp.Data.Count)
+			}
+		})
+	}
+}
+
 func invokeRoomsService(t *testing.T, m *mockRoomsStorage, method, target string, body io.Reader) *httptest.ResponseRecorder {
 	t.Helper()
 
@@ -424,17 +1256,41 @@ type (
 		err error
 
 		roomID string
+		name   string
 		req    arcade.RoomRequest
 
 		room  arcade.Room
 		rooms []arcade.Room
 
+		getManyIDs []string
+
+		roomIDs []string
+		tag     string
+		count   int
+
+		exists map[string]bool
+
+		intoID  string
+		remove  bool
+		cascade bool
+
+		renameReq arcade.RoomRenameRequest
+
+		preview arcade.RoomCascadePreview
+
+		listFilter   arcade.RoomsFilter
+		listBypassed bool
+
 		listCalled, getCalled, createCalled, updateCalled, removeCalled bool
+		addTagCalled, removeTagCalled, getByNameCalled, existsCalled    bool
+		mergeCalled, renameCalled, previewCalled                        bool
 	}
 )
 
-func (m *mockRoomsStorage) List(context.Context, arcade.RoomsFilter) ([]arcade.Room, error) {
+func (m *mockRoomsStorage) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Room, error) {
 	m.listCalled = true
+	m.listFilter = filter
+	m.listBypassed = arcade.CacheBypassed(ctx)
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -452,6 +1308,77 @@ func (m *mockRoomsStorage) Get(ctx context.Context, roomID string) (arcade.Room,
 	return m.room, nil
 }
 
+func (m *mockRoomsStorage) GetMany(ctx context.Context, roomIDs []string) ([]arcade.Room, error) {
+	m.getManyIDs = roomIDs
+	if m.err != nil {
+		return nil, m.err
+	}
+	rooms := make([]arcade.Room, 0)
+	for _, room := range append(m.rooms, m.room) {
+		for _, id := range roomIDs {
+			if room.ID == id {
+				rooms = append(rooms, room)
+			}
+		}
+	}
+	return rooms, nil
+}
+
+func (m *mockRoomsStorage) GetByName(ctx context.Context, name string) (arcade.Room, error) {
+	m.getByNameCalled = true
+	if m.err != nil {
+		return arcade.Room{}, m.err
+	}
+	if m.name != name {
+		m.t.Fatalf("get by name: expected name %s, actual name %s", m.name, name)
+	}
+	return m.room, nil
+}
+
+func (m *mockRoomsStorage) Exists(ctx context.Context, roomIDs []string) (map[string]bool, error) {
+	m.existsCalled = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	if strings.Join(m.roomIDs, ",") != strings.Join(roomIDs, ",") {
+		m.t.Fatalf("exists: expected roomIDs %v, actual roomIDs %v", m.roomIDs, roomIDs)
+	}
+	return m.exists, nil
+}
+
+func (m *mockRoomsStorage) Merge(ctx context.Context, roomID, intoID string, remove bool) error {
+	m.mergeCalled = true
+	if m.err != nil {
+		return m.err
+	}
+	if m.roomID != roomID || m.intoID != intoID || m.remove != remove {
+		m.t.Fatalf("merge: expected %s into %s remove %t, actual %s into %s remove %t", m.roomID, m.intoID, m.remove, roomID, intoID, remove)
+	}
+	return nil
+}
+
+func (m *mockRoomsStorage) Rename(ctx context.Context, roomID string, req arcade.RoomRenameRequest) (arcade.Room, error) {
+	m.renameCalled = true
+	if m.err != nil {
+		return arcade.Room{}, m.err
+	}
+	if m.roomID != roomID || m.renameReq != req {
+		m.t.Fatalf("rename: expected %s %+v, actual %s %+v", m.roomID, m.renameReq, roomID, req)
+	}
+	return m.room, nil
+}
+
+func (m *mockRoomsStorage) CascadePreview(ctx context.Context, roomID string) (arcade.RoomCascadePreview, error) {
+	m.previewCalled = true
+	if m.err != nil {
+		return arcade.RoomCascadePreview{}, m.err
+	}
+	if m.roomID != roomID {
+		m.t.Fatalf("cascade preview: expected %s, actual %s", m.roomID, roomID)
+	}
+	return m.preview, nil
+}
+
 func (m *mockRoomsStorage) Create(ctx context.Context, req arcade.RoomRequest) (arcade.Room, error) {
 	m.createCalled = true
 	if m.err != nil {
@@ -477,8 +1404,9 @@ func (m *mockRoomsStorage) Update(ctx context.Context, roomID string, req arcade
 	return m.room, nil
 }
 
-func (m *mockRoomsStorage) Remove(ctx context.Context, roomID string) error {
+func (m *mockRoomsStorage) Remove(ctx context.Context, roomID string, cascade bool) error {
 	m.removeCalled = true
+	m.cascade = cascade
 	if m.err != nil {
 		return m.err
 	}
@@ -487,3 +1415,25 @@ func (m *mockRoomsStorage) Remove(ctx context.Context, roomID string) error {
 	}
 	return nil
 }
+
+func (m *mockRoomsStorage) AddTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	m.addTagCalled = true
+	if m.err != nil {
+		return 0, m.err
+	}
+	if strings.Join(m.roomIDs, ",") != strings.Join(roomIDs, ",") || m.tag != tag {
+		m.t.Fatalf("add tag: expected %v %s, actual %v %s", m.roomIDs, m.tag, roomIDs, tag)
+	}
+	return m.count, nil
+}
+
+func (m *mockRoomsStorage) RemoveTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	m.removeTagCalled = true
+	if m.err != nil {
+		return 0, m.err
+	}
+	if strings.Join(m.roomIDs, ",") != strings.Join(roomIDs, ",") || m.tag != tag {
+		m.t.Fatalf("remove tag: expected %v %s, actual %v %s", m.roomIDs, m.tag, roomIDs, tag)
+	}
+	return m.count, nil
+}
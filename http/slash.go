@@ -0,0 +1,84 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+// TrailingSlash is the handling of an entity route given with a trailing
+// slash, e.g. /items/ rather than /items.
+type TrailingSlash int
+
+const (
+	// TrailingSlashStrict leaves a route given with a trailing slash
+	// unmatched.
+	TrailingSlashStrict TrailingSlash = iota
+
+	// TrailingSlashRedirect permanently redirects a route given with a
+	// trailing slash to the route without it.
+	TrailingSlashRedirect
+
+	// TrailingSlashIgnore serves a route given with a trailing slash as if
+	// it were given without it.
+	TrailingSlashIgnore
+)
+
+// Decode allows the trailing slash handling to be read from the environment
+// by envconfig, as one of strict, redirect or ignore.
+func (t *TrailingSlash) Decode(value string) error {
+	switch value {
+	case "strict":
+		*t = TrailingSlashStrict
+	case "redirect":
+		*t = TrailingSlashRedirect
+	case "ignore":
+		*t = TrailingSlashIgnore
+	default:
+		return fmt.Errorf("%w: invalid trailing slash handling: '%s'", cerrors.ErrInvalidArgument, value)
+	}
+	return nil
+}
+
+// handleTrailingSlash adds a route to the subrouter r, matching the paths
+// with a trailing slash left unmatched by its other routes. Depending upon
+// the handling, the path is redirected, or served by the router without the
+// trailing slash.
+func handleTrailingSlash(router, r *mux.Router, t TrailingSlash) {
+	if t == TrailingSlashStrict {
+		return
+	}
+	r.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		return strings.HasSuffix(req.URL.Path, "/")
+	}).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimRight(r.URL.Path, "/")
+		if t == TrailingSlashRedirect {
+			if r.URL.RawQuery != "" {
+				path += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, path, http.StatusMovedPermanently)
+			return
+		}
+		r = r.Clone(r.Context())
+		r.URL.Path, r.URL.RawPath = path, ""
+		router.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,105 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestTrailingSlash(t *testing.T) {
+	const id = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+
+	t.Run("strict", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+
+		w := invokeService(t, ahttp.ItemsService{Storage: m}, http.MethodGet, ahttp.ItemsRoute+"/", nil)
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if m.listCalled {
+			t.Error("expected list not to be called")
+		}
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		s := ahttp.ItemsService{TrailingSlash: ahttp.TrailingSlashRedirect}
+		redirects := map[string]string{
+			ahttp.ItemsRoute + "/?limit=2":    ahttp.ItemsRoute + "?limit=2",
+			ahttp.ItemsRoute + "/" + id + "/": ahttp.ItemsRoute + "/" + id,
+		}
+		for target, location := range redirects {
+			w := invokeService(t, s, http.MethodGet, target, nil)
+
+			if w.Result().StatusCode != http.StatusMovedPermanently {
+				t.Errorf("Unexpected status for %s: %d", target, w.Result().StatusCode)
+			}
+			if w.Header().Get("Location") != location {
+				t.Errorf("Unexpected location for %s: %s", target, w.Header().Get("Location"))
+			}
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, itemID: id, item: arcade.Item{ID: id}}
+		s := ahttp.ItemsService{Storage: m, TrailingSlash: ahttp.TrailingSlashIgnore}
+
+		w := invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"/?sort=-name", nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if !m.listCalled || m.listFilter.Sort != (arcade.Sort{Column: "name", Desc: true}) {
+			t.Errorf("expected list to be called with the query: %+v", m.listFilter)
+		}
+
+		w = invokeService(t, s, http.MethodGet, ahttp.ItemsRoute+"/"+id+"/", nil)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status: %d", w.Result().StatusCode)
+		}
+		if !m.getCalled {
+			t.Error("expected get to be called")
+		}
+	})
+}
+
+func TestTrailingSlashDecode(t *testing.T) {
+	valid := map[string]ahttp.TrailingSlash{
+		"strict":   ahttp.TrailingSlashStrict,
+		"redirect": ahttp.TrailingSlashRedirect,
+		"ignore":   ahttp.TrailingSlashIgnore,
+	}
+	for value, expected := range valid {
+		var ts ahttp.TrailingSlash
+		if err := ts.Decode(value); err != nil {
+			t.Errorf("Unexpected error for %q: %s", value, err)
+		}
+		if ts != expected {
+			t.Errorf("Unexpected trailing slash for %q: %d", value, ts)
+		}
+	}
+
+	var ts ahttp.TrailingSlash
+	err := ts.Decode("sometimes")
+	expected := "invalid argument: invalid trailing slash handling: 'sometimes'"
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nExpected error: %s\nActual error:   %v", expected, err)
+	}
+}
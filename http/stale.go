@@ -0,0 +1,44 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+)
+
+// StaleReadHeader is the request header asking for the reads of a get or list
+// request to tolerate slightly stale data.
+const StaleReadHeader = "X-Stale-Read"
+
+// StaleReads returns a middleware which lets a GET or HEAD request given the
+// X-Stale-Read header read slightly stale data, which the storage may serve
+// more cheaply. Other requests always read current data.
+func StaleReads() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				if stale, _ := strconv.ParseBool(r.Header.Get(StaleReadHeader)); stale {
+					r = r.WithContext(arcade.WithStaleReads(r.Context()))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
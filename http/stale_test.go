@@ -0,0 +1,58 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestStaleReads(t *testing.T) {
+	for _, test := range []struct {
+		name, method, header string
+		stale                bool
+	}{
+		{"get", http.MethodGet, "true", true},
+		{"head", http.MethodHead, "true", true},
+		{"no header", http.MethodGet, "", false},
+		{"false header", http.MethodGet, "false", false},
+		{"update", http.MethodPut, "true", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var stale bool
+			router := mux.NewRouter()
+			router.Use(ahttp.StaleReads())
+			router.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+				stale = arcade.StaleReadsFromContext(r.Context())
+			})
+
+			r := httptest.NewRequest(test.method, "/items", nil)
+			if test.header != "" {
+				r.Header.Set(ahttp.StaleReadHeader, test.header)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), r)
+
+			if stale != test.stale {
+				t.Errorf("Unexpected stale reads: %t", stale)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+const (
+	StatsRoute string = "/stats"
+)
+
+type (
+	// StatsService serves the entity counts over time. It is meant for
+	// operators, and is registered alongside the telemetry services rather
+	// than the api.
+	StatsService struct {
+		Storage arcade.StatsStorage
+	}
+)
+
+// Register sets up the http handler for this service with the given router.
+func (s StatsService) Register(router *mux.Router) {
+	r := router.PathPrefix(StatsRoute).Subrouter()
+	r.HandleFunc("/history", s.History).Methods(http.MethodGet)
+}
+
+// Name returns the name of the service.
+func (StatsService) Name() string {
+	return "stats"
+}
+
+// Shutdown is a no-op since there no long running processes for this service.
+func (StatsService) Shutdown() {}
+
+// History handles a request for the recorded counts of an entity.
+func (s StatsService) History(w http.ResponseWriter, r *http.Request) {
+	filter, err := arcade.NewStatsHistoryFilter(r)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	points, err := s.Storage.History(r.Context(), filter)
+	if err != nil {
+		response(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = encoder(w, r).Encode(arcade.StatsHistoryResponse{Entity: filter.Entity, Data: points})
+	if err != nil {
+		response(w, r, fmt.Errorf(
+			"%w: unable to write response: %s", cerrors.ErrInternal, err,
+		))
+		return
+	}
+}
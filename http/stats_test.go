@@ -0,0 +1,100 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+type mockStatsStorage struct {
+	filter arcade.StatsHistoryFilter
+	points []arcade.StatsPoint
+	err    error
+}
+
+func (m *mockStatsStorage) Record(context.Context) error {
+	return m.err
+}
+
+func (m *mockStatsStorage) History(_ context.Context, filter arcade.StatsHistoryFilter) ([]arcade.StatsPoint, error) {
+	m.filter = filter
+	return m.points, m.err
+}
+
+func TestStatsServiceName(t *testing.T) {
+	var s ahttp.StatsService
+	if s.Name() != "stats" {
+		t.Errorf("Unexpected service name: %s", s.Name())
+	}
+}
+
+func TestStatsServiceHistory(t *testing.T) {
+	route := ahttp.StatsRoute + "/history"
+
+	t.Run("invalid entity", func(t *testing.T) {
+		s := ahttp.StatsService{Storage: &mockStatsStorage{}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route+"?entity=dragon", nil),
+			http.StatusBadRequest, "invalid argument: invalid entity query parameter: 'dragon'",
+		)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		s := ahttp.StatsService{Storage: &mockStatsStorage{err: errors.New("unknown error")}}
+
+		checkRespError(
+			t, invokeService(t, s, http.MethodGet, route+"?entity=item", nil),
+			http.StatusInternalServerError, "unknown error",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		recorded := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+		m := &mockStatsStorage{points: []arcade.StatsPoint{
+			{Count: 3, Recorded: arcade.Timestamp{Time: recorded}},
+			{Count: 5, Recorded: arcade.Timestamp{Time: recorded.Add(time.Hour)}},
+		}}
+
+		w := invokeService(t, ahttp.StatsService{Storage: m}, http.MethodGet, route+"?entity=item&since=2023-04-01T00:00:00Z", nil)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		if m.filter.Entity != "item" || !m.filter.Since.Equal(time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected filter: %+v", m.filter)
+		}
+
+		var historyResp arcade.StatsHistoryResponse
+		if err := json.NewDecoder(resp.Body).Decode(&historyResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if historyResp.Entity != "item" || len(historyResp.Data) != 2 ||
+			historyResp.Data[0].Count != 3 || historyResp.Data[1].Count != 5 ||
+			!historyResp.Data[1].Recorded.Equal(recorded.Add(time.Hour)) {
+			t.Errorf("Unexpected history: %+v", historyResp)
+		}
+	})
+}
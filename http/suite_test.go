@@ -21,9 +21,24 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
+
 	"arcadium.dev/core/http"
 )
 
+func invokeService(t *testing.T, s http.Service, method, target string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := mux.NewRouter()
+	s.Register(router)
+
+	r := httptest.NewRequest(method, target, body)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	return w
+}
+
 func checkRespError(t *testing.T, w *httptest.ResponseRecorder, status int, errMsg string) {
 	t.Helper()
 
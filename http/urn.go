@@ -0,0 +1,223 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+// IDFormatHeader is the request header selecting the format of the ids of a
+// response, either "urn" or "uuid", overriding the configured format.
+const IDFormatHeader = "X-ID-Format"
+
+// urnPrefix prefixes the typed URN of an id, e.g.
+// urn:arcade:item:c39761fc-5096-4b1c-9d02-c75730b7b8bf.
+const urnPrefix = "urn:arcade:"
+
+// idTypes is the type of the id under each json field, path variable and
+// query parameter.
+var idTypes = map[string]string{
+	"playerID":      "player",
+	"ownerID":       "player",
+	"inventoryID":   "player",
+	"roomID":        "room",
+	"locationID":    "room",
+	"destinationID": "room",
+	"homeID":        "room",
+	"parentID":      "room",
+	"intoID":        "room",
+	"toID":          "room",
+	"linkID":        "link",
+	"itemID":        "item",
+}
+
+// idType returns the type of the id, or ids, under the given key.
+func idType(key string) (string, bool) {
+	typ, ok := idTypes[strings.TrimSuffix(key, "s")]
+	return typ, ok
+}
+
+// fromURN returns the id of the given value under key when it is a URN, or
+// the value as is otherwise. A URN of a type other than that of the key is an
+// invalid argument.
+func fromURN(key, value string) (string, error) {
+	if !strings.HasPrefix(value, urnPrefix) {
+		return value, nil
+	}
+	typ, id, found := strings.Cut(strings.TrimPrefix(value, urnPrefix), ":")
+	if !found || id == "" {
+		return "", fmt.Errorf("%w: invalid %s urn: '%s'", cerrors.ErrInvalidArgument, key, value)
+	}
+	if expected, ok := idType(key); ok && typ != expected {
+		return "", fmt.Errorf("%w: %s must be a urn of type %s: '%s'", cerrors.ErrInvalidArgument, key, expected, value)
+	}
+	return id, nil
+}
+
+type urnIDsKey struct{}
+
+// IDFormat returns a middleware which accepts the ids of the path variables
+// and query parameters of a request as typed URNs, replacing them with their
+// plain ids, and which renders the ids of the responses as URNs by default
+// when urns is set.
+func IDFormat(urns bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.Clone(context.WithValue(r.Context(), urnIDsKey{}, urns))
+
+			// The variables are those of this request alone, so they are
+			// replaced in place.
+			vars := mux.Vars(r)
+			for key, value := range vars {
+				if _, ok := idType(key); !ok {
+					continue
+				}
+				id, err := fromURN(key, value)
+				if err != nil {
+					response(w, r, err)
+					return
+				}
+				vars[key] = id
+			}
+
+			query, replaced := r.URL.Query(), false
+			for key, values := range query {
+				if _, ok := idType(key); !ok {
+					continue
+				}
+				for i, value := range values {
+					id, err := fromURN(key, value)
+					if err != nil {
+						response(w, r, err)
+						return
+					}
+					replaced = replaced || id != value
+					values[i] = id
+				}
+			}
+			if replaced {
+				r.URL.RawQuery = query.Encode()
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// urnIDs returns true when the ids of the response should be rendered as
+// URNs, as given by the X-ID-Format header, or else by the configured format.
+func urnIDs(r *http.Request) bool {
+	switch strings.ToLower(r.Header.Get(IDFormatHeader)) {
+	case "urn":
+		return true
+	case "uuid":
+		return false
+	}
+	urns, _ := r.Context().Value(urnIDsKey{}).(bool)
+	return urns
+}
+
+// urnEncoder is a json encoder rendering the ids of the encoded value as
+// typed URNs.
+type urnEncoder struct {
+	enc *json.Encoder
+}
+
+// Encode writes the json encoding of v, with its ids rendered as URNs.
+func (e urnEncoder) Encode(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := toURNs(d, &buf, ""); err != nil {
+		return err
+	}
+	return e.enc.Encode(json.RawMessage(buf.Bytes()))
+}
+
+// toURNs copies the next json value of the decoder into buf, in order,
+// rendering the non-empty strings under an id key, or in an array under an
+// ids key, as URNs.
+func toURNs(d *json.Decoder, buf *bytes.Buffer, key string) error {
+	token, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '{':
+			buf.WriteByte('{')
+			for i := 0; d.More(); i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				field, err := d.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := json.Marshal(field)
+				buf.Write(name)
+				buf.WriteByte(':')
+				if err := toURNs(d, buf, field.(string)); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte('}')
+		case '[':
+			buf.WriteByte('[')
+			for i := 0; d.More(); i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := toURNs(d, buf, key); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+		}
+		// Consume the closing delimiter.
+		_, err := d.Token()
+		return err
+
+	case string:
+		if typ, ok := idType(key); ok && token != "" {
+			token = urnPrefix + typ + ":" + token
+		}
+		value, _ := json.Marshal(token)
+		buf.Write(value)
+
+	default:
+		value, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	return nil
+}
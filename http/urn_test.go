@@ -0,0 +1,171 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestIDFormat(t *testing.T) {
+	const (
+		itemID      = "c39761fc-5096-4b1c-9d02-c75730b7b8bf"
+		ownerID     = "2564cd4e-ae30-42a9-aaea-a1203ef0414b"
+		locationID  = "6c4d0a7e-32e4-4fd5-a1d8-4a3e2f58c5b0"
+		inventoryID = "b2a0e7e9-8c55-4a2c-9f0d-3f4b3f6e2a11"
+	)
+	item := arcade.Item{ID: itemID, Name: "Lamp", OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+	serve := func(t *testing.T, m *mockItemsStorage, urns bool, r *http.Request) *http.Response {
+		t.Helper()
+		router := mux.NewRouter()
+		router.Use(ahttp.IDFormat(urns))
+		ahttp.ItemsService{Storage: m}.Register(router)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	decodeItem := func(t *testing.T, resp *http.Response) arcade.Item {
+		t.Helper()
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status: %d", resp.StatusCode)
+		}
+		var itemResp arcade.ItemResponse
+		if err := json.NewDecoder(resp.Body).Decode(&itemResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		return itemResp.Data
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, itemID: itemID, item: item}
+		r := httptest.NewRequest(http.MethodGet, ahttp.ItemsRoute+"/urn:arcade:item:"+itemID, nil)
+
+		got := decodeItem(t, serve(t, m, true, r))
+
+		if got.ID != "urn:arcade:item:"+itemID ||
+			got.OwnerID != "urn:arcade:player:"+ownerID ||
+			got.LocationID != "urn:arcade:room:"+locationID ||
+			got.InventoryID != "urn:arcade:player:"+inventoryID ||
+			got.Name != "Lamp" {
+			t.Errorf("Unexpected item: %+v", got)
+		}
+
+		// The rendered urns are accepted back as the ids of an update.
+		m = &mockItemsStorage{t: t, itemID: itemID, item: item, req: arcade.ItemRequest{
+			Name: "Lamp", OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID,
+		}}
+		body, _ := json.Marshal(arcade.ItemRequest{
+			Name: "Lamp", OwnerID: got.OwnerID, LocationID: got.LocationID, InventoryID: got.InventoryID,
+		})
+		r = httptest.NewRequest(http.MethodPut, ahttp.ItemsRoute+"/"+got.ID, bytes.NewReader(body))
+
+		decodeItem(t, serve(t, m, true, r))
+
+		if !m.updateCalled {
+			t.Error("Expected update to be called")
+		}
+	})
+
+	t.Run("uuid header", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, itemID: itemID, item: item}
+		r := httptest.NewRequest(http.MethodGet, ahttp.ItemsRoute+"/"+itemID, nil)
+		r.Header.Set(ahttp.IDFormatHeader, "uuid")
+
+		got := decodeItem(t, serve(t, m, true, r))
+
+		if got.ID != itemID || got.OwnerID != ownerID || got.LocationID != locationID || got.InventoryID != inventoryID {
+			t.Errorf("Unexpected item: %+v", got)
+		}
+	})
+
+	t.Run("urn header", func(t *testing.T) {
+		m := &mockLinksStorage{t: t, links: []arcade.Link{{ID: itemID, OwnerID: ownerID, DestinationID: locationID}}}
+		router := mux.NewRouter()
+		router.Use(ahttp.IDFormat(false))
+		ahttp.LinksService{Storage: m}.Register(router)
+
+		r := httptest.NewRequest(http.MethodGet, ahttp.LinksRoute+"?ownerID=urn:arcade:player:"+ownerID, nil)
+		r.Header.Set(ahttp.IDFormatHeader, "urn")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		var linksResp arcade.LinksResponse
+		if err := json.NewDecoder(resp.Body).Decode(&linksResp); err != nil {
+			t.Fatalf("Failed to decode response: %s", err)
+		}
+		if len(linksResp.Data) != 1 ||
+			linksResp.Data[0].ID != "urn:arcade:link:"+itemID ||
+			linksResp.Data[0].OwnerID != "urn:arcade:player:"+ownerID ||
+			linksResp.Data[0].DestinationID != "urn:arcade:room:"+locationID {
+			t.Errorf("Unexpected links: %+v", linksResp.Data)
+		}
+		if m.listFilter.OwnerID == nil || m.listFilter.OwnerID.String() != ownerID {
+			t.Errorf("Unexpected owner filter: %v", m.listFilter.OwnerID)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		m := &mockItemsStorage{t: t, itemID: itemID, item: item}
+		body, _ := json.Marshal(arcade.ItemRequest{
+			Name: "Lamp", OwnerID: ownerID, LocationID: "urn:arcade:item:" + locationID, InventoryID: inventoryID,
+		})
+		r := httptest.NewRequest(http.MethodPut, ahttp.ItemsRoute+"/"+itemID, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router := mux.NewRouter()
+		router.Use(ahttp.IDFormat(true))
+		ahttp.ItemsService{Storage: m}.Register(router)
+
+		router.ServeHTTP(w, r)
+
+		checkRespError(t, w, http.StatusBadRequest,
+			"invalid argument: locationID must be a urn of type room: 'urn:arcade:item:"+locationID+"'",
+		)
+		if m.updateCalled {
+			t.Error("Unexpected update")
+		}
+	})
+
+	t.Run("path type mismatch", func(t *testing.T) {
+		m := &mockItemsStorage{t: t}
+		r := httptest.NewRequest(http.MethodGet, ahttp.ItemsRoute+"/urn:arcade:room:"+itemID, nil)
+		w := httptest.NewRecorder()
+		router := mux.NewRouter()
+		router.Use(ahttp.IDFormat(false))
+		ahttp.ItemsService{Storage: m}.Register(router)
+
+		router.ServeHTTP(w, r)
+
+		checkRespError(t, w, http.StatusBadRequest,
+			"invalid argument: itemID must be a urn of type item: 'urn:arcade:room:"+itemID+"'",
+		)
+		if m.getCalled {
+			t.Error("Unexpected get")
+		}
+	})
+}
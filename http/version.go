@@ -0,0 +1,55 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http // import "arcadium.dev/arcade/http"
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+)
+
+const (
+	// VersionRoute is the route of the build information.
+	VersionRoute string = "/version"
+)
+
+type (
+	// VersionService reports the build information of the service, so the
+	// deployed build can be confirmed.
+	VersionService struct {
+		Info arcade.Version
+	}
+)
+
+// Register sets up the http handler for this service with the given router.
+func (s VersionService) Register(router *mux.Router) {
+	r := router.PathPrefix(VersionRoute).Subrouter()
+	r.HandleFunc("", s.get).Methods(http.MethodGet)
+}
+
+// Name returns the name of the service.
+func (VersionService) Name() string {
+	return "version"
+}
+
+// Shutdown is a no-op since there no long running processes for this service.
+func (VersionService) Shutdown() {}
+
+func (s VersionService) get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder(w, r).Encode(arcade.VersionResponse{Data: s.Info})
+}
@@ -0,0 +1,68 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"arcadium.dev/arcade"
+	ahttp "arcadium.dev/arcade/http"
+)
+
+func TestVersionServiceRegister(t *testing.T) {
+	info := arcade.Version{
+		Name:    "assets",
+		Version: "v1.2.3",
+		Branch:  "main",
+		Commit:  "0123abc",
+		Date:    "2026-10-16T10:30:00Z",
+		Go:      "go1.18",
+	}
+
+	router := mux.NewRouter()
+	s := ahttp.VersionService{Info: info}
+	s.Register(router)
+
+	r := httptest.NewRequest(http.MethodGet, ahttp.VersionRoute, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	var versionResp arcade.VersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&versionResp); err != nil {
+		t.Fatalf("Failed to json decode response: %s", err)
+	}
+	if versionResp.Data != info {
+		t.Errorf("\nExpected version: %+v\nActual version:   %+v", info, versionResp.Data)
+	}
+}
+
+func TestVersionServiceName(t *testing.T) {
+	var s ahttp.VersionService
+	if s.Name() != "version" {
+		t.Errorf("Unexpected service name: %s", s.Name())
+	}
+}
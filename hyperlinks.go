@@ -0,0 +1,25 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+type (
+	// Hyperlinks are the navigational links of a resource or a page of
+	// resources, encoded as the _links field of a response.
+	Hyperlinks struct {
+		Self string `json:"self,omitempty"`
+		Next string `json:"next,omitempty"`
+		Prev string `json:"prev,omitempty"`
+	}
+)
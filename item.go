@@ -17,6 +17,8 @@ package arcade // import "arcadium.dev/arcade"
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,8 +27,20 @@ import (
 )
 
 const (
-	MaxItemNameLen        = 255
-	MaxItemDescriptionLen = 4096
+	MaxItemNameLen          = 255
+	MaxItemDescriptionLen   = 4096
+	DefaultItemsFilterLimit = 10
+	MaxItemsFilterLimit     = 100
+	MaxItemsSearchQueryLen  = 255
+	ItemsImportBatchSize    = 100
+	ItemsImportMaxRows      = 10000
+	ItemsImportMaxBytes     = 8 << 20
+)
+
+const (
+	// The types of the location of an item.
+	ItemLocationRoom   = "room"
+	ItemLocationPlayer = "player"
 )
 
 type (
@@ -38,17 +52,22 @@ type (
 		OwnerID     string    `json:"ownerID"`
 		LocationID  string    `json:"locationID"`
 		InventoryID string    `json:"inventoryID"`
-		Created     time.Time `json:"created"`
-		Updated     time.Time `json:"updated"`
+		Weight      int       `json:"weight"`
+		CreatedBy   string    `json:"createdBy"`
+		Created     Timestamp `json:"created"`
+		Updated     Timestamp `json:"updated"`
+
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
 	}
 
 	// ItemRequest is the payload of a item create or update request.
 	ItemRequest struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		OwnerID     string `json:"ownerID"`
-		LocationID  string `json:"locationID"`
-		InventoryID string `json:"inventoryID"`
+		Name        string `json:"name" schema:"required"`
+		Description string `json:"description" schema:"required"`
+		OwnerID     string `json:"ownerID" schema:"required,format=uuid"`
+		LocationID  string `json:"locationID" schema:"required,format=uuid"`
+		InventoryID string `json:"inventoryID" schema:"required,format=uuid"`
+		Weight      int    `json:"weight"`
 	}
 
 	// ItemResponse is used to json encoded a single item response.
@@ -58,7 +77,51 @@ type (
 
 	// ItemsResponse is used to json encoded a multi-item response.
 	ItemsResponse struct {
-		Data []Item `json:"data"`
+		Data       []Item      `json:"data"`
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
+	}
+
+	// ItemsChangesResponse is used to json encode the items changed since a
+	// watermark. The watermark is the update time and itemID of the last
+	// item, to be given as the since and sinceID of the next request.
+	ItemsChangesResponse struct {
+		Data        []Item    `json:"data"`
+		Watermark   Timestamp `json:"watermark"`
+		WatermarkID string    `json:"watermarkID,omitempty"`
+	}
+
+	// OwnerCount is the number of items of an owner.
+	OwnerCount struct {
+		OwnerID string `json:"ownerID"`
+		Count   int    `json:"count"`
+	}
+
+	// OwnerCountsResponse is used to json encode a top owners response.
+	OwnerCountsResponse struct {
+		Data []OwnerCount `json:"data"`
+	}
+
+	// ItemLocationID identifies where an item is, either a room or the
+	// inventory of a player, by its type, ItemLocationRoom or
+	// ItemLocationPlayer, and id.
+	ItemLocationID struct {
+		Type string
+		ID   string
+	}
+
+	// ItemLocationCount is the number of items in a location. A room is
+	// given by its locationID, and a player's inventory by its inventoryID.
+	ItemLocationCount struct {
+		Type        string `json:"type"`
+		LocationID  string `json:"locationID,omitempty"`
+		InventoryID string `json:"inventoryID,omitempty"`
+		Count       int    `json:"count"`
+	}
+
+	// ItemLocationCountsResponse is used to json encode an inventory summary
+	// response.
+	ItemLocationCountsResponse struct {
+		Data []ItemLocationCount `json:"data"`
 	}
 
 	// ItemsFilter is used to filter results from a List.
@@ -69,8 +132,91 @@ type (
 		// LocationID filters for items located in the given room.
 		LocationID *string
 
+		// OwnerIDs filters for items owned by any of the given players. A
+		// nil slice does not filter, and an empty one is ignored unless the
+		// storage is configured to match nothing.
+		OwnerIDs []uuid.UUID
+
 		// InventoryID filters for items in the inventory of the given player.
-		InventoryID *string
+		InventoryID *uuid.UUID
+
+		// NeverUpdated, when true, filters for items which have not been
+		// updated since they were created, and when false, for items which
+		// have been.
+		NeverUpdated *bool
+
+		// CreatedBy filters for items created by the given actor.
+		CreatedBy *string
+
+		// CreatedWithin filters for items created within the duration
+		// before now.
+		CreatedWithin *time.Duration
+
+		// AsOf, when set, reads the items as they were at the given time, so
+		// the pages of a list are consistent while the items change.
+		AsOf *time.Time
+
+		// Sort orders the results.
+		Sort Sort
+
+		// Restrict to a subset of the results.
+		Offset int
+		Limit  int
+	}
+
+	// ItemsSwapRequest is the payload of a request to swap the locations of
+	// two items.
+	ItemsSwapRequest struct {
+		ItemIDs []string `json:"itemIDs"`
+	}
+
+	// ItemTransferRequest is the payload of a request to transfer an item
+	// to a new owner.
+	ItemTransferRequest struct {
+		OwnerID string `json:"ownerID"`
+	}
+
+	// ItemsRemoveRequest is the payload of a request to remove multiple
+	// items.
+	ItemsRemoveRequest struct {
+		ItemIDs []string `json:"itemIDs"`
+	}
+
+	// ItemsRemoved is the result of removing multiple items.
+	ItemsRemoved struct {
+		// Count is the number of items removed.
+		Count int `json:"count"`
+	}
+
+	// ItemsRemoveResponse is used to json encode a bulk remove response.
+	ItemsRemoveResponse struct {
+		Data ItemsRemoved `json:"data"`
+	}
+
+	// ItemImportRow is a row of an items import, the request to create an
+	// item given by a line of the import.
+	ItemImportRow struct {
+		Line    int
+		Request ItemRequest
+	}
+
+	// ItemImportResult is the result of importing a row.
+	ItemImportResult struct {
+		Line     int    `json:"line"`
+		Imported bool   `json:"imported"`
+		ItemID   string `json:"itemID,omitempty"`
+		Error    string `json:"error,omitempty"`
+	}
+
+	// ItemsImportResponse is used to json encode an import response.
+	ItemsImportResponse struct {
+		Data []ItemImportResult `json:"data"`
+	}
+
+	// ItemsSearchFilter is used to search for items by name and description.
+	ItemsSearchFilter struct {
+		// Query is the text to search for.
+		Query string
 
 		// Restrict to a subset of the results.
 		Offset int
@@ -85,6 +231,10 @@ type (
 		// Get returns a single item given the itemID.
 		Get(ctx context.Context, itemID string) (Item, error)
 
+		// GetMany returns the items of the given itemIDs which exist,
+		// in no particular order.
+		GetMany(ctx context.Context, itemIDs []string) ([]Item, error)
+
 		// Create a item given the item request, returning the creating item.
 		Create(ctx context.Context, req ItemRequest) (Item, error)
 
@@ -93,9 +243,103 @@ type (
 
 		// Remove deletes the given item from persistent storage.
 		Remove(ctx context.Context, itemID string) error
+
+		// RemoveMany deletes the given items from persistent storage,
+		// returning the number removed. Items which do not exist are skipped.
+		RemoveMany(ctx context.Context, itemIDs []string) (int, error)
+
+		// Exists returns true if the given item exists.
+		Exists(ctx context.Context, itemID string) (bool, error)
+
+		// Search returns a slice of items matching the search filter, ordered
+		// by relevance.
+		Search(ctx context.Context, filter ItemsSearchFilter) ([]Item, error)
+
+		// SwapLocations exchanges the locations of the two given items
+		// atomically.
+		SwapLocations(ctx context.Context, itemID, otherID string) error
+
+		// ChangedSince returns up to limit items after the watermark of the
+		// given time and itemID, in order of update then itemID. Without an
+		// itemID, the items updated after the time are returned.
+		ChangedSince(ctx context.Context, since Timestamp, sinceID string, limit int) ([]Item, error)
+
+		// TopOwners returns the number of items of up to limit owners, in
+		// descending order of count.
+		TopOwners(ctx context.Context, limit int) ([]OwnerCount, error)
+
+		// TotalWeight returns the total weight of the items in the
+		// inventory of the given player.
+		TotalWeight(ctx context.Context, playerID string) (int, error)
+
+		// ListGroupedByLocation returns the number of items of the given
+		// owner in each location, a room or the inventory of a player.
+		ListGroupedByLocation(ctx context.Context, ownerID string) (map[ItemLocationID]int, error)
+
+		// FindOrphans returns the items whose owner, location or inventory
+		// does not exist.
+		FindOrphans(ctx context.Context) ([]Item, error)
+
+		// FixOrphans resets the dangling references of the orphaned items,
+		// returning the items as they were found.
+		FixOrphans(ctx context.Context) ([]Item, error)
+
+		// Transfer reassigns the given item to the given owner, recording
+		// the transfer, returning the transferred item.
+		Transfer(ctx context.Context, itemID, ownerID string) (Item, error)
+
+		// Import creates an item from each of the given rows, returning the
+		// result of each row. When strict, no item is created unless all
+		// of them are.
+		Import(ctx context.Context, rows []ItemImportRow, strict bool) ([]ItemImportResult, error)
+
+		// Snapshot returns the current time of the storage, as of which a
+		// list of items may be read consistently across pages.
+		Snapshot(ctx context.Context) (time.Time, error)
 	}
 )
 
+// Validate returns an error for an invalid swap request. A valid request
+// will return the parsed UUIDs of the two items.
+func (r ItemsSwapRequest) Validate() (uuid.UUID, uuid.UUID, error) {
+	if len(r.ItemIDs) != 2 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: swap requires exactly two itemIDs", errors.ErrInvalidArgument)
+	}
+	var ids [2]uuid.UUID
+	for i, id := range r.ItemIDs {
+		itemID, err := uuid.Parse(id)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid itemID: '%s'", errors.ErrInvalidArgument, id)
+		}
+		ids[i] = itemID
+	}
+	if ids[0] == ids[1] {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: cannot swap an item with itself", errors.ErrInvalidArgument)
+	}
+	return ids[0], ids[1], nil
+}
+
+// Validate returns an error for an invalid bulk remove request. A valid
+// request will return the parsed item UUIDs, without duplicates.
+func (r ItemsRemoveRequest) Validate() ([]uuid.UUID, error) {
+	if len(r.ItemIDs) == 0 {
+		return nil, fmt.Errorf("%w: empty itemIDs", errors.ErrInvalidArgument)
+	}
+	seen := make(map[uuid.UUID]bool, len(r.ItemIDs))
+	itemIDs := make([]uuid.UUID, 0, len(r.ItemIDs))
+	for _, id := range r.ItemIDs {
+		itemID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid itemID: '%s'", errors.ErrInvalidArgument, id)
+		}
+		if !seen[itemID] {
+			seen[itemID] = true
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+	return itemIDs, nil
+}
+
 // Validate returns an error for an invalid item request. A vaild request
 // will return the parsed owner and location UUIDs.
 func (r ItemRequest) Validate() (uuid.UUID, uuid.UUID, uuid.UUID, error) {
@@ -105,12 +349,15 @@ func (r ItemRequest) Validate() (uuid.UUID, uuid.UUID, uuid.UUID, error) {
 	if len(r.Name) > MaxItemNameLen {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: item name exceeds maximum length", errors.ErrInvalidArgument)
 	}
-	if r.Description == "" {
+	if r.Description == "" && RequireDescription {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: empty item description", errors.ErrInvalidArgument)
 	}
 	if len(r.Description) > MaxItemDescriptionLen {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: item description exceeds maximum length", errors.ErrInvalidArgument)
 	}
+	if r.Weight < 0 {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid item weight: %d", errors.ErrInvalidArgument, r.Weight)
+	}
 	ownerID, err := uuid.Parse(r.OwnerID)
 	if err != nil {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid ownerID: '%s'", errors.ErrInvalidArgument, r.OwnerID)
@@ -126,6 +373,21 @@ func (r ItemRequest) Validate() (uuid.UUID, uuid.UUID, uuid.UUID, error) {
 	return ownerID, locationID, inventoryID, nil
 }
 
+// ItemSchema returns the schema of an item create or update request, with the
+// longest name allowed by the given name policy.
+func ItemSchema(names NamePolicy) Schema {
+	schema := NewSchema(ItemRequest{}, map[string]int{
+		"name":        names.MaxNameLen(MaxItemNameLen),
+		"description": MaxItemDescriptionLen,
+	})
+	for i := range schema.Fields {
+		if schema.Fields[i].Name == "description" {
+			schema.Fields[i].Required = RequireDescription
+		}
+	}
+	return schema
+}
+
 // NewItemsResponse returns a items response given a slice of items.
 func NewItemsResponse(rs []Item) ItemsResponse {
 	var resp ItemsResponse
@@ -134,3 +396,134 @@ func NewItemsResponse(rs []Item) ItemsResponse {
 	}
 	return resp
 }
+
+// NewItemsChangesResponse returns a changes response given the items
+// changed since a watermark, in order of update then itemID.
+func NewItemsChangesResponse(items []Item, since Timestamp, sinceID string) ItemsChangesResponse {
+	resp := ItemsChangesResponse{Data: items, Watermark: since, WatermarkID: sinceID}
+	if len(items) > 0 {
+		resp.Watermark = items[len(items)-1].Updated
+		resp.WatermarkID = items[len(items)-1].ID
+	}
+	return resp
+}
+
+// NewItemsFilter creates an ItemsFilter from the given request's URL query
+// parameters.
+func NewItemsFilter(r *http.Request) (ItemsFilter, error) {
+	q := r.URL.Query()
+	var filter ItemsFilter
+
+	if values := q["neverUpdated"]; len(values) > 0 {
+		neverUpdated, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return ItemsFilter{}, fmt.Errorf("%w: invalid neverUpdated query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.NeverUpdated = &neverUpdated
+	}
+
+	// An empty ownerIDs value gives an empty, rather than nil, list.
+	if values, ok := q["ownerIDs"]; ok {
+		filter.OwnerIDs = []uuid.UUID{}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			ownerID, err := uuid.Parse(value)
+			if err != nil {
+				return ItemsFilter{}, fmt.Errorf("%w: invalid ownerIDs query parameter: '%s'", errors.ErrInvalidArgument, value)
+			}
+			filter.OwnerIDs = append(filter.OwnerIDs, ownerID)
+		}
+	}
+
+	createdBy, err := newCreatedBy(q["createdBy"])
+	if err != nil {
+		return ItemsFilter{}, err
+	}
+	filter.CreatedBy = createdBy
+
+	createdWithin, err := newCreatedWithin(q["createdWithin"])
+	if err != nil {
+		return ItemsFilter{}, err
+	}
+	filter.CreatedWithin = createdWithin
+
+	sort, err := newSort(q["sort"])
+	if err != nil {
+		return ItemsFilter{}, err
+	}
+	filter.Sort = sort
+	if filter.CreatedWithin != nil {
+		filter.Sort = recentFirst(filter.Sort)
+	}
+
+	// Without a limit, the list is capped by the storage.
+	if values := q["limit"]; len(values) > 0 {
+		limit, err := strconv.Atoi(values[0])
+		if err != nil || limit <= 0 || limit > MaxItemsFilterLimit {
+			return ItemsFilter{}, fmt.Errorf("%w: invalid limit query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Limit = limit
+	}
+
+	if values := q["offset"]; len(values) > 0 {
+		offset, err := strconv.Atoi(values[0])
+		if err != nil || offset <= 0 {
+			return ItemsFilter{}, fmt.Errorf("%w: invalid offset query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Offset = offset
+	}
+
+	// A cursor continues a list read as of a snapshot.
+	if values := q["cursor"]; len(values) > 0 {
+		if q.Has("offset") {
+			return ItemsFilter{}, fmt.Errorf("%w: cursor and offset query parameters are exclusive", errors.ErrInvalidArgument)
+		}
+		cursor, err := ParseCursor(values[0])
+		if err != nil {
+			return ItemsFilter{}, err
+		}
+		filter.AsOf = &cursor.AsOf
+		filter.Offset = cursor.Offset
+	}
+
+	return filter, nil
+}
+
+// NewItemsSearchFilter creates an ItemsSearchFilter from the given request's
+// URL query parameters.
+func NewItemsSearchFilter(r *http.Request) (ItemsSearchFilter, error) {
+	q := r.URL.Query()
+	filter := ItemsSearchFilter{
+		Limit: DefaultItemsFilterLimit,
+	}
+
+	if values := q["q"]; len(values) > 0 {
+		filter.Query = values[0]
+	}
+	if filter.Query == "" {
+		return ItemsSearchFilter{}, fmt.Errorf("%w: empty q query parameter", errors.ErrInvalidArgument)
+	}
+	if len(filter.Query) > MaxItemsSearchQueryLen {
+		return ItemsSearchFilter{}, fmt.Errorf("%w: q query parameter exceeds maximum length", errors.ErrInvalidArgument)
+	}
+
+	if values := q["limit"]; len(values) > 0 {
+		limit, err := strconv.Atoi(values[0])
+		if err != nil || limit <= 0 || limit > MaxItemsFilterLimit {
+			return ItemsSearchFilter{}, fmt.Errorf("%w: invalid limit query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Limit = limit
+	}
+
+	if values := q["offset"]; len(values) > 0 {
+		offset, err := strconv.Atoi(values[0])
+		if err != nil || offset <= 0 {
+			return ItemsSearchFilter{}, fmt.Errorf("%w: invalid offset query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
@@ -16,6 +16,10 @@ package arcade_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,8 +48,8 @@ func TestItemJSONEncoding(t *testing.T) {
 			OwnerID:     ownerID,
 			LocationID:  locationID,
 			InventoryID: inventoryID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 
 		b, err := json.Marshal(p)
@@ -97,8 +101,8 @@ func TestItemJSONEncoding(t *testing.T) {
 				OwnerID:     ownerID,
 				LocationID:  locationID,
 				InventoryID: inventoryID,
-				Created:     created,
-				Updated:     updated,
+				Created:     arcade.Timestamp{Time: created},
+				Updated:     arcade.Timestamp{Time: updated},
 			},
 		}
 
@@ -131,8 +135,8 @@ func TestItemJSONEncoding(t *testing.T) {
 					OwnerID:     ownerID,
 					LocationID:  locationID,
 					InventoryID: inventoryID,
-					Created:     created,
-					Updated:     updated,
+					Created:     arcade.Timestamp{Time: created},
+					Updated:     arcade.Timestamp{Time: updated},
 				},
 			},
 		}
@@ -205,6 +209,24 @@ func TestItemRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test optional description", func(t *testing.T) {
+		defer func(required bool) { arcade.RequireDescription = required }(arcade.RequireDescription)
+		arcade.RequireDescription = false
+
+		r := arcade.ItemRequest{
+			Name:        randString(42),
+			OwnerID:     uuid.NewString(),
+			LocationID:  uuid.NewString(),
+			InventoryID: uuid.NewString(),
+		}
+
+		_, _, _, err := r.Validate()
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
 	t.Run("test description length", func(t *testing.T) {
 		r := arcade.ItemRequest{
 			Name:        randString(42),
@@ -222,6 +244,24 @@ func TestItemRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test negative weight", func(t *testing.T) {
+		r := arcade.ItemRequest{
+			Name:        randString(42),
+			Description: randString(128),
+			Weight:      -1,
+		}
+
+		_, _, _, err := r.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: invalid item weight: -1"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("test invalid ownerID", func(t *testing.T) {
 		r := arcade.ItemRequest{
 			Name:        randString(42),
@@ -313,8 +353,8 @@ func TestNewItemsReponse(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			LocationID:  locationID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 	)
 	r := arcade.NewItemsResponse([]arcade.Item{p})
@@ -324,8 +364,220 @@ func TestNewItemsReponse(t *testing.T) {
 		r.Data[0].Description != description ||
 		r.Data[0].OwnerID != ownerID ||
 		r.Data[0].LocationID != locationID ||
-		!created.Equal(r.Data[0].Created) ||
-		!updated.Equal(r.Data[0].Updated) {
+		!created.Equal(r.Data[0].Created.Time) ||
+		!updated.Equal(r.Data[0].Updated.Time) {
 		t.Errorf("Unexpected response: %+v", r)
 	}
 }
+
+func TestNewItemsChangesResponse(t *testing.T) {
+	since := arcade.Timestamp{Time: time.Date(2022, time.October, 16, 16, 0, 0, 0, time.UTC)}
+
+	t.Run("no changes", func(t *testing.T) {
+		resp := arcade.NewItemsChangesResponse([]arcade.Item{}, since, "0")
+		if !resp.Watermark.Equal(since.Time) || resp.WatermarkID != "0" {
+			t.Errorf("Unexpected watermark: %s %s", resp.Watermark, resp.WatermarkID)
+		}
+	})
+
+	t.Run("advance", func(t *testing.T) {
+		items := []arcade.Item{
+			{ID: "1", Updated: arcade.Timestamp{Time: since.Add(time.Second)}},
+			{ID: "2", Updated: arcade.Timestamp{Time: since.Add(time.Minute)}},
+		}
+		resp := arcade.NewItemsChangesResponse(items, since, "")
+		if len(resp.Data) != 2 || resp.Data[0].ID != "1" || resp.Data[1].ID != "2" {
+			t.Errorf("Unexpected data: %+v", resp.Data)
+		}
+		if !resp.Watermark.Equal(since.Add(time.Minute)) || resp.WatermarkID != "2" {
+			t.Errorf("Unexpected watermark: %s %s", resp.Watermark, resp.WatermarkID)
+		}
+	})
+}
+
+func TestNewItemsFilter(t *testing.T) {
+	t.Run("invalid neverUpdated", func(t *testing.T) {
+		_, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "neverUpdated=maybe"}})
+
+		expected := "invalid argument: invalid neverUpdated query parameter: 'maybe'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.NeverUpdated != nil {
+			t.Errorf("Unexpected neverUpdated: %t", *filter.NeverUpdated)
+		}
+	})
+
+	for _, neverUpdated := range []bool{true, false} {
+		t.Run(fmt.Sprintf("neverUpdated %t", neverUpdated), func(t *testing.T) {
+			filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: fmt.Sprintf("neverUpdated=%t&sort=-name", neverUpdated)}})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if filter.NeverUpdated == nil || *filter.NeverUpdated != neverUpdated {
+				t.Errorf("Unexpected neverUpdated: %v", filter.NeverUpdated)
+			}
+			if filter.Sort != (arcade.Sort{Column: "name", Desc: true}) {
+				t.Errorf("Unexpected sort: %+v", filter.Sort)
+			}
+		})
+	}
+
+	t.Run("ownerIDs", func(t *testing.T) {
+		a, b := uuid.New(), uuid.New()
+
+		filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "ownerIDs=" + a.String() + "&ownerIDs=" + b.String()}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(filter.OwnerIDs) != 2 || filter.OwnerIDs[0] != a || filter.OwnerIDs[1] != b {
+			t.Errorf("Unexpected ownerIDs: %v", filter.OwnerIDs)
+		}
+
+		filter, err = arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "ownerIDs="}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.OwnerIDs == nil || len(filter.OwnerIDs) != 0 {
+			t.Errorf("Expected empty ownerIDs: %v", filter.OwnerIDs)
+		}
+
+		filter, err = arcade.NewItemsFilter(&http.Request{URL: &url.URL{}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.OwnerIDs != nil {
+			t.Errorf("Expected nil ownerIDs: %v", filter.OwnerIDs)
+		}
+
+		_, err = arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "ownerIDs=42"}})
+		expected := "invalid argument: invalid ownerIDs query parameter: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("empty createdBy", func(t *testing.T) {
+		_, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "createdBy="}})
+
+		expected := "invalid argument: empty createdBy query parameter"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("createdBy", func(t *testing.T) {
+		filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "createdBy=player:42"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.CreatedBy == nil || *filter.CreatedBy != "player:42" {
+			t.Errorf("Unexpected createdBy: %v", filter.CreatedBy)
+		}
+	})
+
+	t.Run("createdWithin", func(t *testing.T) {
+		filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "createdWithin=15m"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.CreatedWithin == nil || *filter.CreatedWithin != 15*time.Minute {
+			t.Errorf("Unexpected createdWithin: %v", filter.CreatedWithin)
+		}
+		if filter.Sort != (arcade.Sort{Column: "created", Desc: true}) {
+			t.Errorf("Unexpected sort: %+v", filter.Sort)
+		}
+
+		filter, err = arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "createdWithin=1h&sort=name"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Sort != (arcade.Sort{Column: "name"}) {
+			t.Errorf("Unexpected sort: %+v", filter.Sort)
+		}
+	})
+
+	for _, value := range []string{"fortnight", "15", "-15m"} {
+		t.Run("invalid createdWithin "+value, func(t *testing.T) {
+			_, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "createdWithin=" + value}})
+
+			expected := "invalid argument: invalid createdWithin query parameter: '" + value + "'"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+		})
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		asOf := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+		cursor := arcade.Cursor{AsOf: asOf, Offset: 20}.String()
+
+		filter, err := arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "limit=10&cursor=" + cursor}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.AsOf == nil || !filter.AsOf.Equal(asOf) || filter.Offset != 20 || filter.Limit != 10 {
+			t.Errorf("Unexpected filter: %+v", filter)
+		}
+
+		_, err = arcade.NewItemsFilter(&http.Request{URL: &url.URL{RawQuery: "offset=10&cursor=" + cursor}})
+
+		expected := "invalid argument: cursor and offset query parameters are exclusive"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+}
+
+func TestNewItemsSearchFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		err  string
+	}{
+		{name: "missing q", q: "", err: "invalid argument: empty q query parameter"},
+		{name: "empty q", q: "q=", err: "invalid argument: empty q query parameter"},
+		{name: "q too long", q: "q=" + strings.Repeat("a", arcade.MaxItemsSearchQueryLen+1), err: "invalid argument: q query parameter exceeds maximum length"},
+		{name: "negative limit", q: "q=sword&limit=-100", err: "invalid argument: invalid limit query parameter: '-100'"},
+		{name: "large limit", q: "q=sword&limit=1000", err: "invalid argument: invalid limit query parameter: '1000'"},
+		{name: "negative offset", q: "q=sword&offset=-100", err: "invalid argument: invalid offset query parameter: '-100'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := arcade.NewItemsSearchFilter(&http.Request{URL: &url.URL{RawQuery: test.q}})
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if err.Error() != test.err {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", test.err, err)
+			}
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		filter, err := arcade.NewItemsSearchFilter(&http.Request{URL: &url.URL{RawQuery: "q=sword&limit=5&offset=10"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Query != "sword" || filter.Limit != 5 || filter.Offset != 10 {
+			t.Errorf("Unexpected filter: %+v", filter)
+		}
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		filter, err := arcade.NewItemsSearchFilter(&http.Request{URL: &url.URL{RawQuery: "q=sword"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Limit != arcade.DefaultItemsFilterLimit {
+			t.Errorf("Unexpected limit: %d", filter.Limit)
+		}
+	})
+}
@@ -17,6 +17,8 @@ package arcade // import "arcadium.dev/arcade"
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +29,7 @@ import (
 const (
 	MaxLinkNameLen        = 255
 	MaxLinkDescriptionLen = 4096
+	MaxLinksFilterLimit   = 100
 )
 
 type (
@@ -38,8 +41,17 @@ type (
 		OwnerID       string    `json:"ownerID"`
 		LocationID    string    `json:"locationID"`
 		DestinationID string    `json:"destinationID"`
-		Created       time.Time `json:"created"`
-		Updated       time.Time `json:"updated"`
+		Capacity      int       `json:"capacity"`
+		CreatedBy     string    `json:"createdBy"`
+		Created       Timestamp `json:"created"`
+		Updated       Timestamp `json:"updated"`
+
+		// LocationName and DestinationName are the names of the rooms the
+		// link connects, given only when the rooms are expanded.
+		LocationName    string `json:"locationName,omitempty"`
+		DestinationName string `json:"destinationName,omitempty"`
+
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
 	}
 
 	// LinkRequest is the payload of a link create or update request.
@@ -49,6 +61,10 @@ type (
 		OwnerID       string `json:"ownerID"`
 		LocationID    string `json:"locationID"`
 		DestinationID string `json:"destinationID"`
+
+		// Capacity is the number of players that may traverse the link at
+		// once, zero is unlimited.
+		Capacity int `json:"capacity"`
 	}
 
 	// LinkResponse is used to json encoded a single link response.
@@ -58,19 +74,46 @@ type (
 
 	// LinksResponse is used to json encoded a multi-link response.
 	LinksResponse struct {
-		Data []Link `json:"data"`
+		Data       []Link      `json:"data"`
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
+	}
+
+	// RoomHops is a room reachable from another by following a number of
+	// links.
+	RoomHops struct {
+		RoomID string `json:"roomID"`
+		Hops   int    `json:"hops"`
+	}
+
+	// RoomsHopsResponse is used to json encode a nearby rooms response.
+	RoomsHopsResponse struct {
+		Data []RoomHops `json:"data"`
 	}
 
 	// LinksFilter is used to filter results from a List.
 	LinksFilter struct {
-		// OwnerID filters for links owned by a given link.
-		OwnerID *string
+		// OwnerID filters for links owned by a given player.
+		OwnerID *uuid.UUID
 
-		// LocationID filters for links located in a location link (non-recursive).
-		LocationID *string
+		// LocationID filters for links located in a given room (non-recursive).
+		LocationID *uuid.UUID
 
 		// DestinationID filters for links connected to the given destination.
-		DestinationID *string
+		DestinationID *uuid.UUID
+
+		// TraversalCountAtLeast filters for links traversed at least the
+		// given number of times.
+		TraversalCountAtLeast *int
+
+		// CreatedBy filters for links created by the given actor.
+		CreatedBy *string
+
+		// CreatedWithin filters for links created within the duration
+		// before now.
+		CreatedWithin *time.Duration
+
+		// Sort orders the results.
+		Sort Sort
 
 		// Restrict to a subset of the results.
 		Offset int
@@ -85,6 +128,18 @@ type (
 		// Get returns a single link given the linkID.
 		Get(ctx context.Context, linkID string) (Link, error)
 
+		// GetMany returns the links of the given linkIDs which exist,
+		// in no particular order.
+		GetMany(ctx context.Context, linkIDs []string) ([]Link, error)
+
+		// GetWithRooms returns a single link given the linkID, with the
+		// names of its location and destination rooms.
+		GetWithRooms(ctx context.Context, linkID string) (Link, error)
+
+		// GetReverse returns the oldest link leading back from the
+		// destination of the given link to its location.
+		GetReverse(ctx context.Context, linkID string) (Link, error)
+
 		// Create a link given the link request, returning the creating link.
 		Create(ctx context.Context, req LinkRequest) (Link, error)
 
@@ -93,6 +148,28 @@ type (
 
 		// Remove deletes the given link from persistent storage.
 		Remove(ctx context.Context, linkID string) error
+
+		// TryTraverse occupies a slot of the given link, returning ErrConflict
+		// when the link is at capacity.
+		TryTraverse(ctx context.Context, linkID string) error
+
+		// Release frees a slot of the given link.
+		Release(ctx context.Context, linkID string) error
+
+		// IncrementTraversal counts a traversal of the given link.
+		IncrementTraversal(ctx context.Context, linkID string) error
+
+		// WithinHops returns the rooms reachable from the given room by
+		// following at most hops links, nearest first.
+		WithinHops(ctx context.Context, roomID string, hops int) ([]RoomHops, error)
+
+		// Route returns the route with the fewest links from one room to
+		// another, following at most hops links.
+		Route(ctx context.Context, fromID, toID string, hops int) (Route, error)
+
+		// FindDangling returns the links whose location or destination room
+		// does not exist.
+		FindDangling(ctx context.Context) ([]Link, error)
 	}
 )
 
@@ -105,7 +182,7 @@ func (r LinkRequest) Validate() (uuid.UUID, uuid.UUID, uuid.UUID, error) {
 	if len(r.Name) > MaxLinkNameLen {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: link name exceeds maximum length", errors.ErrInvalidArgument)
 	}
-	if r.Description == "" {
+	if r.Description == "" && RequireDescription {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: empty link description", errors.ErrInvalidArgument)
 	}
 	if len(r.Description) > MaxLinkDescriptionLen {
@@ -123,6 +200,9 @@ func (r LinkRequest) Validate() (uuid.UUID, uuid.UUID, uuid.UUID, error) {
 	if err != nil {
 		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid destinationID: '%s'", errors.ErrInvalidArgument, r.DestinationID)
 	}
+	if r.Capacity < 0 {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("%w: negative link capacity: %d", errors.ErrInvalidArgument, r.Capacity)
+	}
 	return ownerID, locationID, destinationID, nil
 }
 
@@ -134,3 +214,61 @@ func NewLinksResponse(rs []Link) LinksResponse {
 	}
 	return resp
 }
+
+// NewLinksFilter creates a LinksFilter from the given request's URL query
+// parameters, ordered by the given sort unless filtered by createdWithin
+// without one.
+func NewLinksFilter(r *http.Request, sort Sort) (LinksFilter, error) {
+	q := r.URL.Query()
+	filter := LinksFilter{Sort: sort}
+
+	if values := q["ownerID"]; len(values) > 0 && values[0] != "" {
+		ownerID, err := uuid.Parse(values[0])
+		if err != nil {
+			return LinksFilter{}, fmt.Errorf("%w: invalid ownerID query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.OwnerID = &ownerID
+	}
+
+	createdBy, err := newCreatedBy(q["createdBy"])
+	if err != nil {
+		return LinksFilter{}, err
+	}
+	filter.CreatedBy = createdBy
+
+	createdWithin, err := newCreatedWithin(q["createdWithin"])
+	if err != nil {
+		return LinksFilter{}, err
+	}
+	filter.CreatedWithin = createdWithin
+	if filter.CreatedWithin != nil {
+		filter.Sort = recentFirst(filter.Sort)
+	}
+
+	if values := q["traversalCountAtLeast"]; len(values) > 0 && values[0] != "" {
+		count, err := strconv.Atoi(values[0])
+		if err != nil || count < 0 {
+			return LinksFilter{}, fmt.Errorf("%w: invalid traversalCountAtLeast query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.TraversalCountAtLeast = &count
+	}
+
+	// Without a limit, the list is capped by the storage.
+	if values := q["limit"]; len(values) > 0 {
+		limit, err := strconv.Atoi(values[0])
+		if err != nil || limit <= 0 || limit > MaxLinksFilterLimit {
+			return LinksFilter{}, fmt.Errorf("%w: invalid limit query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Limit = limit
+	}
+
+	if values := q["offset"]; len(values) > 0 {
+		offset, err := strconv.Atoi(values[0])
+		if err != nil || offset <= 0 {
+			return LinksFilter{}, fmt.Errorf("%w: invalid offset query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
@@ -16,6 +16,8 @@ package arcade_test
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -44,8 +46,8 @@ func TestLinkJSONEncoding(t *testing.T) {
 			OwnerID:       ownerID,
 			LocationID:    locationID,
 			DestinationID: destinationID,
-			Created:       created,
-			Updated:       updated,
+			Created:       arcade.Timestamp{Time: created},
+			Updated:       arcade.Timestamp{Time: updated},
 		}
 
 		b, err := json.Marshal(p)
@@ -97,8 +99,8 @@ func TestLinkJSONEncoding(t *testing.T) {
 				OwnerID:       ownerID,
 				LocationID:    locationID,
 				DestinationID: destinationID,
-				Created:       created,
-				Updated:       updated,
+				Created:       arcade.Timestamp{Time: created},
+				Updated:       arcade.Timestamp{Time: updated},
 			},
 		}
 
@@ -131,8 +133,8 @@ func TestLinkJSONEncoding(t *testing.T) {
 					OwnerID:       ownerID,
 					LocationID:    locationID,
 					DestinationID: destinationID,
-					Created:       created,
-					Updated:       updated,
+					Created:       arcade.Timestamp{Time: created},
+					Updated:       arcade.Timestamp{Time: updated},
 				},
 			},
 		}
@@ -205,6 +207,24 @@ func TestLinkRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test optional description", func(t *testing.T) {
+		defer func(required bool) { arcade.RequireDescription = required }(arcade.RequireDescription)
+		arcade.RequireDescription = false
+
+		r := arcade.LinkRequest{
+			Name:          randString(42),
+			OwnerID:       uuid.NewString(),
+			LocationID:    uuid.NewString(),
+			DestinationID: uuid.NewString(),
+		}
+
+		_, _, _, err := r.Validate()
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
 	t.Run("test description length", func(t *testing.T) {
 		r := arcade.LinkRequest{
 			Name:        randString(42),
@@ -279,6 +299,27 @@ func TestLinkRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test negative capacity", func(t *testing.T) {
+		r := arcade.LinkRequest{
+			Name:          randString(42),
+			Description:   randString(128),
+			OwnerID:       uuid.NewString(),
+			LocationID:    uuid.NewString(),
+			DestinationID: uuid.NewString(),
+			Capacity:      -1,
+		}
+
+		_, _, _, err := r.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: negative link capacity: -1"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("success", func(t *testing.T) {
 		r := arcade.LinkRequest{
 			Name:          randString(73),
@@ -313,8 +354,8 @@ func TestNewLinksReponse(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			LocationID:  locationID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 	)
 	r := arcade.NewLinksResponse([]arcade.Link{p})
@@ -324,8 +365,78 @@ func TestNewLinksReponse(t *testing.T) {
 		r.Data[0].Description != description ||
 		r.Data[0].OwnerID != ownerID ||
 		r.Data[0].LocationID != locationID ||
-		!created.Equal(r.Data[0].Created) ||
-		!updated.Equal(r.Data[0].Updated) {
+		!created.Equal(r.Data[0].Created.Time) ||
+		!updated.Equal(r.Data[0].Updated.Time) {
 		t.Errorf("Unexpected response: %+v", r)
 	}
 }
+
+func TestNewLinksFilter(t *testing.T) {
+	t.Run("owner bad uuid", func(t *testing.T) {
+		q := "ownerID=42"
+		_, err := arcade.NewLinksFilter(&http.Request{URL: &url.URL{RawQuery: q}}, arcade.Sort{})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: invalid ownerID query parameter: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("empty created by", func(t *testing.T) {
+		q := "createdBy="
+		_, err := arcade.NewLinksFilter(&http.Request{URL: &url.URL{RawQuery: q}}, arcade.Sort{})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: empty createdBy query parameter"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("bad traversal count", func(t *testing.T) {
+		q := "traversalCountAtLeast=-1"
+		_, err := arcade.NewLinksFilter(&http.Request{URL: &url.URL{RawQuery: q}}, arcade.Sort{})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: invalid traversalCountAtLeast query parameter: '-1'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("created within keeps the given sort", func(t *testing.T) {
+		q := "createdBy=alice&createdWithin=1h&traversalCountAtLeast=3"
+		sort := arcade.Sort{Column: "name"}
+		filter, err := arcade.NewLinksFilter(&http.Request{URL: &url.URL{RawQuery: q}}, sort)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.CreatedBy == nil || *filter.CreatedBy != "alice" {
+			t.Errorf("Unexpected createdBy: %v", filter.CreatedBy)
+		}
+		if filter.CreatedWithin == nil || *filter.CreatedWithin != time.Hour {
+			t.Errorf("Unexpected createdWithin: %v", filter.CreatedWithin)
+		}
+		if filter.TraversalCountAtLeast == nil || *filter.TraversalCountAtLeast != 3 {
+			t.Errorf("Unexpected traversalCountAtLeast: %v", filter.TraversalCountAtLeast)
+		}
+		if filter.Sort != sort {
+			t.Errorf("Unexpected sort: %+v", filter.Sort)
+		}
+	})
+
+	t.Run("created within sorts newest first", func(t *testing.T) {
+		q := "createdWithin=1h"
+		filter, err := arcade.NewLinksFilter(&http.Request{URL: &url.URL{RawQuery: q}}, arcade.Sort{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if expected := (arcade.Sort{Column: "created", Desc: true}); filter.Sort != expected {
+			t.Errorf("Unexpected sort: %+v", filter.Sort)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"context"
+)
+
+type (
+	// TableAnalysis is the result of refreshing the statistics of a table.
+	TableAnalysis struct {
+		Table    string `json:"table"`
+		Analyzed bool   `json:"analyzed"`
+		Error    string `json:"error,omitempty"`
+	}
+
+	// TablesAnalysisResponse is used to json encode an analyze response.
+	TablesAnalysisResponse struct {
+		Data []TableAnalysis `json:"data"`
+	}
+
+	// MaintenanceStorage represents the maintenance of the persistent storage.
+	MaintenanceStorage interface {
+		// Analyze refreshes the statistics of the entity tables, returning
+		// the result for each table.
+		Analyze(ctx context.Context) ([]TableAnalysis, error)
+	}
+)
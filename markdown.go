@@ -0,0 +1,56 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"arcadium.dev/core/errors"
+)
+
+var (
+	// disallowedMarkup matches raw html elements which are dangerous when
+	// rendered, and links using the javascript scheme.
+	disallowedMarkup = regexp.MustCompile(
+		`(?i)<\s*/?\s*(script|style|iframe|object|embed|link|meta)\b|\]\(\s*javascript:|<\s*[a-z][^>]*\son[a-z]+\s*=`,
+	)
+)
+
+// ValidateMarkdown returns an error if the given markdown contains markup
+// that cannot be safely rendered by the client: dangerous raw html outside of
+// a code fence, or an unterminated code fence. Plain text is always valid.
+func ValidateMarkdown(md string) error {
+	var (
+		fence string
+		text  []string
+	)
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		case fence == "":
+			text = append(text, line)
+		}
+	}
+	if fence != "" || disallowedMarkup.MatchString(strings.Join(text, "\n")) {
+		return fmt.Errorf("%w: description contains disallowed markup", errors.ErrInvalidArgument)
+	}
+	return nil
+}
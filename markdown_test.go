@@ -0,0 +1,55 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestValidateMarkdown(t *testing.T) {
+	valid := []string{
+		"A plain description.",
+		"# The Hall\n\nA *long* hall with a [map](https://example.com/map.png).\n\n- one\n- two",
+		"```\ncode <script> in a fence is text\n```",
+		"Less than 3 < 4 and a <b>bold</b> word.",
+	}
+	for _, md := range valid {
+		if err := arcade.ValidateMarkdown(md); err != nil {
+			t.Errorf("Unexpected error for %q: %s", md, err)
+		}
+	}
+
+	invalid := []string{
+		"Hello <script>alert('hi')</script>",
+		"Hello <SCRIPT src=x>",
+		"An <iframe src=\"https://example.com\"></iframe>",
+		"A [link](javascript:alert(1))",
+		"An <img src=x onerror=alert(1)>",
+		"```\nan unterminated fence",
+	}
+	for _, md := range invalid {
+		err := arcade.ValidateMarkdown(md)
+		if err == nil {
+			t.Errorf("Expected an error for %q", md)
+			continue
+		}
+		expected := "invalid argument: description contains disallowed markup"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	}
+}
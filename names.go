@@ -0,0 +1,64 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"arcadium.dev/core/errors"
+)
+
+// NamePolicy restricts the names given to assets. The zero value allows any
+// name.
+type NamePolicy struct {
+	// Pattern, when set, must match a name.
+	Pattern *regexp.Regexp
+
+	// Blocklist holds substrings a name may not contain, ignoring case.
+	Blocklist []string
+
+	// MaxLen, when non-zero, is the longest name allowed, in bytes. It
+	// may not exceed the maximum of the asset's entity, e.g. MaxRoomNameLen,
+	// the size of its name column.
+	MaxLen int
+}
+
+// Validate returns an error if the given name is not allowed by the policy.
+func (p NamePolicy) Validate(name string) error {
+	if p.MaxLen > 0 && len(name) > p.MaxLen {
+		return fmt.Errorf("%w: name exceeds maximum length of %d", errors.ErrInvalidArgument, p.MaxLen)
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(name) {
+		return fmt.Errorf("%w: name does not match required pattern", errors.ErrInvalidArgument)
+	}
+	lower := strings.ToLower(name)
+	for _, word := range p.Blocklist {
+		if word != "" && strings.Contains(lower, strings.ToLower(word)) {
+			return fmt.Errorf("%w: name contains a blocked word", errors.ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
+// MaxNameLen returns the longest name allowed by the policy for an entity
+// whose names are at most max long, max unless the policy sets MaxLen.
+func (p NamePolicy) MaxNameLen(max int) int {
+	if p.MaxLen > 0 {
+		return p.MaxLen
+	}
+	return max
+}
@@ -0,0 +1,77 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"regexp"
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestNamePolicyValidate(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		if err := (arcade.NamePolicy{}).Validate("anything at all 42"); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	p := arcade.NamePolicy{
+		Pattern:   regexp.MustCompile(`^[A-Z][a-z]+( [A-Z][a-z]+)*$`),
+		Blocklist: []string{"darn"},
+	}
+
+	for _, name := range []string{"Drunen", "Sword Of Martin"} {
+		if err := p.Validate(name); err != nil {
+			t.Errorf("Unexpected error for %q: %s", name, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"drunen":      "invalid argument: name does not match required pattern",
+		"Sword of 42": "invalid argument: name does not match required pattern",
+		"Darned Hall": "invalid argument: name contains a blocked word",
+	}
+	for name, expected := range invalid {
+		err := p.Validate(name)
+		if err == nil {
+			t.Errorf("Expected an error for %q", name)
+			continue
+		}
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	}
+}
+
+func TestNamePolicyMaxLen(t *testing.T) {
+	p := arcade.NamePolicy{MaxLen: 5}
+
+	if err := p.Validate("Lamp!"); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	err := p.Validate("Lantern")
+	expected := "invalid argument: name exceeds maximum length of 5"
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+	}
+
+	if max := p.MaxNameLen(255); max != 5 {
+		t.Errorf("Unexpected max name length: %d", max)
+	}
+	if max := (arcade.NamePolicy{}).MaxNameLen(255); max != 255 {
+		t.Errorf("Unexpected max name length: %d", max)
+	}
+}
@@ -36,13 +36,17 @@ const (
 type (
 	// Player is the internal representation of the data related to a player.
 	Player struct {
-		ID          string    `json:"playerID"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		HomeID      string    `json:"homeID"`
-		LocationID  string    `json:"locationID"`
-		Created     time.Time `json:"created"`
-		Updated     time.Time `json:"updated"`
+		ID                string    `json:"playerID"`
+		Name              string    `json:"name"`
+		Description       string    `json:"description"`
+		HomeID            string    `json:"homeID"`
+		LocationID        string    `json:"locationID"`
+		InventoryCapacity int       `json:"inventoryCapacity"`
+		CreatedBy         string    `json:"createdBy"`
+		Created           Timestamp `json:"created"`
+		Updated           Timestamp `json:"updated"`
+
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
 	}
 
 	// PlayerRequest is the payload of a player create or update request.
@@ -51,6 +55,10 @@ type (
 		Description string `json:"description"`
 		HomeID      string `json:"homeID"`
 		LocationID  string `json:"locationID"`
+
+		// InventoryCapacity is the maximum number of items in the player's
+		// inventory, zero is unlimited.
+		InventoryCapacity int `json:"inventoryCapacity"`
 	}
 
 	// PlayerResponse is used to json encoded a single player response.
@@ -60,7 +68,42 @@ type (
 
 	// PlayersResponse is used to json encoded a multi-player resposne.
 	PlayersResponse struct {
-		Data []Player `json:"data"`
+		Data       []Player    `json:"data"`
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
+	}
+
+	// PlayerState is a player with their inventory and current room.
+	PlayerState struct {
+		Player    Player `json:"player"`
+		Inventory []Item `json:"inventory"`
+		Room      Room   `json:"room"`
+	}
+
+	// PlayerStateResponse is used to json encode a player state response.
+	PlayerStateResponse struct {
+		Data PlayerState `json:"data"`
+	}
+
+	// PlayerWeight is the total weight of the items a player carries.
+	PlayerWeight struct {
+		PlayerID string `json:"playerID"`
+		Weight   int    `json:"weight"`
+	}
+
+	// PlayerWeightResponse is used to json encode a player weight response.
+	PlayerWeightResponse struct {
+		Data PlayerWeight `json:"data"`
+	}
+
+	// PlayerSpawn is a player moved into the room created for them.
+	PlayerSpawn struct {
+		Player Player `json:"player"`
+		Room   Room   `json:"room"`
+	}
+
+	// PlayerSpawnResponse is used to json encode a player spawn response.
+	PlayerSpawnResponse struct {
+		Data PlayerSpawn `json:"data"`
 	}
 
 	// PlayersFilter is used to filter results from List.
@@ -68,6 +111,19 @@ type (
 		// LocationID filters for players in a given location.
 		LocationID *uuid.UUID
 
+		// LastSeenBefore filters for players last seen before the given time.
+		LastSeenBefore *time.Time
+
+		// CreatedBy filters for players created by the given actor.
+		CreatedBy *string
+
+		// CreatedWithin filters for players created within the duration
+		// before now.
+		CreatedWithin *time.Duration
+
+		// Sort orders the results.
+		Sort Sort
+
 		// Restrict to a subset of the results.
 		Offset int
 		Limit  int
@@ -81,6 +137,10 @@ type (
 		// Get returns a single player given the playerID.
 		Get(ctx context.Context, playerID string) (Player, error)
 
+		// GetMany returns the players of the given playerIDs which exist,
+		// in no particular order.
+		GetMany(ctx context.Context, playerIDs []string) ([]Player, error)
+
 		// Create a player given the player request, returning the creating player.
 		Create(ctx context.Context, req PlayerRequest) (Player, error)
 
@@ -89,6 +149,13 @@ type (
 
 		// Remove deletes the given player from persistent storage.
 		Remove(ctx context.Context, playerID string) error
+
+		// UpdateLastSeen sets the last seen time of the given player to now.
+		UpdateLastSeen(ctx context.Context, playerID string) error
+
+		// Spawn creates a room given the room request and moves the given
+		// player into it, atomically. Neither happens unless both do.
+		Spawn(ctx context.Context, playerID string, req RoomRequest) (PlayerSpawn, error)
 	}
 )
 
@@ -101,7 +168,7 @@ func (r PlayerRequest) Validate() (uuid.UUID, uuid.UUID, error) {
 	if len(r.Name) > MaxPlayerNameLen {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: player name exceeds maximum length", errors.ErrInvalidArgument)
 	}
-	if r.Description == "" {
+	if r.Description == "" && RequireDescription {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: empty player description", errors.ErrInvalidArgument)
 	}
 	if len(r.Description) > MaxPlayerDescriptionLen {
@@ -115,6 +182,9 @@ func (r PlayerRequest) Validate() (uuid.UUID, uuid.UUID, error) {
 	if err != nil {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid locationID: '%s'", errors.ErrInvalidArgument, r.LocationID)
 	}
+	if r.InventoryCapacity < 0 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: negative player inventory capacity: %d", errors.ErrInvalidArgument, r.InventoryCapacity)
+	}
 	return homeID, locationID, nil
 }
 
@@ -142,6 +212,34 @@ func NewPlayersFilter(r *http.Request) (PlayersFilter, error) {
 		}
 		filter.LocationID = &locationID
 	}
+	if values := q["lastSeenBefore"]; len(values) > 0 {
+		lastSeenBefore, err := time.Parse(time.RFC3339, values[0])
+		if err != nil {
+			return PlayersFilter{}, fmt.Errorf("%w: invalid lastSeenBefore query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.LastSeenBefore = &lastSeenBefore
+	}
+
+	createdBy, err := newCreatedBy(q["createdBy"])
+	if err != nil {
+		return PlayersFilter{}, err
+	}
+	filter.CreatedBy = createdBy
+
+	createdWithin, err := newCreatedWithin(q["createdWithin"])
+	if err != nil {
+		return PlayersFilter{}, err
+	}
+	filter.CreatedWithin = createdWithin
+
+	sort, err := newSort(q["sort"])
+	if err != nil {
+		return PlayersFilter{}, err
+	}
+	filter.Sort = sort
+	if filter.CreatedWithin != nil {
+		filter.Sort = recentFirst(filter.Sort)
+	}
 
 	if values := q["limit"]; len(values) > 0 {
 		limit, err := strconv.Atoi(values[0])
@@ -45,8 +45,8 @@ func TestPlayerJSONEncoding(t *testing.T) {
 			Description: description,
 			HomeID:      homeID,
 			LocationID:  locationID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 
 		b, err := json.Marshal(p)
@@ -95,8 +95,8 @@ func TestPlayerJSONEncoding(t *testing.T) {
 				Description: description,
 				HomeID:      homeID,
 				LocationID:  locationID,
-				Created:     created,
-				Updated:     updated,
+				Created:     arcade.Timestamp{Time: created},
+				Updated:     arcade.Timestamp{Time: updated},
 			},
 		}
 
@@ -127,8 +127,8 @@ func TestPlayerJSONEncoding(t *testing.T) {
 					Description: description,
 					HomeID:      homeID,
 					LocationID:  locationID,
-					Created:     created,
-					Updated:     updated,
+					Created:     arcade.Timestamp{Time: created},
+					Updated:     arcade.Timestamp{Time: updated},
 				},
 			},
 		}
@@ -200,6 +200,23 @@ func TestPlayerRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test optional description", func(t *testing.T) {
+		defer func(required bool) { arcade.RequireDescription = required }(arcade.RequireDescription)
+		arcade.RequireDescription = false
+
+		r := arcade.PlayerRequest{
+			Name:       randString(42),
+			HomeID:     uuid.NewString(),
+			LocationID: uuid.NewString(),
+		}
+
+		_, _, err := r.Validate()
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
 	t.Run("test description length", func(t *testing.T) {
 		r := arcade.PlayerRequest{
 			Name:        randString(42),
@@ -254,6 +271,26 @@ func TestPlayerRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test negative inventory capacity", func(t *testing.T) {
+		r := arcade.PlayerRequest{
+			Name:              randString(42),
+			Description:       randString(128),
+			HomeID:            uuid.NewString(),
+			LocationID:        uuid.NewString(),
+			InventoryCapacity: -1,
+		}
+
+		_, _, err := r.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: negative player inventory capacity: -1"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("success", func(t *testing.T) {
 		r := arcade.PlayerRequest{
 			Name:        randString(73),
@@ -287,8 +324,8 @@ func TestNewPlayersReponse(t *testing.T) {
 			Description: description,
 			HomeID:      homeID,
 			LocationID:  locationID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 	)
 	r := arcade.NewPlayersResponse([]arcade.Player{p})
@@ -298,8 +335,8 @@ func TestNewPlayersReponse(t *testing.T) {
 		r.Data[0].Description != description ||
 		r.Data[0].HomeID != homeID ||
 		r.Data[0].LocationID != locationID ||
-		!created.Equal(r.Data[0].Created) ||
-		!updated.Equal(r.Data[0].Updated) {
+		!created.Equal(r.Data[0].Created.Time) ||
+		!updated.Equal(r.Data[0].Updated.Time) {
 		t.Errorf("Unexpected response: %+v", r)
 	}
 }
@@ -338,6 +375,30 @@ func TestNewPlayersFilter(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid lastSeenBefore", func(t *testing.T) {
+		q := "lastSeenBefore=yesterday"
+		_, err := arcade.NewPlayersFilter(&http.Request{URL: &url.URL{RawQuery: q}})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: invalid lastSeenBefore query parameter: 'yesterday'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("valid lastSeenBefore", func(t *testing.T) {
+		q := "lastSeenBefore=2022-10-16T12:00:00Z"
+		filter, err := arcade.NewPlayersFilter(&http.Request{URL: &url.URL{RawQuery: q}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := time.Date(2022, 10, 16, 12, 0, 0, 0, time.UTC)
+		if filter.LastSeenBefore == nil || !filter.LastSeenBefore.Equal(expected) {
+			t.Errorf("Unexpected lastSeenBefore: %v", filter.LastSeenBefore)
+		}
+	})
+
 	t.Run("negative limit", func(t *testing.T) {
 		q := "limit=-100"
 		_, err := arcade.NewPlayersFilter(&http.Request{URL: &url.URL{RawQuery: q}})
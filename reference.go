@@ -0,0 +1,51 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+// The types of asset a reference may refer to.
+const (
+	PlayerReference = "player"
+	RoomReference   = "room"
+	LinkReference   = "link"
+	ItemReference   = "item"
+)
+
+type (
+	// Reference refers to an asset of any type.
+	Reference struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+
+	// UnresolvedReference is a reference which could not be resolved, with
+	// a note of why.
+	UnresolvedReference struct {
+		Reference
+		Note string `json:"note"`
+	}
+
+	// ResolveResponse is used to json encode a resolve response, mapping the
+	// key of each resolved reference to its asset.
+	ResolveResponse struct {
+		Data       map[string]interface{} `json:"data"`
+		Unresolved []UnresolvedReference  `json:"unresolved,omitempty"`
+	}
+)
+
+// Key returns the key of the referenced asset in a resolve response, its
+// type and id joined by a colon.
+func (r Reference) Key() string {
+	return r.Type + ":" + r.ID
+}
@@ -29,10 +29,16 @@ import (
 const (
 	MaxRoomNameLen          = 255
 	MaxRoomDescriptionLen   = 4096
+	MaxRoomTagLen           = 255
 	DefaultRoomsFilterLimit = 10
 	MaxRoomsFilterLimit     = 100
+	MaxRoomRenameSuffix     = 10
 )
 
+// LimboRoomID is the id of the room Limbo, the default room of players and
+// rooms which have lost theirs.
+const LimboRoomID = "00000000-0000-0000-0000-000000000001"
+
 type (
 	// Room is the internal representation of the data related to a room.
 	Room struct {
@@ -41,8 +47,12 @@ type (
 		Description string    `json:"description"`
 		OwnerID     string    `json:"ownerID"`
 		ParentID    string    `json:"parentID"`
-		Created     time.Time `json:"created"`
-		Updated     time.Time `json:"updated"`
+		Capacity    int       `json:"capacity"`
+		CreatedBy   string    `json:"createdBy"`
+		Created     Timestamp `json:"created"`
+		Updated     Timestamp `json:"updated"`
+
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
 	}
 
 	// RoomRequest is the payload of a room create or update request.
@@ -51,6 +61,10 @@ type (
 		Description string `json:"description"`
 		OwnerID     string `json:"ownerID"`
 		ParentID    string `json:"parentID"`
+
+		// Capacity is the maximum number of players in the room, zero is
+		// unlimited.
+		Capacity int `json:"capacity"`
 	}
 
 	// RoomResponse is used to json encoded a single room response.
@@ -60,7 +74,79 @@ type (
 
 	// RoomsResponse is used to json encoded a multi-room response.
 	RoomsResponse struct {
-		Data []Room `json:"data"`
+		Data       []Room      `json:"data"`
+		Hyperlinks *Hyperlinks `json:"_links,omitempty"`
+	}
+
+	// RoomContents is a room with a page of the items located in it and
+	// its outgoing links.
+	RoomContents struct {
+		Room  Room   `json:"room"`
+		Items []Item `json:"items"`
+		Links []Link `json:"links"`
+	}
+
+	// RoomContentsResponse is used to json encode a room contents response.
+	// The hyperlinks page through the items.
+	RoomContentsResponse struct {
+		Data       RoomContents `json:"data"`
+		Hyperlinks *Hyperlinks  `json:"_links,omitempty"`
+	}
+
+	// RoomCascadePreview is the items and links a removal of a room would
+	// move to the default room, without removing it.
+	RoomCascadePreview struct {
+		RoomID     string   `json:"roomID"`
+		ItemsCount int      `json:"itemsCount"`
+		LinksCount int      `json:"linksCount"`
+		ItemIDs    []string `json:"itemIDs"`
+		LinkIDs    []string `json:"linkIDs"`
+	}
+
+	// RoomCascadePreviewResponse is used to json encode a cascade preview
+	// response.
+	RoomCascadePreviewResponse struct {
+		Data RoomCascadePreview `json:"data"`
+	}
+
+	// RoomRenameRequest is the payload of a request to rename a room.
+	RoomRenameRequest struct {
+		Name string `json:"name"`
+
+		// AutoSuffix, when set, renames the room to the name suffixed with
+		// " (2)", " (3)" and so on, up to MaxRoomRenameSuffix, while the
+		// name is taken.
+		AutoSuffix bool `json:"autoSuffix"`
+	}
+
+	// RoomsTagRequest is the payload of a request to add or remove a tag
+	// from multiple rooms.
+	RoomsTagRequest struct {
+		RoomIDs []string `json:"roomIDs"`
+		Tag     string   `json:"tag"`
+	}
+
+	// RoomsTag is the result of adding or removing a tag from multiple rooms.
+	RoomsTag struct {
+		// Count is the number of rooms changed.
+		Count int `json:"count"`
+	}
+
+	// RoomsTagResponse is used to json encode a tag response.
+	RoomsTagResponse struct {
+		Data RoomsTag `json:"data"`
+	}
+
+	// RoomsExistsRequest is the payload of a request to check which of
+	// multiple rooms exist.
+	RoomsExistsRequest struct {
+		RoomIDs []string `json:"roomIDs"`
+	}
+
+	// RoomsExistsResponse is used to json encode an exists response, mapping
+	// each requested room id to whether the room exists.
+	RoomsExistsResponse struct {
+		Data map[string]bool `json:"data"`
 	}
 
 	// RoomsFilter is used to filter results from a List.
@@ -71,6 +157,16 @@ type (
 		// ParentID filters for rooms located in a parent room (non-recursive).
 		ParentID *uuid.UUID
 
+		// CreatedBy filters for rooms created by the given actor.
+		CreatedBy *string
+
+		// CreatedWithin filters for rooms created within the duration
+		// before now.
+		CreatedWithin *time.Duration
+
+		// Sort orders the results.
+		Sort Sort
+
 		// Restrict to a subset of the results.
 		Offset int
 		Limit  int
@@ -84,14 +180,47 @@ type (
 		// Get returns a single room given the roomID.
 		Get(ctx context.Context, roomID string) (Room, error)
 
+		// GetMany returns the rooms of the given roomIDs which exist,
+		// in no particular order.
+		GetMany(ctx context.Context, roomIDs []string) ([]Room, error)
+
+		// GetByName returns a single room given its name.
+		GetByName(ctx context.Context, name string) (Room, error)
+
 		// Create a room given the room request, returning the creating room.
 		Create(ctx context.Context, req RoomRequest) (Room, error)
 
 		// Update a room given the room request, returning the updated room.
 		Update(ctx context.Context, roomID string, req RoomRequest) (Room, error)
 
-		// Remove deletes the given room from persistent storage.
-		Remove(ctx context.Context, roomID string) error
+		// Remove deletes the given room from persistent storage. When
+		// cascade is set, a room referenced by items or links is removed
+		// even when referenced rooms are protected.
+		Remove(ctx context.Context, roomID string, cascade bool) error
+
+		// AddTag adds the tag to the given rooms, returning the number of
+		// rooms changed. Rooms that already have the tag are unchanged.
+		AddTag(ctx context.Context, roomIDs []string, tag string) (int, error)
+
+		// RemoveTag removes the tag from the given rooms, returning the number
+		// of rooms changed.
+		RemoveTag(ctx context.Context, roomIDs []string, tag string) (int, error)
+
+		// Exists returns whether each of the given rooms exists, keyed by
+		// the given room id.
+		Exists(ctx context.Context, roomIDs []string) (map[string]bool, error)
+
+		// Merge moves the items and links in a room into another room, and
+		// redirects the links to it, optionally removing the merged room.
+		Merge(ctx context.Context, roomID, intoID string, remove bool) error
+
+		// Rename changes the name of the given room, returning the renamed
+		// room.
+		Rename(ctx context.Context, roomID string, req RoomRenameRequest) (Room, error)
+
+		// CascadePreview returns the items and links a removal of the given
+		// room would move to the default room, without removing it.
+		CascadePreview(ctx context.Context, roomID string) (RoomCascadePreview, error)
 	}
 )
 
@@ -104,7 +233,7 @@ func (r RoomRequest) Validate() (uuid.UUID, uuid.UUID, error) {
 	if len(r.Name) > MaxRoomNameLen {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: room name exceeds maximum length", errors.ErrInvalidArgument)
 	}
-	if r.Description == "" {
+	if r.Description == "" && RequireDescription {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: empty room description", errors.ErrInvalidArgument)
 	}
 	if len(r.Description) > MaxRoomDescriptionLen {
@@ -118,9 +247,63 @@ func (r RoomRequest) Validate() (uuid.UUID, uuid.UUID, error) {
 	if err != nil {
 		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid parentID: '%s'", errors.ErrInvalidArgument, r.ParentID)
 	}
+	if r.Capacity < 0 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: negative room capacity: %d", errors.ErrInvalidArgument, r.Capacity)
+	}
 	return ownerID, parentID, nil
 }
 
+// Validate returns an error for an invalid room rename request.
+func (r RoomRenameRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: empty room name", errors.ErrInvalidArgument)
+	}
+	if len(r.Name) > MaxRoomNameLen {
+		return fmt.Errorf("%w: room name exceeds maximum length", errors.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Validate returns an error for an invalid rooms tag request. A valid request
+// will return the parsed room UUIDs.
+func (r RoomsTagRequest) Validate() ([]uuid.UUID, error) {
+	if r.Tag == "" {
+		return nil, fmt.Errorf("%w: empty room tag", errors.ErrInvalidArgument)
+	}
+	if len(r.Tag) > MaxRoomTagLen {
+		return nil, fmt.Errorf("%w: room tag exceeds maximum length", errors.ErrInvalidArgument)
+	}
+	if len(r.RoomIDs) == 0 {
+		return nil, fmt.Errorf("%w: empty roomIDs", errors.ErrInvalidArgument)
+	}
+	roomIDs := make([]uuid.UUID, 0, len(r.RoomIDs))
+	for _, id := range r.RoomIDs {
+		roomID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid roomID: '%s'", errors.ErrInvalidArgument, id)
+		}
+		roomIDs = append(roomIDs, roomID)
+	}
+	return roomIDs, nil
+}
+
+// Validate returns an error for an invalid rooms exists request. A valid
+// request will return the parsed room UUIDs.
+func (r RoomsExistsRequest) Validate() ([]uuid.UUID, error) {
+	if len(r.RoomIDs) == 0 {
+		return nil, fmt.Errorf("%w: empty roomIDs", errors.ErrInvalidArgument)
+	}
+	roomIDs := make([]uuid.UUID, 0, len(r.RoomIDs))
+	for _, id := range r.RoomIDs {
+		roomID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid roomID: '%s'", errors.ErrInvalidArgument, id)
+		}
+		roomIDs = append(roomIDs, roomID)
+	}
+	return roomIDs, nil
+}
+
 // NewRoomsResponse returns a rooms response given a slice of rooms.
 func NewRoomsResponse(rs []Room) RoomsResponse {
 	var resp RoomsResponse
@@ -153,6 +336,27 @@ func NewRoomsFilter(r *http.Request) (RoomsFilter, error) {
 		filter.ParentID = &parentID
 	}
 
+	createdBy, err := newCreatedBy(q["createdBy"])
+	if err != nil {
+		return RoomsFilter{}, err
+	}
+	filter.CreatedBy = createdBy
+
+	createdWithin, err := newCreatedWithin(q["createdWithin"])
+	if err != nil {
+		return RoomsFilter{}, err
+	}
+	filter.CreatedWithin = createdWithin
+
+	sort, err := newSort(q["sort"])
+	if err != nil {
+		return RoomsFilter{}, err
+	}
+	filter.Sort = sort
+	if filter.CreatedWithin != nil {
+		filter.Sort = recentFirst(filter.Sort)
+	}
+
 	if values := q["limit"]; len(values) > 0 {
 		limit, err := strconv.Atoi(values[0])
 		if err != nil || limit <= 0 || limit > MaxRoomsFilterLimit {
@@ -171,3 +375,30 @@ func NewRoomsFilter(r *http.Request) (RoomsFilter, error) {
 
 	return filter, nil
 }
+
+// NewRoomContentsFilter creates the ItemsFilter paging through the items of
+// a room's contents from the given request's URL query parameters.
+func NewRoomContentsFilter(r *http.Request) (ItemsFilter, error) {
+	q := r.URL.Query()
+	filter := ItemsFilter{
+		Limit: DefaultItemsFilterLimit,
+	}
+
+	if values := q["limit"]; len(values) > 0 {
+		limit, err := strconv.Atoi(values[0])
+		if err != nil || limit <= 0 || limit > MaxItemsFilterLimit {
+			return ItemsFilter{}, fmt.Errorf("%w: invalid limit query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Limit = limit
+	}
+
+	if values := q["offset"]; len(values) > 0 {
+		offset, err := strconv.Atoi(values[0])
+		if err != nil || offset <= 0 {
+			return ItemsFilter{}, fmt.Errorf("%w: invalid offset query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
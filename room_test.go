@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,8 +46,8 @@ func TestRoomJSONEncoding(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			ParentID:    parentID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 
 		b, err := json.Marshal(p)
@@ -95,8 +96,8 @@ func TestRoomJSONEncoding(t *testing.T) {
 				Description: description,
 				OwnerID:     ownerID,
 				ParentID:    parentID,
-				Created:     created,
-				Updated:     updated,
+				Created:     arcade.Timestamp{Time: created},
+				Updated:     arcade.Timestamp{Time: updated},
 			},
 		}
 
@@ -127,8 +128,8 @@ func TestRoomJSONEncoding(t *testing.T) {
 					Description: description,
 					OwnerID:     ownerID,
 					ParentID:    parentID,
-					Created:     created,
-					Updated:     updated,
+					Created:     arcade.Timestamp{Time: created},
+					Updated:     arcade.Timestamp{Time: updated},
 				},
 			},
 		}
@@ -200,6 +201,23 @@ func TestRoomRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test optional description", func(t *testing.T) {
+		defer func(required bool) { arcade.RequireDescription = required }(arcade.RequireDescription)
+		arcade.RequireDescription = false
+
+		r := arcade.RoomRequest{
+			Name:     randString(42),
+			OwnerID:  uuid.NewString(),
+			ParentID: uuid.NewString(),
+		}
+
+		_, _, err := r.Validate()
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
 	t.Run("test description length", func(t *testing.T) {
 		r := arcade.RoomRequest{
 			Name:        randString(42),
@@ -254,6 +272,26 @@ func TestRoomRequestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("test negative capacity", func(t *testing.T) {
+		r := arcade.RoomRequest{
+			Name:        randString(42),
+			Description: randString(128),
+			OwnerID:     uuid.NewString(),
+			ParentID:    uuid.NewString(),
+			Capacity:    -1,
+		}
+
+		_, _, err := r.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: negative room capacity: -1"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("success", func(t *testing.T) {
 		r := arcade.RoomRequest{
 			Name:        randString(73),
@@ -270,6 +308,118 @@ func TestRoomRequestValidate(t *testing.T) {
 	})
 }
 
+func TestRoomsTagRequestValidate(t *testing.T) {
+	t.Run("test empty tag", func(t *testing.T) {
+		_, err := arcade.RoomsTagRequest{RoomIDs: []string{uuid.NewString()}}.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: empty room tag"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test tag length", func(t *testing.T) {
+		r := arcade.RoomsTagRequest{RoomIDs: []string{uuid.NewString()}, Tag: randString(arcade.MaxRoomTagLen + 1)}
+
+		_, err := r.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: room tag exceeds maximum length"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test empty roomIDs", func(t *testing.T) {
+		_, err := arcade.RoomsTagRequest{Tag: "dungeon"}.Validate()
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: empty roomIDs"
+		if expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test success", func(t *testing.T) {
+		id := uuid.New()
+
+		ids, err := arcade.RoomsTagRequest{RoomIDs: []string{id.String()}, Tag: "dungeon"}.Validate()
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(ids) != 1 || ids[0] != id {
+			t.Errorf("Unexpected roomIDs: %v", ids)
+		}
+	})
+}
+
+func TestRoomsExistsRequestValidate(t *testing.T) {
+	t.Run("test empty roomIDs", func(t *testing.T) {
+		_, err := arcade.RoomsExistsRequest{}.Validate()
+
+		expected := "invalid argument: empty roomIDs"
+		if err == nil || expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test invalid roomID", func(t *testing.T) {
+		_, err := arcade.RoomsExistsRequest{RoomIDs: []string{"42"}}.Validate()
+
+		expected := "invalid argument: invalid roomID: '42'"
+		if err == nil || expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test success", func(t *testing.T) {
+		id := uuid.New()
+
+		ids, err := arcade.RoomsExistsRequest{RoomIDs: []string{id.String()}}.Validate()
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(ids) != 1 || ids[0] != id {
+			t.Errorf("Unexpected roomIDs: %v", ids)
+		}
+	})
+}
+
+func TestRoomRenameRequestValidate(t *testing.T) {
+	t.Run("test empty name", func(t *testing.T) {
+		err := arcade.RoomRenameRequest{}.Validate()
+
+		expected := "invalid argument: empty room name"
+		if err == nil || expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test long name", func(t *testing.T) {
+		err := arcade.RoomRenameRequest{Name: strings.Repeat("a", arcade.MaxRoomNameLen+1)}.Validate()
+
+		expected := "invalid argument: room name exceeds maximum length"
+		if err == nil || expected != err.Error() {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("test success", func(t *testing.T) {
+		if err := (arcade.RoomRenameRequest{Name: "Hall"}).Validate(); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+}
+
 func TestNewRoomsReponse(t *testing.T) {
 	var (
 		id          = uuid.NewString()
@@ -287,8 +437,8 @@ func TestNewRoomsReponse(t *testing.T) {
 			Description: description,
 			OwnerID:     ownerID,
 			ParentID:    parentID,
-			Created:     created,
-			Updated:     updated,
+			Created:     arcade.Timestamp{Time: created},
+			Updated:     arcade.Timestamp{Time: updated},
 		}
 	)
 	r := arcade.NewRoomsResponse([]arcade.Room{p})
@@ -298,8 +448,8 @@ func TestNewRoomsReponse(t *testing.T) {
 		r.Data[0].Description != description ||
 		r.Data[0].OwnerID != ownerID ||
 		r.Data[0].ParentID != parentID ||
-		!created.Equal(r.Data[0].Created) ||
-		!updated.Equal(r.Data[0].Updated) {
+		!created.Equal(r.Data[0].Created.Time) ||
+		!updated.Equal(r.Data[0].Updated.Time) {
 		t.Errorf("Unexpected response: %+v", r)
 	}
 }
@@ -486,3 +636,36 @@ func TestNewRoomsFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestNewRoomContentsFilter(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		filter, err := arcade.NewRoomContentsFilter(&http.Request{URL: &url.URL{}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Limit != arcade.DefaultItemsFilterLimit || filter.Offset != 0 {
+			t.Errorf("Unexpected page: %d %d", filter.Limit, filter.Offset)
+		}
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		_, err := arcade.NewRoomContentsFilter(&http.Request{URL: &url.URL{RawQuery: "offset=-1"}})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "invalid argument: invalid offset query parameter: '-1'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("page", func(t *testing.T) {
+		filter, err := arcade.NewRoomContentsFilter(&http.Request{URL: &url.URL{RawQuery: "limit=20&offset=40"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Limit != 20 || filter.Offset != 40 {
+			t.Errorf("Unexpected page: %d %d", filter.Limit, filter.Offset)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"fmt"
+
+	"arcadium.dev/core/errors"
+)
+
+type (
+	// Route is a chain of links between two rooms. Links[i] leads from
+	// Rooms[i] to Rooms[i+1].
+	Route struct {
+		Rooms []string `json:"rooms"`
+		Links []string `json:"links"`
+	}
+
+	// RouteResponse is used to json encode a route response.
+	RouteResponse struct {
+		Data Route `json:"data"`
+	}
+)
+
+// NewRoute returns the route with the fewest links from one room to another,
+// found by a breadth first search of the given links.
+func NewRoute(links []Link, fromID, toID string) (Route, error) {
+	exits := make(map[string][]Link)
+	for _, l := range links {
+		exits[l.LocationID] = append(exits[l.LocationID], l)
+	}
+
+	// via maps each reached room to the link used to reach it.
+	via := map[string]*Link{fromID: nil}
+	queue := []string{fromID}
+	for len(queue) > 0 && via[toID] == nil && fromID != toID {
+		roomID := queue[0]
+		queue = queue[1:]
+		for i, l := range exits[roomID] {
+			if _, ok := via[l.DestinationID]; ok {
+				continue
+			}
+			via[l.DestinationID] = &exits[roomID][i]
+			queue = append(queue, l.DestinationID)
+		}
+	}
+	if _, ok := via[toID]; !ok {
+		return Route{}, fmt.Errorf("%w: no route from room '%s' to room '%s'", errors.ErrNotFound, fromID, toID)
+	}
+
+	// Walk back from the destination, then reverse into travel order.
+	route := Route{Rooms: []string{toID}, Links: []string{}}
+	for l := via[toID]; l != nil; l = via[l.LocationID] {
+		route.Rooms = append(route.Rooms, l.LocationID)
+		route.Links = append(route.Links, l.ID)
+	}
+	reverse(route.Rooms)
+	reverse(route.Links)
+	return route, nil
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,73 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"reflect"
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestNewRoute(t *testing.T) {
+	links := []arcade.Link{
+		{ID: "ab", LocationID: "a", DestinationID: "b"},
+		{ID: "ba", LocationID: "b", DestinationID: "a"},
+		{ID: "bc", LocationID: "b", DestinationID: "c"},
+		{ID: "cd", LocationID: "c", DestinationID: "d"},
+		{ID: "ad", LocationID: "a", DestinationID: "d"},
+	}
+
+	t.Run("two hops", func(t *testing.T) {
+		route, err := arcade.NewRoute(links, "a", "c")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := arcade.Route{Rooms: []string{"a", "b", "c"}, Links: []string{"ab", "bc"}}
+		if !reflect.DeepEqual(route, expected) {
+			t.Errorf("\nExpected route: %+v\nActual route:   %+v", expected, route)
+		}
+	})
+
+	t.Run("shortest", func(t *testing.T) {
+		route, err := arcade.NewRoute(links, "a", "d")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := arcade.Route{Rooms: []string{"a", "d"}, Links: []string{"ad"}}
+		if !reflect.DeepEqual(route, expected) {
+			t.Errorf("\nExpected route: %+v\nActual route:   %+v", expected, route)
+		}
+	})
+
+	t.Run("same room", func(t *testing.T) {
+		route, err := arcade.NewRoute(links, "b", "b")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := arcade.Route{Rooms: []string{"b"}, Links: []string{}}
+		if !reflect.DeepEqual(route, expected) {
+			t.Errorf("\nExpected route: %+v\nActual route:   %+v", expected, route)
+		}
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		_, err := arcade.NewRoute(links, "d", "a")
+		expected := "not found: no route from room 'd' to room 'a'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %v", expected, err)
+		}
+	})
+}
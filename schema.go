@@ -0,0 +1,97 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"reflect"
+	"strings"
+)
+
+type (
+	// FieldSchema describes a field of a request, for a client rendering a
+	// form for it.
+	FieldSchema struct {
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Format    string `json:"format,omitempty"`
+		MaxLength int    `json:"maxLength,omitempty"`
+		Required  bool   `json:"required"`
+		Nullable  bool   `json:"nullable"`
+	}
+
+	// Schema describes the fields of a request.
+	Schema struct {
+		Fields []FieldSchema `json:"fields"`
+	}
+
+	// SchemaResponse is used to json encode a schema response.
+	SchemaResponse struct {
+		Data Schema `json:"data"`
+	}
+)
+
+// NewSchema returns the schema of the given request struct. Fields are named
+// by their json tag, and described by their schema tag, a comma separated
+// list of required, nullable and format=<format>. The maximum lengths of
+// fields are given keyed by field name.
+func NewSchema(req any, maxLengths map[string]int) Schema {
+	t := reflect.TypeOf(req)
+
+	schema := Schema{Fields: make([]FieldSchema, 0, t.NumField())}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := FieldSchema{
+			Name:      name,
+			Type:      schemaType(f.Type.Kind()),
+			MaxLength: maxLengths[name],
+		}
+		for _, opt := range strings.Split(f.Tag.Get("schema"), ",") {
+			switch {
+			case opt == "required":
+				field.Required = true
+			case opt == "nullable":
+				field.Nullable = true
+			case strings.HasPrefix(opt, "format="):
+				field.Format = strings.TrimPrefix(opt, "format=")
+			}
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	return schema
+}
+
+// schemaType returns the json type of a field of the given kind.
+func schemaType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
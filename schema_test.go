@@ -0,0 +1,86 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestNewSchema(t *testing.T) {
+	type request struct {
+		Name    string   `json:"name" schema:"required"`
+		Count   int      `json:"count,omitempty"`
+		Tags    []string `json:"tags" schema:"nullable"`
+		OwnerID string   `json:"ownerID" schema:"required,format=uuid"`
+		Ignored string   `json:"-"`
+	}
+
+	schema := arcade.NewSchema(request{}, map[string]int{"name": 42})
+
+	expected := []arcade.FieldSchema{
+		{Name: "name", Type: "string", MaxLength: 42, Required: true},
+		{Name: "count", Type: "integer"},
+		{Name: "tags", Type: "array", Nullable: true},
+		{Name: "ownerID", Type: "string", Format: "uuid", Required: true},
+	}
+	if len(schema.Fields) != len(expected) {
+		t.Fatalf("Unexpected fields: %+v", schema.Fields)
+	}
+	for i := range expected {
+		if schema.Fields[i] != expected[i] {
+			t.Errorf("\nExpected field: %+v\nActual field:   %+v", expected[i], schema.Fields[i])
+		}
+	}
+}
+
+func TestItemSchema(t *testing.T) {
+	fields := make(map[string]arcade.FieldSchema)
+	for _, f := range arcade.ItemSchema(arcade.NamePolicy{}).Fields {
+		fields[f.Name] = f
+	}
+
+	if f := fields["name"]; f.MaxLength != arcade.MaxItemNameLen || !f.Required {
+		t.Errorf("Unexpected name field: %+v", f)
+	}
+	if f := fields["description"]; f.MaxLength != arcade.MaxItemDescriptionLen || !f.Required {
+		t.Errorf("Unexpected description field: %+v", f)
+	}
+	for _, name := range []string{"ownerID", "locationID", "inventoryID"} {
+		if f := fields[name]; f.Format != "uuid" || !f.Required || f.Nullable {
+			t.Errorf("Unexpected %s field: %+v", name, f)
+		}
+	}
+
+	t.Run("optional description", func(t *testing.T) {
+		defer func(required bool) { arcade.RequireDescription = required }(arcade.RequireDescription)
+		arcade.RequireDescription = false
+
+		for _, f := range arcade.ItemSchema(arcade.NamePolicy{}).Fields {
+			if f.Name == "description" && f.Required {
+				t.Errorf("Unexpected description field: %+v", f)
+			}
+		}
+	})
+
+	t.Run("configured name length", func(t *testing.T) {
+		for _, f := range arcade.ItemSchema(arcade.NamePolicy{MaxLen: 32}).Fields {
+			if f.Name == "name" && f.MaxLength != 32 {
+				t.Errorf("Unexpected name field: %+v", f)
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"fmt"
+	"strings"
+
+	"arcadium.dev/core/errors"
+)
+
+var (
+	// sortColumns are the columns a list may be sorted by.
+	sortColumns = map[string]bool{"name": true, "created": true, "updated": true}
+)
+
+type (
+	// Sort is the order of the results of a List. The zero value is
+	// unspecified, leaving the order to the configured default, falling back
+	// to ascending by creation time.
+	Sort struct {
+		Column string
+		Desc   bool
+	}
+)
+
+// ParseSort parses a sort of the form "column" for ascending order, or
+// "-column" for descending order.
+func ParseSort(value string) (Sort, error) {
+	s := Sort{Column: value}
+	if strings.HasPrefix(value, "-") {
+		s = Sort{Column: value[1:], Desc: true}
+	}
+	if !sortColumns[s.Column] {
+		return Sort{}, fmt.Errorf("%w: invalid sort: '%s'", errors.ErrInvalidArgument, value)
+	}
+	return s, nil
+}
+
+// Decode allows a sort to be read from the environment by envconfig.
+func (s *Sort) Decode(value string) error {
+	sort, err := ParseSort(value)
+	if err != nil {
+		return err
+	}
+	*s = sort
+	return nil
+}
+
+// newSort parses the sort query parameter of a list request, returning the
+// zero sort if it is not given.
+func newSort(values []string) (Sort, error) {
+	if len(values) == 0 {
+		return Sort{}, nil
+	}
+	s, err := ParseSort(values[0])
+	if err != nil {
+		return Sort{}, fmt.Errorf("%w: invalid sort query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+	}
+	return s, nil
+}
@@ -0,0 +1,63 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"testing"
+
+	"arcadium.dev/arcade"
+)
+
+func TestParseSort(t *testing.T) {
+	valid := map[string]arcade.Sort{
+		"name":     {Column: "name"},
+		"-updated": {Column: "updated", Desc: true},
+		"created":  {Column: "created"},
+	}
+	for value, expected := range valid {
+		s, err := arcade.ParseSort(value)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %s", value, err)
+		}
+		if s != expected {
+			t.Errorf("Unexpected sort for %q: %+v", value, s)
+		}
+	}
+
+	for _, value := range []string{"", "-", "owner_id", "name; DROP TABLE items"} {
+		_, err := arcade.ParseSort(value)
+		if err == nil {
+			t.Errorf("Expected an error for %q", value)
+			continue
+		}
+		expected := "invalid argument: invalid sort: '" + value + "'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		var s arcade.Sort
+		if err := s.Decode("-name"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if s != (arcade.Sort{Column: "name", Desc: true}) {
+			t.Errorf("Unexpected sort: %+v", s)
+		}
+		if err := s.Decode("bogus"); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
@@ -0,0 +1,37 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"context"
+)
+
+type (
+	staleReadsKey struct{}
+)
+
+// WithStaleReads returns a context whose list and get reads tolerate slightly
+// stale data, which a driver may serve more cheaply, e.g. from the nearest
+// replica.
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+// StaleReadsFromContext returns true when the reads of the given context
+// tolerate stale data.
+func StaleReadsFromContext(ctx context.Context) bool {
+	stale, _ := ctx.Value(staleReadsKey{}).(bool)
+	return stale
+}
@@ -0,0 +1,90 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"arcadium.dev/core/errors"
+)
+
+// StatsEntities are the entities whose counts are recorded in the stats
+// history.
+var StatsEntities = []string{"player", "room", "link", "item"}
+
+type (
+	// StatsPoint is the count of an entity at the time it was recorded.
+	StatsPoint struct {
+		Count    int64     `json:"count"`
+		Recorded Timestamp `json:"recorded"`
+	}
+
+	// StatsHistoryFilter selects the recorded counts of an entity.
+	StatsHistoryFilter struct {
+		// Entity is one of the StatsEntities.
+		Entity string
+
+		// Since, when set, restricts the history to the counts recorded
+		// at or after it.
+		Since time.Time
+	}
+
+	// StatsHistoryResponse is used to json encode the stats history of an
+	// entity.
+	StatsHistoryResponse struct {
+		Entity string       `json:"entity"`
+		Data   []StatsPoint `json:"data"`
+	}
+
+	// StatsStorage represents the persistent storage of the entity counts
+	// over time.
+	StatsStorage interface {
+		// Record records the current count of each of the StatsEntities.
+		Record(ctx context.Context) error
+
+		// History returns the recorded counts of an entity, oldest first.
+		History(ctx context.Context, filter StatsHistoryFilter) ([]StatsPoint, error)
+	}
+)
+
+// NewStatsHistoryFilter creates a stats history filter from the given
+// request's URL query parameters.
+func NewStatsHistoryFilter(r *http.Request) (StatsHistoryFilter, error) {
+	q := r.URL.Query()
+	var filter StatsHistoryFilter
+
+	entity := q.Get("entity")
+	for _, e := range StatsEntities {
+		if entity == e {
+			filter.Entity = entity
+		}
+	}
+	if filter.Entity == "" {
+		return StatsHistoryFilter{}, fmt.Errorf("%w: invalid entity query parameter: '%s'", errors.ErrInvalidArgument, entity)
+	}
+
+	if values := q["since"]; len(values) > 0 {
+		since, err := time.Parse(time.RFC3339, values[0])
+		if err != nil {
+			return StatsHistoryFilter{}, fmt.Errorf("%w: invalid since query parameter: '%s'", errors.ErrInvalidArgument, values[0])
+		}
+		filter.Since = since
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,66 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade"
+)
+
+func TestNewStatsHistoryFilter(t *testing.T) {
+	for name, test := range map[string]struct {
+		query    string
+		expected string
+	}{
+		"missing entity": {"", "invalid argument: invalid entity query parameter: ''"},
+		"unknown entity": {"entity=dragon", "invalid argument: invalid entity query parameter: 'dragon'"},
+		"invalid since":  {"entity=item&since=yesterday", "invalid argument: invalid since query parameter: 'yesterday'"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := arcade.NewStatsHistoryFilter(&http.Request{URL: &url.URL{RawQuery: test.query}})
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if err.Error() != test.expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", test.expected, err)
+			}
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		q := "entity=room&since=2023-04-01T12:00:00Z"
+		filter, err := arcade.NewStatsHistoryFilter(&http.Request{URL: &url.URL{RawQuery: q}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Entity != "room" || !filter.Since.Equal(time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected filter: %+v", filter)
+		}
+	})
+
+	t.Run("all time", func(t *testing.T) {
+		filter, err := arcade.NewStatsHistoryFilter(&http.Request{URL: &url.URL{RawQuery: "entity=player"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if filter.Entity != "player" || !filter.Since.IsZero() {
+			t.Errorf("Unexpected filter: %+v", filter)
+		}
+	})
+}
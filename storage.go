@@ -17,12 +17,16 @@ package arcade // import "arcadium.dev/arcade"
 type (
 	// Storage represents the SQL driver specific functionality.
 	StorageDriver interface {
-		// PlayersListQuery returns the List query string given the filter.
+		// PlayersListQuery returns the List query string given the filter. The
+		// created by filter, when given, is its $1 bind parameter.
 		PlayersListQuery(PlayersFilter) string
 
 		// PlayersGetQuery returns the Get query string.
 		PlayersGetQuery() string
 
+		// PlayersGetManyQuery returns the GetMany query string.
+		PlayersGetManyQuery() string
+
 		// PlayersCreateQuery returns the Create query string.
 		PlayersCreateQuery() string
 
@@ -32,12 +36,37 @@ type (
 		// PlayersRemoveQuery returns the Remove query string.
 		PlayersRemoveQuery() string
 
-		// RoomListQuery returns the List query string given the filter.
+		// PlayersUpdateLastSeenQuery returns the UpdateLastSeen query string.
+		PlayersUpdateLastSeenQuery() string
+
+		// PlayersSetLocationQuery returns the query string to move a player
+		// into a room.
+		PlayersSetLocationQuery() string
+
+		// PlayersRoomOccupancyQuery returns the query string to read and lock
+		// the capacity of a room, and count the players other than the given
+		// player in it.
+		PlayersRoomOccupancyQuery() string
+
+		// PlayersExistsQuery returns the query string to check a player exists.
+		PlayersExistsQuery() string
+
+		// PlayersGetByNameQuery returns the GetByName query string.
+		PlayersGetByNameQuery() string
+
+		// RoomListQuery returns the List query string given the filter. The
+		// created by filter, when given, is its $1 bind parameter.
 		RoomsListQuery(RoomsFilter) string
 
 		// RoomsGetQuery returns the Get query string.
 		RoomsGetQuery() string
 
+		// RoomsGetManyQuery returns the GetMany query string.
+		RoomsGetManyQuery() string
+
+		// RoomsGetByNameQuery returns the GetByName query string.
+		RoomsGetByNameQuery() string
+
 		// RoomsCreateQuery returns the Create query string.
 		RoomsCreateQuery() string
 
@@ -47,12 +76,62 @@ type (
 		// RoomsRemoveQuery returns the Remove query string.
 		RoomsRemoveQuery() string
 
-		// LinksListQuery returns the List query string given the filter.
+		// RoomsAddTagQuery returns the AddTag query string.
+		RoomsAddTagQuery() string
+
+		// RoomsRemoveTagQuery returns the RemoveTag query string.
+		RoomsRemoveTagQuery() string
+
+		// RoomsExistsQuery returns the query string to check a room exists.
+		RoomsExistsQuery() string
+
+		// RoomsExistingQuery returns the query string to select which of
+		// the given rooms exist.
+		RoomsExistingQuery() string
+
+		// RoomsRenameQuery returns the Rename query string.
+		RoomsRenameQuery() string
+
+		// RoomsNameTakenQuery returns the query string to check a name is
+		// taken by a room other than the given room.
+		RoomsNameTakenQuery() string
+
+		// RoomsLockQuery returns the query string to lock a room, selecting
+		// its id.
+		RoomsLockQuery() string
+
+		// RoomsDependentsQuery returns the query string to count the items
+		// and links located in or leading to a room.
+		RoomsDependentsQuery() string
+
+		// RoomsReferencesQuery returns the query string to count the items
+		// located in a room, and the links located in or leading to it.
+		RoomsReferencesQuery() string
+
+		// RoomsCascadeItemsQuery returns the query string to select the ids
+		// of the items counted by RoomsReferencesQuery.
+		RoomsCascadeItemsQuery() string
+
+		// RoomsCascadeLinksQuery returns the query string to select the ids
+		// of the links counted by RoomsReferencesQuery.
+		RoomsCascadeLinksQuery() string
+
+		// LinksListQuery returns the List query string given the filter. The
+		// created by filter, when given, is its $1 bind parameter.
 		LinksListQuery(LinksFilter) string
 
 		// LinksGetQuery returns the Get query string.
 		LinksGetQuery() string
 
+		// LinksGetManyQuery returns the GetMany query string.
+		LinksGetManyQuery() string
+
+		// LinksGetWithRoomsQuery returns the GetWithRooms query string.
+		LinksGetWithRoomsQuery() string
+
+		// LinksGetReverseQuery returns the GetReverse query string.
+		LinksGetReverseQuery() string
+
 		// LinksCreateQuery returns the Create query string.
 		LinksCreateQuery() string
 
@@ -62,12 +141,44 @@ type (
 		// LinksRemoveQuery returns the Remove query string.
 		LinksRemoveQuery() string
 
-		// ItemsListQuery returns the List query string given the filter.
+		// LinksTraverseQuery returns the TryTraverse query string.
+		LinksTraverseQuery() string
+
+		// LinksReleaseQuery returns the Release query string.
+		LinksReleaseQuery() string
+
+		// LinksIncrementTraversalQuery returns the IncrementTraversal query
+		// string.
+		LinksIncrementTraversalQuery() string
+
+		// LinksWithinHopsQuery returns the WithinHops query string.
+		LinksWithinHopsQuery() string
+
+		// LinksExitsQuery returns the query string of the links located in
+		// any of the given rooms, used to find a route.
+		LinksExitsQuery() string
+
+		// LinksDanglingQuery returns the FindDangling query string.
+		LinksDanglingQuery() string
+
+		// LinksMoveAllQuery returns the query string to move all links in a
+		// room to another room.
+		LinksMoveAllQuery() string
+
+		// LinksRedirectAllQuery returns the query string to change the
+		// destination of all links to a room to another room.
+		LinksRedirectAllQuery() string
+
+		// ItemsListQuery returns the List query string given the filter. The
+		// created by filter, when given, is its $1 bind parameter.
 		ItemsListQuery(ItemsFilter) string
 
 		// ItemsGetQuery returns the Get query string.
 		ItemsGetQuery() string
 
+		// ItemsGetManyQuery returns the GetMany query string.
+		ItemsGetManyQuery() string
+
 		// ItemsCreateQuery returns the Create query string.
 		ItemsCreateQuery() string
 
@@ -77,6 +188,125 @@ type (
 		// ItemsRemoveQuery returns the Remove query string.
 		ItemsRemoveQuery() string
 
+		// ItemsRemoveManyQuery returns the RemoveMany query string.
+		ItemsRemoveManyQuery() string
+
+		// ItemsInventoryOccupancyQuery returns the query string to lock a
+		// player, selecting its inventory capacity, the number of other items
+		// in its inventory, and whether it holds the item.
+		ItemsInventoryOccupancyQuery() string
+
+		// ItemsExistsQuery returns the Exists query string.
+		ItemsExistsQuery() string
+
+		// ItemsSearchQuery returns the Search query string given the filter.
+		// The search text is the query's only argument. Results are ordered by
+		// relevance, using full-text search where the database supports it
+		// and a case insensitive match otherwise.
+		ItemsSearchQuery(ItemsSearchFilter) string
+
+		// ItemsLocationQuery returns the query string to read and lock the
+		// location of an item.
+		ItemsLocationQuery() string
+
+		// ItemsSetLocationQuery returns the query string to set the location
+		// of an item.
+		ItemsSetLocationQuery() string
+
+		// ItemsMoveAllQuery returns the query string to move all items in a
+		// room to another room.
+		ItemsMoveAllQuery() string
+
+		// ItemsChangedSinceQuery returns the query string to list the items
+		// after a watermark of update time and itemID, in order of update
+		// then itemID.
+		ItemsChangedSinceQuery() string
+
+		// ItemsTopOwnersQuery returns the query string to count the items of
+		// each owner, in descending order of count.
+		ItemsTopOwnersQuery() string
+
+		// ItemsTotalWeightQuery returns the query string to sum the weight
+		// of the items in the inventory of a player.
+		ItemsTotalWeightQuery() string
+
+		// ItemsGroupedByLocationQuery returns the query string to count the
+		// items of an owner in each location.
+		ItemsGroupedByLocationQuery() string
+
+		// ItemsOrphansQuery returns the FindOrphans query string.
+		ItemsOrphansQuery() string
+
+		// ItemsFixOrphanedOwnersQuery returns the query string to reset the
+		// dangling owner of items to the default owner.
+		ItemsFixOrphanedOwnersQuery() string
+
+		// ItemsFixOrphanedLocationsQuery returns the query string to reset
+		// the dangling location of items to the default location.
+		ItemsFixOrphanedLocationsQuery() string
+
+		// ItemsFixOrphanedInventoriesQuery returns the query string to null
+		// the dangling inventory of items.
+		ItemsFixOrphanedInventoriesQuery() string
+
+		// ItemsOwnerQuery returns the query string to read and lock the owner
+		// and inventory of an item.
+		ItemsOwnerQuery() string
+
+		// ItemsSetOwnerQuery returns the query string to set the owner of an
+		// item.
+		ItemsSetOwnerQuery() string
+
+		// ItemsRecordTransferQuery returns the query string to record the
+		// transfer of an item from one owner to another.
+		ItemsRecordTransferQuery() string
+
+		// AnalyzeQuery returns the query string to refresh the statistics of
+		// the given table, or an empty string when the driver does not
+		// support it.
+		AnalyzeQuery(table string) string
+
+		// WorldEntitiesQuery returns the query string to count the players,
+		// rooms, links and items together.
+		WorldEntitiesQuery() string
+
+		// StatsRecordQuery returns the query string to record the current
+		// count of each stats entity.
+		StatsRecordQuery() string
+
+		// StatsHistoryQuery returns the query string to select the recorded
+		// counts of an entity since a time, oldest first, capped to the
+		// maximum list rows.
+		StatsHistoryQuery() string
+
+		// ExportChecksumQuery returns the query string to select the id,
+		// entity and updated time of every entity, ordered by id.
+		ExportChecksumQuery() string
+
+		// ItemsImportSavepointQuery returns the query string to set a
+		// savepoint before importing a row.
+		ItemsImportSavepointQuery() string
+
+		// ItemsImportRollbackQuery returns the query string to rollback a
+		// failed row of an import to its savepoint.
+		ItemsImportRollbackQuery() string
+
+		// ItemsImportReleaseQuery returns the query string to release the
+		// savepoint of an imported row.
+		ItemsImportReleaseQuery() string
+
+		// SnapshotQuery returns the query string to read the current time of
+		// the storage, as of which a list may be read.
+		SnapshotQuery() string
+
+		// StaleReadQuery returns the given list or get query reading slightly
+		// stale data, or the query as is when the driver does not support
+		// stale reads.
+		StaleReadQuery(query string) string
+
+		// ListRowsCap returns the most rows a list query will return.
+		ListRowsCap() int
+
 		// IsForeignKeyViolation returns true if the given error is a foreign key violation error.
 		IsForeignKeyViolation(err error) bool
 
@@ -0,0 +1,63 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	cerrors "arcadium.dev/core/errors"
+)
+
+type (
+	// uuidArray encodes a slice of UUIDs as an array literal, suitable for
+	// use as the argument of an ANY() expression.
+	uuidArray []uuid.UUID
+)
+
+// Value implements the driver.Valuer interface.
+func (a uuidArray) Value() (driver.Value, error) {
+	ids := make([]string, 0, len(a))
+	for _, id := range a {
+		ids = append(ids, id.String())
+	}
+	return "{" + strings.Join(ids, ",") + "}", nil
+}
+
+// parseIDs returns the parsed ids of the given kind, e.g. "item", with an
+// invalid argument error naming the first invalid id.
+func parseIDs(kind string, ids []string) ([]uuid.UUID, error) {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		pid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s id: '%s'", cerrors.ErrInvalidArgument, kind, id)
+		}
+		parsed = append(parsed, pid)
+	}
+	return parsed, nil
+}
+
+// listArgs returns the bind parameters of a list query given the created by
+// filter, which is bound rather than written into the query.
+func listArgs(createdBy *string) []interface{} {
+	if createdBy == nil {
+		return nil
+	}
+	return []interface{}{*createdBy}
+}
@@ -0,0 +1,168 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"arcadium.dev/arcade"
+)
+
+// RoomsCacheMaxLists is the most lists a rooms cache holds at once.
+const RoomsCacheMaxLists = 1000
+
+type (
+	// RoomsCache is a rooms storage which caches the results of List, keyed
+	// by the filter, for a short time to live. Any write through the cache
+	// invalidates it. Writes made elsewhere, such as a removed player
+	// changing the owner of their rooms, are seen once the cached lists
+	// expire. Expired lists are evicted once the cache is full, and while
+	// it is still full no further lists are cached.
+	RoomsCache struct {
+		arcade.RoomsStorage
+
+		ttl time.Duration
+
+		mu    sync.Mutex
+		gen   uint64
+		lists map[string]cachedRooms
+	}
+
+	cachedRooms struct {
+		rooms   []arcade.Room
+		expires time.Time
+	}
+)
+
+// NewRoomsCache returns a cache of the given rooms storage, with lists cached
+// for the given time to live.
+func NewRoomsCache(rooms arcade.RoomsStorage, ttl time.Duration) *RoomsCache {
+	return &RoomsCache{
+		RoomsStorage: rooms,
+		ttl:          ttl,
+		lists:        make(map[string]cachedRooms),
+	}
+}
+
+// List returns a slice of rooms based on the value of the filter, from the
+// cache when possible.
+func (c *RoomsCache) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Room, error) {
+	// A stale read is neither served from nor stored in the cache, so it
+	// never hides a fresher list from the reads which follow it.
+	if arcade.CacheBypassed(ctx) || arcade.StaleReadsFromContext(ctx) {
+		return c.RoomsStorage.List(ctx, filter)
+	}
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return c.RoomsStorage.List(ctx, filter)
+	}
+	key := string(b)
+
+	c.mu.Lock()
+	cached, ok := c.lists[key]
+	gen := c.gen
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return append([]arcade.Room(nil), cached.rooms...), nil
+	}
+
+	rooms, err := c.RoomsStorage.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only cache the list if no write invalidated the cache while reading.
+	c.mu.Lock()
+	if c.gen == gen && c.fits(key) {
+		c.lists[key] = cachedRooms{
+			rooms:   append([]arcade.Room(nil), rooms...),
+			expires: time.Now().Add(c.ttl),
+		}
+	}
+	c.mu.Unlock()
+
+	return rooms, nil
+}
+
+// Create a room given the room request, returning the creating room.
+func (c *RoomsCache) Create(ctx context.Context, req arcade.RoomRequest) (arcade.Room, error) {
+	defer c.invalidate()
+	return c.RoomsStorage.Create(ctx, req)
+}
+
+// Update a room given the room request, returning the updated room.
+func (c *RoomsCache) Update(ctx context.Context, roomID string, req arcade.RoomRequest) (arcade.Room, error) {
+	defer c.invalidate()
+	return c.RoomsStorage.Update(ctx, roomID, req)
+}
+
+// Remove deletes the given room from persistent storage.
+func (c *RoomsCache) Remove(ctx context.Context, roomID string, cascade bool) error {
+	defer c.invalidate()
+	return c.RoomsStorage.Remove(ctx, roomID, cascade)
+}
+
+// AddTag adds the tag to the given rooms, returning the number of rooms
+// changed.
+func (c *RoomsCache) AddTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	defer c.invalidate()
+	return c.RoomsStorage.AddTag(ctx, roomIDs, tag)
+}
+
+// RemoveTag removes the tag from the given rooms, returning the number of
+// rooms changed.
+func (c *RoomsCache) RemoveTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	defer c.invalidate()
+	return c.RoomsStorage.RemoveTag(ctx, roomIDs, tag)
+}
+
+// Merge moves the items and links in a room into another room, optionally
+// removing the merged room.
+func (c *RoomsCache) Merge(ctx context.Context, roomID, intoID string, remove bool) error {
+	defer c.invalidate()
+	return c.RoomsStorage.Merge(ctx, roomID, intoID, remove)
+}
+
+// Rename changes the name of the given room, returning the renamed room.
+func (c *RoomsCache) Rename(ctx context.Context, roomID string, req arcade.RoomRenameRequest) (arcade.Room, error) {
+	defer c.invalidate()
+	return c.RoomsStorage.Rename(ctx, roomID, req)
+}
+
+// fits reports whether the list with the given key can be cached, evicting
+// the expired lists when the cache is full. It must be called with the
+// mutex held.
+func (c *RoomsCache) fits(key string) bool {
+	if _, ok := c.lists[key]; ok || len(c.lists) < RoomsCacheMaxLists {
+		return true
+	}
+	now := time.Now()
+	for k, cached := range c.lists {
+		if !now.Before(cached.expires) {
+			delete(c.lists, k)
+		}
+	}
+	return len(c.lists) < RoomsCacheMaxLists
+}
+
+func (c *RoomsCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.lists = make(map[string]cachedRooms)
+}
@@ -0,0 +1,178 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade"
+	"arcadium.dev/arcade/storage"
+)
+
+type countingRooms struct {
+	arcade.RoomsStorage
+
+	mu    sync.Mutex
+	lists int
+}
+
+func (c *countingRooms) List(context.Context, arcade.RoomsFilter) ([]arcade.Room, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lists++
+	return []arcade.Room{{ID: "c39761fc-5096-4b1c-9d02-c75730b7b8bf"}}, nil
+}
+
+func (c *countingRooms) Create(context.Context, arcade.RoomRequest) (arcade.Room, error) {
+	return arcade.Room{}, nil
+}
+
+func TestRoomsCache(t *testing.T) {
+	ctx := context.Background()
+	limit := func(n int) arcade.RoomsFilter { return arcade.RoomsFilter{Limit: n} }
+
+	t.Run("hit within ttl", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			if _, err := c.List(ctx, limit(10)); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		if rooms.lists != 1 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+
+		// A different filter is a different key.
+		_, _ = c.List(ctx, limit(20))
+		if rooms.lists != 2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Millisecond)
+
+		_, _ = c.List(ctx, limit(10))
+		time.Sleep(5 * time.Millisecond)
+		_, _ = c.List(ctx, limit(10))
+
+		if rooms.lists != 2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("invalidated after create", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		_, _ = c.List(ctx, limit(10))
+		if _, err := c.Create(ctx, arcade.RoomRequest{}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, _ = c.List(ctx, limit(10))
+
+		if rooms.lists != 2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("bypassed", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		_, _ = c.List(ctx, limit(10))
+		_, _ = c.List(arcade.WithoutCache(ctx), limit(10))
+
+		if rooms.lists != 2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("stale reads", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		// A stale read neither hits nor fills the cache.
+		_, _ = c.List(ctx, limit(10))
+		_, _ = c.List(arcade.WithStaleReads(ctx), limit(10))
+		_, _ = c.List(arcade.WithStaleReads(ctx), limit(20))
+		_, _ = c.List(ctx, limit(20))
+
+		if rooms.lists != 4 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("full", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		for i := 0; i < storage.RoomsCacheMaxLists; i++ {
+			_, _ = c.List(ctx, limit(i))
+		}
+
+		// A full cache keeps the lists it has, but caches no more.
+		_, _ = c.List(ctx, limit(0))
+		_, _ = c.List(ctx, limit(-1))
+		_, _ = c.List(ctx, limit(-1))
+
+		if rooms.lists != storage.RoomsCacheMaxLists+2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("full of expired", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, 5*time.Millisecond)
+
+		for i := 0; i < storage.RoomsCacheMaxLists; i++ {
+			_, _ = c.List(ctx, limit(i))
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		// The expired lists are evicted to make room for a new one.
+		_, _ = c.List(ctx, limit(-1))
+		_, _ = c.List(ctx, limit(-1))
+
+		if rooms.lists != storage.RoomsCacheMaxLists+1 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		rooms := &countingRooms{}
+		c := storage.NewRoomsCache(rooms, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(n int) {
+				defer wg.Done()
+				_, _ = c.List(ctx, limit(n%3))
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, _ = c.Create(ctx, arcade.RoomRequest{})
+			}()
+		}
+		wg.Wait()
+	})
+}
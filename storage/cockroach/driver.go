@@ -17,7 +17,10 @@ package cockroach // import "arcadium.dev/cockroach"
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 
@@ -27,57 +30,273 @@ import (
 const (
 	// Player Queries
 
-	PlayersListQuery   = `SELECT player_id, name, description, home_id, location_id, created, updated FROM players`
-	PlayersGetQuery    = `SELECT player_id, name, description, home_id, location_id, created, updated FROM players WHERE player_id = $1`
-	PlayersCreateQuery = `INSERT INTO players (name, description, home_id, location_id) ` +
-		`VALUES ($1, $2, $3, $4) ` +
-		`RETURNING player_id, name, description, home_id, location_id, created, updated`
-	PlayersUpdateQuery = `UPDATE players SET name = $2, description = $3, home_id = $4, location_id = $5, updated = now() ` +
+	PlayersListQuery   = `SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players`
+	PlayersGetQuery    = `SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players WHERE player_id = $1`
+	PlayersCreateQuery = `INSERT INTO players (name, description, home_id, location_id, inventory_capacity, created_by) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6) ` +
+		`RETURNING player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated`
+	PlayersUpdateQuery = `UPDATE players SET name = $2, description = $3, home_id = $4, location_id = $5, inventory_capacity = $6, updated = now() ` +
 		`WHERE player_id = $1 ` +
-		`RETURNING player_id, name, description, home_id, location_id, created, updated`
+		`RETURNING player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated`
 	PlayersRemoveQuery = `DELETE FROM players WHERE player_id = $1`
 
+	PlayersGetManyQuery = PlayersListQuery + ` WHERE player_id = ANY($1)`
+
+	PlayersUpdateLastSeenQuery = `UPDATE players SET last_seen = now() WHERE player_id = $1`
+
+	PlayersSetLocationQuery = `UPDATE players SET location_id = $2, updated = now() ` +
+		`WHERE player_id = $1 ` +
+		`RETURNING player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated`
+
+	PlayersRoomOccupancyQuery = `SELECT capacity, (SELECT count(*) FROM players WHERE location_id = $1 AND player_id != $2) ` +
+		`FROM rooms WHERE room_id = $1 FOR UPDATE`
+
+	PlayersExistsQuery = `SELECT EXISTS(SELECT 1 FROM players WHERE player_id = $1)`
+
+	PlayersGetByNameQuery = `SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players WHERE name = $1`
+
 	// Room Queries
 
-	RoomsListQuery   = `SELECT room_id, name, description, owner_id, parent_id, created, updated FROM rooms`
-	RoomsGetQuery    = `SELECT room_id, name, description, owner_id, parent_id, created, updated FROM rooms WHERE room_id = $1`
-	RoomsCreateQuery = `INSERT INTO rooms (name, description, owner_id, parent_id) ` +
-		`VALUES ($1, $2, $3, $4) ` +
-		`RETURNING room_id, name, description, owner_id, parent_id, created, updated`
-	RoomsUpdateQuery = `UPDATE rooms SET name = $2, description = $3, owner_id = $4, parent_id = $5, updated = now() ` +
+	RoomsListQuery      = `SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms`
+	RoomsGetQuery       = `SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms WHERE room_id = $1`
+	RoomsGetByNameQuery = `SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms WHERE name = $1 LIMIT 2`
+	RoomsCreateQuery    = `INSERT INTO rooms (name, description, owner_id, parent_id, capacity, created_by) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6) ` +
+		`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated`
+	RoomsUpdateQuery = `UPDATE rooms SET name = $2, description = $3, owner_id = $4, parent_id = $5, capacity = $6, updated = now() ` +
 		`WHERE room_id = $1 ` +
-		`RETURNING room_id, name, description, owner_id, parent_id, created, updated`
+		`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated`
 	RoomsRemoveQuery = `DELETE FROM rooms WHERE room_id = $1`
+	RoomsRenameQuery = `UPDATE rooms SET name = $2, updated = now() WHERE room_id = $1 ` +
+		`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated`
+	RoomsAddTagQuery = `UPDATE rooms SET tags = array_append(tags, $1), updated = now() ` +
+		`WHERE room_id = ANY($2) AND NOT ($1 = ANY(tags))`
+	RoomsRemoveTagQuery = `UPDATE rooms SET tags = array_remove(tags, $1), updated = now() ` +
+		`WHERE room_id = ANY($2) AND $1 = ANY(tags)`
+	RoomsExistsQuery     = `SELECT EXISTS(SELECT 1 FROM rooms WHERE room_id = $1)`
+	RoomsNameTakenQuery  = `SELECT EXISTS(SELECT 1 FROM rooms WHERE name = $1 AND room_id != $2)`
+	RoomsExistingQuery   = `SELECT room_id FROM rooms WHERE room_id = ANY($1)`
+	RoomsLockQuery       = `SELECT room_id FROM rooms WHERE room_id = $1 FOR UPDATE`
+	RoomsDependentsQuery = `SELECT (SELECT count(*) ` + roomItems + `) + ` +
+		`(SELECT count(*) ` + roomLinks + `)`
+	RoomsReferencesQuery = `SELECT (SELECT count(*) ` + roomItems + `), ` +
+		`(SELECT count(*) ` + roomLinks + `)`
+
+	RoomsGetManyQuery = RoomsListQuery + ` WHERE room_id = ANY($1)`
+
+	// The cascade of a room removal is the ON DELETE SET DEFAULT of these
+	// foreign keys, which a preview selects with the same predicates.
+	RoomsCascadeItemsQuery = `SELECT item_id ` + roomItems + ` ORDER BY item_id`
+	RoomsCascadeLinksQuery = `SELECT link_id ` + roomLinks + ` ORDER BY link_id`
+
+	roomItems = `FROM items WHERE location_id = $1`
+	roomLinks = `FROM links WHERE location_id = $1 OR destination_id = $1`
+
+	// The predicates of an item's dangling owner, location and inventory,
+	// shared by the orphans query and the queries fixing them.
+	itemOrphanedOwner     = `NOT EXISTS (SELECT 1 FROM players WHERE players.player_id = items.owner_id)`
+	itemOrphanedLocation  = `(items.location_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM rooms WHERE rooms.room_id = items.location_id))`
+	itemOrphanedInventory = `(items.inventory_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM players WHERE players.player_id = items.inventory_id))`
 
 	// Link Queries
 
-	LinksListQuery   = `SELECT link_id, name, description, owner_id, location_id, destination_id, created, updated FROM links`
-	LinksGetQuery    = `SELECT link_id, name, description, owner_id, location_id, destination_id, created, updated FROM links WHERE link_id = $1`
-	LinksCreateQuery = `INSERT INTO links (name, description, owner_id, location_id, destination_id) ` +
-		`VALUES ($1, $2, $3, $4, $5) ` +
-		`RETURNING link_id, name, description, owner_id, location_id, destination_id, created, updated`
-	LinksUpdateQuery = `UPDATE links SET name = $2, description = $3, owner_id = $4, location_id = $5, destination_id = $6,  updated = now() ` +
+	LinksListQuery   = `SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links`
+	LinksGetQuery    = `SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links WHERE link_id = $1`
+	LinksCreateQuery = `INSERT INTO links (name, description, owner_id, location_id, destination_id, capacity, created_by) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7) ` +
+		`RETURNING link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated`
+	LinksUpdateQuery = `UPDATE links SET name = $2, description = $3, owner_id = $4, location_id = $5, destination_id = $6, capacity = $7, updated = now() ` +
 		`WHERE link_id = $1 ` +
-		`RETURNING link_id, name, description, owner_id, location_id, destination_id, created, updated`
+		`RETURNING link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated`
 	LinksRemoveQuery = `DELETE FROM links WHERE link_id = $1`
 
+	LinksGetManyQuery = LinksListQuery + ` WHERE link_id = ANY($1)`
+
+	LinksGetWithRoomsQuery = linksWithRoomsSelect + ` WHERE links.link_id = $1`
+	linksWithRoomsSelect   = `SELECT links.link_id, links.name, links.description, links.owner_id, links.location_id, links.destination_id, ` +
+		`links.capacity, links.created_by, links.created, links.updated, ` +
+		`COALESCE(location.name, ''), COALESCE(destination.name, '') FROM links ` +
+		`LEFT JOIN rooms AS location ON location.room_id = links.location_id ` +
+		`LEFT JOIN rooms AS destination ON destination.room_id = links.destination_id`
+
+	LinksGetReverseQuery = linksReverseSelect + ` WHERE links.link_id = $1 ORDER BY reverse.created ASC, reverse.link_id ASC LIMIT 1`
+	linksReverseSelect   = `SELECT reverse.link_id, reverse.name, reverse.description, reverse.owner_id, reverse.location_id, reverse.destination_id, ` +
+		`reverse.capacity, reverse.created_by, reverse.created, reverse.updated FROM links ` +
+		`JOIN links AS reverse ON reverse.location_id = links.destination_id AND reverse.destination_id = links.location_id ` +
+		`AND reverse.link_id != links.link_id`
+
+	LinksTraverseQuery           = `UPDATE links SET occupancy = occupancy + 1 WHERE link_id = $1 AND (capacity = 0 OR occupancy < capacity)`
+	LinksReleaseQuery            = `UPDATE links SET occupancy = occupancy - 1 WHERE link_id = $1 AND occupancy > 0`
+	LinksIncrementTraversalQuery = `UPDATE links SET traversal_count = traversal_count + 1 WHERE link_id = $1`
+	LinksWithinHopsQuery         = `WITH RECURSIVE reachable (room_id, hops, path) AS (` +
+		`SELECT $1::UUID, 0, ARRAY[$1::UUID] UNION ALL ` +
+		`SELECT links.destination_id, reachable.hops + 1, reachable.path || links.destination_id ` +
+		`FROM links JOIN reachable ON links.location_id = reachable.room_id ` +
+		`WHERE reachable.hops < $2 AND links.destination_id <> ALL(reachable.path)) ` +
+		`SELECT room_id, min(hops) FROM reachable WHERE room_id <> $1 GROUP BY room_id ORDER BY min(hops), room_id`
+	LinksExitsQuery    = LinksListQuery + ` WHERE location_id = ANY($1)`
+	LinksDanglingQuery = LinksListQuery + ` ` +
+		`WHERE NOT EXISTS (SELECT 1 FROM rooms WHERE rooms.room_id = links.location_id) ` +
+		`OR NOT EXISTS (SELECT 1 FROM rooms WHERE rooms.room_id = links.destination_id) ` +
+		`ORDER BY created ASC`
+	LinksMoveAllQuery     = `UPDATE links SET location_id = $2, updated = now() WHERE location_id = $1`
+	LinksRedirectAllQuery = `UPDATE links SET destination_id = $2, updated = now() WHERE destination_id = $1`
+
 	// Item Queries
 
-	ItemsListQuery   = `SELECT item_id, name, description, owner_id, location_id, inventory_id, created, updated FROM items`
-	ItemsGetQuery    = `SELECT item_id, name, description, owner_id, location_id, inventory_id, created, updated FROM items WHERE item_id = $1`
-	ItemsCreateQuery = `INSERT INTO items (name, description, owner_id, location_id, inventory_id) ` +
-		`VALUES ($1, $2, $3, $4, $5) ` +
-		`RETURNING item_id, name, description, owner_id, location_id, inventory_id, created, updated`
-	ItemsUpdateQuery = `UPDATE items SET name = $2, description = $3, owner_id = $4, location_id = $5, inventory_id = $6,  updated = now() ` +
+	ItemsListQuery   = `SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items`
+	ItemsGetQuery    = `SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items WHERE item_id = $1`
+	ItemsCreateQuery = `INSERT INTO items (name, description, owner_id, location_id, inventory_id, weight, created_by) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7) ` +
+		`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated`
+	ItemsUpdateQuery = `UPDATE items SET name = $2, description = $3, owner_id = $4, location_id = $5, inventory_id = $6, weight = $7, updated = now() ` +
 		`WHERE item_id = $1 ` +
-		`RETURNING item_id, name, description, owner_id, location_id, inventory_id, created, updated`
+		`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated`
 	ItemsRemoveQuery = `DELETE FROM items WHERE item_id = $1`
+
+	ItemsGetManyQuery = ItemsListQuery + ` WHERE item_id = ANY($1)`
+
+	ItemsRemoveManyQuery = `DELETE FROM items WHERE item_id = ANY($1)`
+
+	ItemsInventoryOccupancyQuery = `SELECT inventory_capacity, ` +
+		`(SELECT count(*) FROM items WHERE inventory_id = $1 AND item_id != $2), ` +
+		`EXISTS(SELECT 1 FROM items WHERE item_id = $2 AND inventory_id = $1) ` +
+		`FROM players WHERE player_id = $1 FOR UPDATE`
+
+	ItemsExistsQuery = `SELECT EXISTS(SELECT 1 FROM items WHERE item_id = $1)`
+	ItemsSearchQuery = `SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ` +
+		`WHERE to_tsvector('english', name || ' ' || description) @@ plainto_tsquery('english', $1) ` +
+		`ORDER BY ts_rank(to_tsvector('english', name || ' ' || description), plainto_tsquery('english', $1)) DESC, item_id`
+	ItemsILikeSearchQuery = `SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ` +
+		`WHERE name ILIKE ` + itemsILikePattern + ` OR description ILIKE ` + itemsILikePattern + ` ` +
+		`ORDER BY name ILIKE ` + itemsILikePattern + ` DESC, item_id`
+
+	// itemsILikePattern matches the search query $1 anywhere, escaping its
+	// LIKE metacharacters so they match themselves.
+	itemsILikePattern = `'%' || replace(replace(replace($1, '\', '\\'), '%', '\%'), '_', '\_') || '%'`
+
+	ItemsLocationQuery     = `SELECT location_id, inventory_id FROM items WHERE item_id = $1 FOR UPDATE`
+	ItemsSetLocationQuery  = `UPDATE items SET location_id = $2, inventory_id = $3, updated = now() WHERE item_id = $1`
+	ItemsMoveAllQuery      = `UPDATE items SET location_id = $2, updated = now() WHERE location_id = $1`
+	ItemsChangedSinceQuery = `SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ` +
+		`WHERE (updated, item_id) > ($1, $2) ORDER BY updated ASC, item_id ASC LIMIT $3`
+	ItemsTopOwnersQuery = `SELECT owner_id, count(*) FROM items WHERE owner_id IS NOT NULL ` +
+		`GROUP BY owner_id ORDER BY count(*) DESC, owner_id LIMIT $1`
+	ItemsGroupedByLocationQuery = `SELECT CASE WHEN inventory_id IS NULL THEN 'room' ELSE 'player' END, ` +
+		`COALESCE(inventory_id, location_id), count(*) FROM items ` +
+		`WHERE owner_id = $1 AND (inventory_id IS NOT NULL OR location_id IS NOT NULL) GROUP BY 1, 2`
+	ItemsTotalWeightQuery = `SELECT (SELECT sum(weight)::INT8 FROM items WHERE inventory_id = $1) FROM players WHERE player_id = $1`
+	ItemsOrphansQuery     = ItemsListQuery + ` ` +
+		`WHERE ` + itemOrphanedOwner + ` OR ` + itemOrphanedLocation + ` OR ` + itemOrphanedInventory + ` ` +
+		`ORDER BY created ASC`
+	ItemsFixOrphanedOwnersQuery      = `UPDATE items SET owner_id = DEFAULT, updated = now() WHERE ` + itemOrphanedOwner
+	ItemsFixOrphanedLocationsQuery   = `UPDATE items SET location_id = DEFAULT, updated = now() WHERE ` + itemOrphanedLocation
+	ItemsFixOrphanedInventoriesQuery = `UPDATE items SET inventory_id = NULL, updated = now() WHERE ` + itemOrphanedInventory
+	ItemsImportSavepointQuery        = `SAVEPOINT item_import`
+	ItemsImportRollbackQuery         = `ROLLBACK TO SAVEPOINT item_import`
+	ItemsImportReleaseQuery          = `RELEASE SAVEPOINT item_import`
+
+	ItemsOwnerQuery    = `SELECT owner_id, inventory_id FROM items WHERE item_id = $1 FOR UPDATE`
+	ItemsSetOwnerQuery = `UPDATE items SET owner_id = $2, updated = now() WHERE item_id = $1 ` +
+		`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated`
+	ItemsRecordTransferQuery = `INSERT INTO item_transfers (item_id, from_id, to_id) VALUES ($1, $2, $3)`
+
+	AnalyzeQuery = `ANALYZE %s`
+
+	WorldEntitiesQuery = `SELECT (SELECT count(*) FROM players) + (SELECT count(*) FROM rooms) + ` +
+		`(SELECT count(*) FROM links) + (SELECT count(*) FROM items)`
+
+	StatsRecordQuery = `INSERT INTO entity_stats (entity, count) ` +
+		`SELECT 'player', count(*) FROM players UNION ALL ` +
+		`SELECT 'room', count(*) FROM rooms UNION ALL ` +
+		`SELECT 'link', count(*) FROM links UNION ALL ` +
+		`SELECT 'item', count(*) FROM items`
+	StatsHistoryQuery = `SELECT count, recorded FROM entity_stats WHERE entity = $1 AND recorded >= $2 ORDER BY recorded ASC`
+
+	ExportChecksumQuery = `SELECT player_id, 'player', updated FROM players UNION ALL ` +
+		`SELECT room_id, 'room', updated FROM rooms UNION ALL ` +
+		`SELECT link_id, 'link', updated FROM links UNION ALL ` +
+		`SELECT item_id, 'item', updated FROM items ` +
+		`ORDER BY 1, 2`
+
+	SnapshotQuery = `SELECT now()`
+
+	FollowerReadClause = ` AS OF SYSTEM TIME follower_read_timestamp()`
 )
 
+// DefaultMaxListRows is the most rows a list query will return, when the
+// driver does not give a maximum.
+const DefaultMaxListRows = 10000
+
 type (
-	Driver struct{}
+	Driver struct {
+		// NoFullTextSearch, when set, searches using ILIKE for versions of
+		// cockroach without full-text search support (prior to v23.1).
+		NoFullTextSearch bool
+
+		// MaxListRows is the most rows a list query will return, guarding
+		// against materializing a huge table. A limit is appended to any
+		// list query without a smaller one. Zero uses DefaultMaxListRows.
+		MaxListRows int
+
+		// EmptyListFilterMatchesNothing, when set, has an empty list filter,
+		// e.g. the OwnerIDs of items, match no rows. Otherwise an empty list
+		// filter is ignored, as if it were not given.
+		EmptyListFilterMatchesNothing bool
+	}
 )
 
+// orderBy returns the ORDER BY clause of a list query, falling back to
+// ascending by creation time when no sort is given.
+func orderBy(sort arcade.Sort) string {
+	if sort.Column == "" {
+		return " ORDER BY created ASC"
+	}
+	if sort.Desc {
+		return fmt.Sprintf(" ORDER BY %s DESC", sort.Column)
+	}
+	return fmt.Sprintf(" ORDER BY %s ASC", sort.Column)
+}
+
+// anyOf returns the condition that the column is any of the given ids. An
+// empty list gives no condition, unless it is configured to match nothing.
+func (d Driver) anyOf(column string, ids []uuid.UUID) (string, bool) {
+	if len(ids) == 0 && !d.EmptyListFilterMatchesNothing {
+		return "", false
+	}
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String())
+	}
+	return fmt.Sprintf("%s = ANY('{%s}')", column, strings.Join(s, ",")), true
+}
+
+// limit returns the given limit, capped by the maximum rows of a list query.
+func (d Driver) limit(limit int) int {
+	max := d.ListRowsCap()
+	if limit <= 0 || limit > max {
+		return max
+	}
+	return limit
+}
+
+// createdWithin returns the condition selecting the rows created within the
+// given duration before now.
+func createdWithin(d time.Duration) string {
+	return fmt.Sprintf("created > now() - INTERVAL '%d microseconds'", d.Microseconds())
+}
+
+// asOf returns the AS OF SYSTEM TIME clause reading a table as it was at the
+// given time.
+func asOf(t time.Time) string {
+	return " AS OF SYSTEM TIME " + quote(t.UTC().Format("2006-01-02 15:04:05.999999-07:00"))
+}
+
+// quote returns the given string as a sql string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 func limitAndOffset(limit, offset int) string {
 	fq := ""
 	if limit > 0 {
@@ -90,12 +309,26 @@ func limitAndOffset(limit, offset int) string {
 }
 
 // PlayersListQuery returns the List query string given the filter.
-func (Driver) PlayersListQuery(filter arcade.PlayersFilter) string {
-	fq := ""
+func (d Driver) PlayersListQuery(filter arcade.PlayersFilter) string {
+	var conds []string
 	if filter.LocationID != nil {
-		fq += fmt.Sprintf(" WHERE location_id = '%s'", filter.LocationID)
+		conds = append(conds, fmt.Sprintf("location_id = '%s'", filter.LocationID))
 	}
-	fq += limitAndOffset(filter.Limit, filter.Offset)
+	if filter.LastSeenBefore != nil {
+		conds = append(conds, fmt.Sprintf("last_seen < '%s'", filter.LastSeenBefore.UTC().Format(time.RFC3339Nano)))
+	}
+	if filter.CreatedBy != nil {
+		conds = append(conds, "created_by = $1")
+	}
+	if filter.CreatedWithin != nil {
+		conds = append(conds, createdWithin(*filter.CreatedWithin))
+	}
+	fq := ""
+	if len(conds) > 0 {
+		fq += " WHERE " + strings.Join(conds, " AND ")
+	}
+	fq += orderBy(filter.Sort)
+	fq += limitAndOffset(d.limit(filter.Limit), filter.Offset)
 	return PlayersListQuery + fq
 }
 
@@ -104,6 +337,11 @@ func (Driver) PlayersGetQuery() string {
 	return PlayersGetQuery
 }
 
+// PlayersGetManyQuery returns the GetMany query string.
+func (Driver) PlayersGetManyQuery() string {
+	return PlayersGetManyQuery
+}
+
 // PlayersCreateQuery returns the Create query string.
 func (Driver) PlayersCreateQuery() string {
 	return PlayersCreateQuery
@@ -119,9 +357,47 @@ func (Driver) PlayersRemoveQuery() string {
 	return PlayersRemoveQuery
 }
 
+// PlayersUpdateLastSeenQuery returns the UpdateLastSeen query string.
+func (Driver) PlayersUpdateLastSeenQuery() string {
+	return PlayersUpdateLastSeenQuery
+}
+
+// PlayersSetLocationQuery returns the query string to move a player into a
+// room.
+func (Driver) PlayersSetLocationQuery() string {
+	return PlayersSetLocationQuery
+}
+
+// PlayersRoomOccupancyQuery returns the query string to read the capacity
+// of a room and the number of other players in it.
+func (Driver) PlayersRoomOccupancyQuery() string {
+	return PlayersRoomOccupancyQuery
+}
+
+// PlayersExistsQuery returns the query string to check a player exists.
+func (Driver) PlayersExistsQuery() string {
+	return PlayersExistsQuery
+}
+
+// PlayersGetByNameQuery returns the GetByName query string.
+func (Driver) PlayersGetByNameQuery() string {
+	return PlayersGetByNameQuery
+}
+
 // RoomListQuery returns the List query string given the filter.
-func (Driver) RoomsListQuery(arcade.RoomsFilter) string {
-	return RoomsListQuery
+func (d Driver) RoomsListQuery(filter arcade.RoomsFilter) string {
+	var conds []string
+	if filter.CreatedBy != nil {
+		conds = append(conds, "created_by = $1")
+	}
+	if filter.CreatedWithin != nil {
+		conds = append(conds, createdWithin(*filter.CreatedWithin))
+	}
+	fq := ""
+	if len(conds) > 0 {
+		fq += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return RoomsListQuery + fq + orderBy(filter.Sort) + limitAndOffset(d.limit(filter.Limit), filter.Offset)
 }
 
 // RoomsGetQuery returns the Get query string.
@@ -129,6 +405,16 @@ func (Driver) RoomsGetQuery() string {
 	return RoomsGetQuery
 }
 
+// RoomsGetManyQuery returns the GetMany query string.
+func (Driver) RoomsGetManyQuery() string {
+	return RoomsGetManyQuery
+}
+
+// RoomsGetByNameQuery returns the GetByName query string.
+func (Driver) RoomsGetByNameQuery() string {
+	return RoomsGetByNameQuery
+}
+
 // RoomsCreateQuery returns the Create query string.
 func (Driver) RoomsCreateQuery() string {
 	return RoomsCreateQuery
@@ -144,9 +430,93 @@ func (Driver) RoomsRemoveQuery() string {
 	return RoomsRemoveQuery
 }
 
+// RoomsRenameQuery returns the Rename query string.
+func (Driver) RoomsRenameQuery() string {
+	return RoomsRenameQuery
+}
+
+// RoomsAddTagQuery returns the AddTag query string.
+func (Driver) RoomsAddTagQuery() string {
+	return RoomsAddTagQuery
+}
+
+// RoomsRemoveTagQuery returns the RemoveTag query string.
+func (Driver) RoomsRemoveTagQuery() string {
+	return RoomsRemoveTagQuery
+}
+
+// RoomsExistsQuery returns the query string to check a room exists.
+func (Driver) RoomsExistsQuery() string {
+	return RoomsExistsQuery
+}
+
+// RoomsNameTakenQuery returns the query string to check a name is taken by a
+// room other than the given room.
+func (Driver) RoomsNameTakenQuery() string {
+	return RoomsNameTakenQuery
+}
+
+// RoomsExistingQuery returns the query string to select which of the given
+// rooms exist.
+func (Driver) RoomsExistingQuery() string {
+	return RoomsExistingQuery
+}
+
+// RoomsLockQuery returns the query string to lock a room, selecting its id.
+func (Driver) RoomsLockQuery() string {
+	return RoomsLockQuery
+}
+
+// RoomsDependentsQuery returns the query string to count the items and links
+// located in or leading to a room.
+func (Driver) RoomsDependentsQuery() string {
+	return RoomsDependentsQuery
+}
+
+// RoomsReferencesQuery returns the query string to count the items located in
+// a room, and the links located in or leading to it.
+func (Driver) RoomsReferencesQuery() string {
+	return RoomsReferencesQuery
+}
+
+// RoomsCascadeItemsQuery returns the query string to select the ids of the
+// items located in a room.
+func (Driver) RoomsCascadeItemsQuery() string {
+	return RoomsCascadeItemsQuery
+}
+
+// RoomsCascadeLinksQuery returns the query string to select the ids of the
+// links located in or leading to a room.
+func (Driver) RoomsCascadeLinksQuery() string {
+	return RoomsCascadeLinksQuery
+}
+
 // LinksListQuery returns the List query string given the filter.
-func (Driver) LinksListQuery(arcade.LinksFilter) string {
-	return LinksListQuery
+func (d Driver) LinksListQuery(filter arcade.LinksFilter) string {
+	var conds []string
+	if filter.OwnerID != nil {
+		conds = append(conds, fmt.Sprintf("owner_id = '%s'", filter.OwnerID))
+	}
+	if filter.LocationID != nil {
+		conds = append(conds, fmt.Sprintf("location_id = '%s'", filter.LocationID))
+	}
+	if filter.DestinationID != nil {
+		conds = append(conds, fmt.Sprintf("destination_id = '%s'", filter.DestinationID))
+	}
+	if filter.TraversalCountAtLeast != nil {
+		conds = append(conds, fmt.Sprintf("traversal_count >= %d", *filter.TraversalCountAtLeast))
+	}
+	if filter.CreatedBy != nil {
+		conds = append(conds, "created_by = $1")
+	}
+	if filter.CreatedWithin != nil {
+		conds = append(conds, createdWithin(*filter.CreatedWithin))
+	}
+	fq := ""
+	if len(conds) > 0 {
+		fq += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return LinksListQuery + fq + orderBy(filter.Sort) + limitAndOffset(d.limit(filter.Limit), filter.Offset)
 }
 
 // LinksGetQuery returns the Get query string.
@@ -154,6 +524,21 @@ func (Driver) LinksGetQuery() string {
 	return LinksGetQuery
 }
 
+// LinksGetManyQuery returns the GetMany query string.
+func (Driver) LinksGetManyQuery() string {
+	return LinksGetManyQuery
+}
+
+// LinksGetWithRoomsQuery returns the GetWithRooms query string.
+func (Driver) LinksGetWithRoomsQuery() string {
+	return LinksGetWithRoomsQuery
+}
+
+// LinksGetReverseQuery returns the GetReverse query string.
+func (Driver) LinksGetReverseQuery() string {
+	return LinksGetReverseQuery
+}
+
 // LinksCreateQuery returns the Create query string.
 func (Driver) LinksCreateQuery() string {
 	return LinksCreateQuery
@@ -169,9 +554,87 @@ func (Driver) LinksRemoveQuery() string {
 	return LinksRemoveQuery
 }
 
+// LinksTraverseQuery returns the TryTraverse query string.
+func (Driver) LinksTraverseQuery() string {
+	return LinksTraverseQuery
+}
+
+// LinksReleaseQuery returns the Release query string.
+func (Driver) LinksReleaseQuery() string {
+	return LinksReleaseQuery
+}
+
+// LinksIncrementTraversalQuery returns the IncrementTraversal query string.
+func (Driver) LinksIncrementTraversalQuery() string {
+	return LinksIncrementTraversalQuery
+}
+
+// LinksWithinHopsQuery returns the WithinHops query string.
+func (Driver) LinksWithinHopsQuery() string {
+	return LinksWithinHopsQuery
+}
+
+// LinksExitsQuery returns the query string of the links located in any of
+// the given rooms, used to find a route.
+func (Driver) LinksExitsQuery() string {
+	return LinksExitsQuery
+}
+
+// LinksDanglingQuery returns the FindDangling query string.
+func (d Driver) LinksDanglingQuery() string {
+	return LinksDanglingQuery + limitAndOffset(d.limit(0), 0)
+}
+
+// LinksMoveAllQuery returns the query string to move all links in a room to
+// another room.
+func (Driver) LinksMoveAllQuery() string {
+	return LinksMoveAllQuery
+}
+
+// LinksRedirectAllQuery returns the query string to change the destination of
+// all links to a room to another room.
+func (Driver) LinksRedirectAllQuery() string {
+	return LinksRedirectAllQuery
+}
+
 // ItemsListQuery returns the List query string given the filter.
-func (Driver) ItemsListQuery(arcade.ItemsFilter) string {
-	return ItemsListQuery
+func (d Driver) ItemsListQuery(filter arcade.ItemsFilter) string {
+	var conds []string
+	if filter.LocationID != nil {
+		conds = append(conds, fmt.Sprintf("location_id = '%s'", *filter.LocationID))
+	}
+	if filter.InventoryID != nil {
+		conds = append(conds, fmt.Sprintf("inventory_id = '%s'", *filter.InventoryID))
+	}
+	if filter.OwnerIDs != nil {
+		if cond, ok := d.anyOf("owner_id", filter.OwnerIDs); ok {
+			conds = append(conds, cond)
+		}
+	}
+	if filter.NeverUpdated != nil {
+		// Create sets both timestamps equal, any update advances updated.
+		if *filter.NeverUpdated {
+			conds = append(conds, "created = updated")
+		} else {
+			conds = append(conds, "created <> updated")
+		}
+	}
+	if filter.CreatedBy != nil {
+		conds = append(conds, "created_by = $1")
+	}
+	if filter.CreatedWithin != nil {
+		conds = append(conds, createdWithin(*filter.CreatedWithin))
+	}
+	fq := ""
+	if filter.AsOf != nil {
+		fq += asOf(*filter.AsOf)
+	}
+	if len(conds) > 0 {
+		fq += " WHERE " + strings.Join(conds, " AND ")
+	}
+	fq += orderBy(filter.Sort)
+	fq += limitAndOffset(d.limit(filter.Limit), filter.Offset)
+	return ItemsListQuery + fq
 }
 
 // ItemsGetQuery returns the Get query string.
@@ -179,6 +642,11 @@ func (Driver) ItemsGetQuery() string {
 	return ItemsGetQuery
 }
 
+// ItemsGetManyQuery returns the GetMany query string.
+func (Driver) ItemsGetManyQuery() string {
+	return ItemsGetManyQuery
+}
+
 // ItemsCreateQuery returns the Create query string.
 func (Driver) ItemsCreateQuery() string {
 	return ItemsCreateQuery
@@ -194,6 +662,208 @@ func (Driver) ItemsRemoveQuery() string {
 	return ItemsRemoveQuery
 }
 
+// ItemsRemoveManyQuery returns the RemoveMany query string.
+func (Driver) ItemsRemoveManyQuery() string {
+	return ItemsRemoveManyQuery
+}
+
+// ItemsInventoryOccupancyQuery returns the query string to lock a player,
+// selecting its inventory capacity, the number of other items in its
+// inventory, and whether it holds the item.
+func (Driver) ItemsInventoryOccupancyQuery() string {
+	return ItemsInventoryOccupancyQuery
+}
+
+// ItemsExistsQuery returns the Exists query string.
+func (Driver) ItemsExistsQuery() string {
+	return ItemsExistsQuery
+}
+
+// ItemsSearchQuery returns the Search query string given the filter.
+func (d Driver) ItemsSearchQuery(filter arcade.ItemsSearchFilter) string {
+	if d.NoFullTextSearch {
+		return ItemsILikeSearchQuery + limitAndOffset(d.limit(filter.Limit), filter.Offset)
+	}
+	return ItemsSearchQuery + limitAndOffset(d.limit(filter.Limit), filter.Offset)
+}
+
+// ItemsLocationQuery returns the query string to read and lock the location
+// of an item.
+func (Driver) ItemsLocationQuery() string {
+	return ItemsLocationQuery
+}
+
+// ItemsSetLocationQuery returns the query string to set the location of an
+// item.
+func (Driver) ItemsSetLocationQuery() string {
+	return ItemsSetLocationQuery
+}
+
+// ItemsMoveAllQuery returns the query string to move all items in a room to
+// another room.
+func (Driver) ItemsMoveAllQuery() string {
+	return ItemsMoveAllQuery
+}
+
+// ItemsChangedSinceQuery returns the query string to list the items updated
+// after a time, in order of update.
+func (Driver) ItemsChangedSinceQuery() string {
+	return ItemsChangedSinceQuery
+}
+
+// ItemsTopOwnersQuery returns the query string to count the items of each
+// owner, in descending order of count.
+func (Driver) ItemsTopOwnersQuery() string {
+	return ItemsTopOwnersQuery
+}
+
+// ItemsTotalWeightQuery returns the query string to sum the weight of the
+// items in the inventory of a player.
+func (Driver) ItemsTotalWeightQuery() string {
+	return ItemsTotalWeightQuery
+}
+
+// ItemsGroupedByLocationQuery returns the query string to count the items of
+// an owner in each location, the inventory of a player taking precedence
+// over a room.
+func (Driver) ItemsGroupedByLocationQuery() string {
+	return ItemsGroupedByLocationQuery
+}
+
+// ItemsOrphansQuery returns the FindOrphans query string.
+func (d Driver) ItemsOrphansQuery() string {
+	return ItemsOrphansQuery + limitAndOffset(d.limit(0), 0)
+}
+
+// ItemsFixOrphanedOwnersQuery returns the query string to reset the dangling
+// owner of items to the default owner.
+func (Driver) ItemsFixOrphanedOwnersQuery() string {
+	return ItemsFixOrphanedOwnersQuery
+}
+
+// ItemsFixOrphanedLocationsQuery returns the query string to reset the
+// dangling location of items to the default location.
+func (Driver) ItemsFixOrphanedLocationsQuery() string {
+	return ItemsFixOrphanedLocationsQuery
+}
+
+// ItemsFixOrphanedInventoriesQuery returns the query string to null the
+// dangling inventory of items.
+func (Driver) ItemsFixOrphanedInventoriesQuery() string {
+	return ItemsFixOrphanedInventoriesQuery
+}
+
+// ItemsOwnerQuery returns the query string to read and lock the owner and
+// inventory of an item.
+func (Driver) ItemsOwnerQuery() string {
+	return ItemsOwnerQuery
+}
+
+// ItemsSetOwnerQuery returns the query string to set the owner of an item.
+func (Driver) ItemsSetOwnerQuery() string {
+	return ItemsSetOwnerQuery
+}
+
+// ItemsRecordTransferQuery returns the query string to record the transfer
+// of an item from one owner to another.
+func (Driver) ItemsRecordTransferQuery() string {
+	return ItemsRecordTransferQuery
+}
+
+// ItemsImportSavepointQuery returns the query string to set a savepoint
+// before importing a row.
+func (Driver) ItemsImportSavepointQuery() string {
+	return ItemsImportSavepointQuery
+}
+
+// ItemsImportRollbackQuery returns the query string to rollback a failed row
+// of an import to its savepoint.
+func (Driver) ItemsImportRollbackQuery() string {
+	return ItemsImportRollbackQuery
+}
+
+// ItemsImportReleaseQuery returns the query string to release the savepoint
+// of an imported row.
+func (Driver) ItemsImportReleaseQuery() string {
+	return ItemsImportReleaseQuery
+}
+
+// AnalyzeQuery returns the query string to refresh the statistics of the
+// given table.
+func (Driver) AnalyzeQuery(table string) string {
+	return fmt.Sprintf(AnalyzeQuery, table)
+}
+
+// WorldEntitiesQuery returns the query string to count the players, rooms,
+// links and items together.
+func (Driver) WorldEntitiesQuery() string {
+	return WorldEntitiesQuery
+}
+
+// StatsRecordQuery returns the query string to record the current count of
+// each stats entity.
+func (Driver) StatsRecordQuery() string {
+	return StatsRecordQuery
+}
+
+// StatsHistoryQuery returns the query string to select the recorded counts
+// of an entity since a time, oldest first, capped to the maximum list rows.
+func (d Driver) StatsHistoryQuery() string {
+	return StatsHistoryQuery + limitAndOffset(d.limit(0), 0)
+}
+
+// ExportChecksumQuery returns the query string to select the id, entity and
+// updated time of every entity, ordered by id.
+func (Driver) ExportChecksumQuery() string {
+	return ExportChecksumQuery
+}
+
+// SnapshotQuery returns the query string to read the current time, as of
+// which a list may be read.
+func (Driver) SnapshotQuery() string {
+	return SnapshotQuery
+}
+
+// followerReadSelects are the selects of the list and get queries which may be
+// read as follower reads. Each ends with its from clause, after which the
+// follower read clause is placed, before any conditions, order or limit.
+var followerReadSelects = []string{
+	PlayersListQuery,
+	RoomsListQuery,
+	LinksListQuery,
+	linksWithRoomsSelect,
+	linksReverseSelect,
+	ItemsListQuery,
+}
+
+// StaleReadQuery returns the given list or get query as a follower read, which
+// any replica may serve, by reading its tables as of the follower read
+// timestamp. A query not built on one of the follower read selects, or
+// already reading as of a time, is returned as is.
+func (Driver) StaleReadQuery(query string) string {
+	if strings.Contains(query, " AS OF SYSTEM TIME ") {
+		return query
+	}
+	for _, sel := range followerReadSelects {
+		if !strings.HasPrefix(query, sel) {
+			continue
+		}
+		rest := query[len(sel):]
+		if rest == "" || strings.HasPrefix(rest, " WHERE ") || strings.HasPrefix(rest, " ORDER BY ") || strings.HasPrefix(rest, " LIMIT ") {
+			return sel + FollowerReadClause + rest
+		}
+	}
+	return query
+}
+
+// ListRowsCap returns the most rows a list query will return.
+func (d Driver) ListRowsCap() int {
+	if d.MaxListRows > 0 {
+		return d.MaxListRows
+	}
+	return DefaultMaxListRows
+}
+
 // IsForeignKeyViolation returns true if the given error is a foreign key violation error.
 func (Driver) IsForeignKeyViolation(err error) bool {
 	var pgErr *pgconn.PgError
@@ -17,7 +17,9 @@ package cockroach_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"arcadium.dev/arcade"
 	"github.com/google/uuid"
@@ -33,6 +35,9 @@ func TestDriver(t *testing.T) {
 	if d.PlayersGetQuery() != cockroach.PlayersGetQuery {
 		t.Error("query mismatch")
 	}
+	if d.PlayersGetManyQuery() != cockroach.PlayersGetManyQuery {
+		t.Error("query mismatch")
+	}
 	if d.PlayersCreateQuery() != cockroach.PlayersCreateQuery {
 		t.Error("query mismatch")
 	}
@@ -42,13 +47,22 @@ func TestDriver(t *testing.T) {
 	if d.PlayersRemoveQuery() != cockroach.PlayersRemoveQuery {
 		t.Error("query mismatch")
 	}
+	if d.PlayersSetLocationQuery() != cockroach.PlayersSetLocationQuery {
+		t.Error("query mismatch")
+	}
 
-	if d.RoomsListQuery(arcade.RoomsFilter{}) != cockroach.RoomsListQuery {
+	if d.RoomsListQuery(arcade.RoomsFilter{}) != cockroach.RoomsListQuery+" ORDER BY created ASC LIMIT 10000" {
 		t.Error("query mismatch")
 	}
 	if d.RoomsGetQuery() != cockroach.RoomsGetQuery {
 		t.Error("query mismatch")
 	}
+	if d.RoomsGetManyQuery() != cockroach.RoomsGetManyQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsGetByNameQuery() != cockroach.RoomsGetByNameQuery {
+		t.Error("query mismatch")
+	}
 	if d.RoomsCreateQuery() != cockroach.RoomsCreateQuery {
 		t.Error("query mismatch")
 	}
@@ -58,13 +72,31 @@ func TestDriver(t *testing.T) {
 	if d.RoomsRemoveQuery() != cockroach.RoomsRemoveQuery {
 		t.Error("query mismatch")
 	}
+	if d.RoomsRenameQuery() != cockroach.RoomsRenameQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsAddTagQuery() != cockroach.RoomsAddTagQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsRemoveTagQuery() != cockroach.RoomsRemoveTagQuery {
+		t.Error("query mismatch")
+	}
 
-	if d.LinksListQuery(arcade.LinksFilter{}) != cockroach.LinksListQuery {
+	if d.LinksListQuery(arcade.LinksFilter{}) != cockroach.LinksListQuery+" ORDER BY created ASC LIMIT 10000" {
 		t.Error("query mismatch")
 	}
 	if d.LinksGetQuery() != cockroach.LinksGetQuery {
 		t.Error("query mismatch")
 	}
+	if d.LinksGetManyQuery() != cockroach.LinksGetManyQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksGetWithRoomsQuery() != cockroach.LinksGetWithRoomsQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksGetReverseQuery() != cockroach.LinksGetReverseQuery {
+		t.Error("query mismatch")
+	}
 	if d.LinksCreateQuery() != cockroach.LinksCreateQuery {
 		t.Error("query mismatch")
 	}
@@ -75,12 +107,15 @@ func TestDriver(t *testing.T) {
 		t.Error("query mismatch")
 	}
 
-	if d.ItemsListQuery(arcade.ItemsFilter{}) != cockroach.ItemsListQuery {
+	if d.ItemsListQuery(arcade.ItemsFilter{}) != cockroach.ItemsListQuery+" ORDER BY created ASC LIMIT 10000" {
 		t.Error("query mismatch")
 	}
 	if d.ItemsGetQuery() != cockroach.ItemsGetQuery {
 		t.Error("query mismatch")
 	}
+	if d.ItemsGetManyQuery() != cockroach.ItemsGetManyQuery {
+		t.Error("query mismatch")
+	}
 	if d.ItemsCreateQuery() != cockroach.ItemsCreateQuery {
 		t.Error("query mismatch")
 	}
@@ -90,6 +125,126 @@ func TestDriver(t *testing.T) {
 	if d.ItemsRemoveQuery() != cockroach.ItemsRemoveQuery {
 		t.Error("query mismatch")
 	}
+	if d.ItemsRemoveManyQuery() != cockroach.ItemsRemoveManyQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsInventoryOccupancyQuery() != cockroach.ItemsInventoryOccupancyQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsExistsQuery() != cockroach.ItemsExistsQuery {
+		t.Error("query mismatch")
+	}
+	if d.PlayersExistsQuery() != cockroach.PlayersExistsQuery {
+		t.Error("query mismatch")
+	}
+	if d.PlayersGetByNameQuery() != cockroach.PlayersGetByNameQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsExistsQuery() != cockroach.RoomsExistsQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsNameTakenQuery() != cockroach.RoomsNameTakenQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsExistingQuery() != cockroach.RoomsExistingQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsLockQuery() != cockroach.RoomsLockQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsDependentsQuery() != cockroach.RoomsDependentsQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsReferencesQuery() != cockroach.RoomsReferencesQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsCascadeItemsQuery() != cockroach.RoomsCascadeItemsQuery {
+		t.Error("query mismatch")
+	}
+	if d.RoomsCascadeLinksQuery() != cockroach.RoomsCascadeLinksQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksMoveAllQuery() != cockroach.LinksMoveAllQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksRedirectAllQuery() != cockroach.LinksRedirectAllQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsMoveAllQuery() != cockroach.ItemsMoveAllQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsChangedSinceQuery() != cockroach.ItemsChangedSinceQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsTopOwnersQuery() != cockroach.ItemsTopOwnersQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsOrphansQuery() != cockroach.ItemsOrphansQuery+" LIMIT 10000" {
+		t.Error("query mismatch")
+	}
+	if d.ItemsFixOrphanedOwnersQuery() != cockroach.ItemsFixOrphanedOwnersQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsFixOrphanedLocationsQuery() != cockroach.ItemsFixOrphanedLocationsQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsFixOrphanedInventoriesQuery() != cockroach.ItemsFixOrphanedInventoriesQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsImportSavepointQuery() != cockroach.ItemsImportSavepointQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsImportRollbackQuery() != cockroach.ItemsImportRollbackQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsImportReleaseQuery() != cockroach.ItemsImportReleaseQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsOwnerQuery() != cockroach.ItemsOwnerQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsSetOwnerQuery() != cockroach.ItemsSetOwnerQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsRecordTransferQuery() != cockroach.ItemsRecordTransferQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsTotalWeightQuery() != cockroach.ItemsTotalWeightQuery {
+		t.Error("query mismatch")
+	}
+	if d.ItemsGroupedByLocationQuery() != cockroach.ItemsGroupedByLocationQuery {
+		t.Error("query mismatch")
+	}
+	if d.AnalyzeQuery("items") != "ANALYZE items" {
+		t.Error("query mismatch")
+	}
+	if d.WorldEntitiesQuery() != cockroach.WorldEntitiesQuery {
+		t.Error("query mismatch")
+	}
+	if d.StatsRecordQuery() != cockroach.StatsRecordQuery {
+		t.Error("query mismatch")
+	}
+	if d.StatsHistoryQuery() != cockroach.StatsHistoryQuery+" LIMIT 10000" {
+		t.Error("query mismatch")
+	}
+	if d.ExportChecksumQuery() != cockroach.ExportChecksumQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksIncrementTraversalQuery() != cockroach.LinksIncrementTraversalQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksWithinHopsQuery() != cockroach.LinksWithinHopsQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksExitsQuery() != cockroach.LinksExitsQuery {
+		t.Error("query mismatch")
+	}
+	if d.LinksDanglingQuery() != cockroach.LinksDanglingQuery+" LIMIT 10000" {
+		t.Error("query mismatch")
+	}
+	if d.SnapshotQuery() != cockroach.SnapshotQuery {
+		t.Error("query mismatch")
+	}
 
 	if d.IsForeignKeyViolation(errors.New("nope")) {
 		t.Error("huh?")
@@ -108,13 +263,183 @@ func TestDriver(t *testing.T) {
 	}
 }
 
+func TestItemsListQuery(t *testing.T) {
+	d := cockroach.Driver{}
+
+	tests := []struct {
+		neverUpdated *bool
+		where        string
+	}{
+		{nil, ""},
+		{func() *bool { b := true; return &b }(), " WHERE created = updated"},
+		{func() *bool { b := false; return &b }(), " WHERE created <> updated"},
+	}
+	for _, test := range tests {
+		actual := d.ItemsListQuery(arcade.ItemsFilter{NeverUpdated: test.neverUpdated})
+		expected := cockroach.ItemsListQuery + test.where + " ORDER BY created ASC LIMIT 10000"
+		if expected != actual {
+			t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+		}
+	}
+
+	id := uuid.New()
+	updated := false
+	actual := d.ItemsListQuery(arcade.ItemsFilter{InventoryID: &id, NeverUpdated: &updated})
+	expected := cockroach.ItemsListQuery + fmt.Sprintf(" WHERE inventory_id = '%s' AND created <> updated ORDER BY created ASC LIMIT 10000", id)
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	asOf := time.Date(2026, 10, 16, 10, 30, 0, 123456000, time.FixedZone("EDT", -4*60*60))
+	actual = d.ItemsListQuery(arcade.ItemsFilter{AsOf: &asOf, NeverUpdated: &updated, Limit: 10, Offset: 20})
+	expected = cockroach.ItemsListQuery + " AS OF SYSTEM TIME '2026-10-16 14:30:00.123456+00:00' WHERE created <> updated ORDER BY created ASC LIMIT 10 OFFSET 20"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	locationID := id.String()
+	actual = d.ItemsListQuery(arcade.ItemsFilter{LocationID: &locationID, Limit: 10, Offset: 20})
+	expected = cockroach.ItemsListQuery + fmt.Sprintf(" WHERE location_id = '%s' ORDER BY created ASC LIMIT 10 OFFSET 20", id)
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
+func TestItemsListQueryOwnerIDs(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name           string
+		ownerIDs       []uuid.UUID
+		matchesNothing bool
+		where          string
+	}{
+		{"nil", nil, false, ""},
+		{"nil matching nothing", nil, true, ""},
+		{"empty", []uuid.UUID{}, false, ""},
+		{"empty matching nothing", []uuid.UUID{}, true, " WHERE owner_id = ANY('{}')"},
+		{"one", []uuid.UUID{a}, false, fmt.Sprintf(" WHERE owner_id = ANY('{%s}')", a)},
+		{"many", []uuid.UUID{a, b}, true, fmt.Sprintf(" WHERE owner_id = ANY('{%s,%s}')", a, b)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := cockroach.Driver{EmptyListFilterMatchesNothing: test.matchesNothing}
+
+			actual := d.ItemsListQuery(arcade.ItemsFilter{OwnerIDs: test.ownerIDs})
+			expected := cockroach.ItemsListQuery + test.where + " ORDER BY created ASC LIMIT 10000"
+			if expected != actual {
+				t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestLinksListQuery(t *testing.T) {
+	count := 100
+	actual := cockroach.Driver{}.LinksListQuery(arcade.LinksFilter{TraversalCountAtLeast: &count})
+	expected := cockroach.LinksListQuery + " WHERE traversal_count >= 100 ORDER BY created ASC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	ownerID := uuid.MustParse("00000000-0000-0000-0000-000000000042")
+	locationID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	actual = cockroach.Driver{}.LinksListQuery(arcade.LinksFilter{OwnerID: &ownerID, LocationID: &locationID})
+	expected = cockroach.LinksListQuery + " WHERE owner_id = '00000000-0000-0000-0000-000000000042'" +
+		" AND location_id = '00000000-0000-0000-0000-000000000001' ORDER BY created ASC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = cockroach.Driver{}.LinksListQuery(arcade.LinksFilter{Limit: 25, Offset: 50})
+	expected = cockroach.LinksListQuery + " ORDER BY created ASC LIMIT 25 OFFSET 50"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
+func TestLinksWithinHopsQuery(t *testing.T) {
+	// On a cyclic graph the recursion must not follow a link back into a
+	// room already on its path, else the rows grow with every hop.
+	q := cockroach.Driver{}.LinksWithinHopsQuery()
+	for _, expected := range []string{
+		"SELECT $1::UUID, 0, ARRAY[$1::UUID] UNION ALL ",
+		"reachable.path || links.destination_id ",
+		"WHERE reachable.hops < $2 AND links.destination_id <> ALL(reachable.path))",
+	} {
+		if !strings.Contains(q, expected) {
+			t.Errorf("\nExpected query containing: %s\nActual query: %s", expected, q)
+		}
+	}
+}
+
+func TestCreatedByListQueries(t *testing.T) {
+	d := cockroach.Driver{}
+	createdBy := "player:42"
+	where := " WHERE created_by = $1 ORDER BY created ASC LIMIT 10000"
+
+	if actual := d.PlayersListQuery(arcade.PlayersFilter{CreatedBy: &createdBy}); actual != cockroach.PlayersListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.RoomsListQuery(arcade.RoomsFilter{CreatedBy: &createdBy}); actual != cockroach.RoomsListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.LinksListQuery(arcade.LinksFilter{CreatedBy: &createdBy}); actual != cockroach.LinksListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.ItemsListQuery(arcade.ItemsFilter{CreatedBy: &createdBy}); actual != cockroach.ItemsListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+}
+
+func TestCreatedWithinListQueries(t *testing.T) {
+	d := cockroach.Driver{}
+	within := 15 * time.Minute
+	where := " WHERE created > now() - INTERVAL '900000000 microseconds' ORDER BY created DESC LIMIT 10000"
+	sort := arcade.Sort{Column: "created", Desc: true}
+
+	if actual := d.PlayersListQuery(arcade.PlayersFilter{CreatedWithin: &within, Sort: sort}); actual != cockroach.PlayersListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.RoomsListQuery(arcade.RoomsFilter{CreatedWithin: &within, Sort: sort}); actual != cockroach.RoomsListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.LinksListQuery(arcade.LinksFilter{CreatedWithin: &within, Sort: sort}); actual != cockroach.LinksListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+	if actual := d.ItemsListQuery(arcade.ItemsFilter{CreatedWithin: &within, Sort: sort}); actual != cockroach.ItemsListQuery+where {
+		t.Errorf("Unexpected query: %s", actual)
+	}
+
+	createdBy := "system"
+	actual := d.RoomsListQuery(arcade.RoomsFilter{CreatedBy: &createdBy, CreatedWithin: &within})
+	expected := cockroach.RoomsListQuery + " WHERE created_by = $1 AND created > now() - INTERVAL '900000000 microseconds' ORDER BY created ASC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
+func TestRoomsListQuery(t *testing.T) {
+	actual := cockroach.Driver{}.RoomsListQuery(arcade.RoomsFilter{Limit: 42, Offset: 10})
+	expected := cockroach.RoomsListQuery + " ORDER BY created ASC LIMIT 42 OFFSET 10"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = cockroach.Driver{MaxListRows: 100}.RoomsListQuery(arcade.RoomsFilter{Limit: 1000})
+	expected = cockroach.RoomsListQuery + " ORDER BY created ASC LIMIT 100"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
 func TestPlayersListQuery(t *testing.T) {
 	d := cockroach.Driver{}
 
 	filter := arcade.PlayersFilter{}
 
 	actual := d.PlayersListQuery(filter)
-	expected := cockroach.PlayersListQuery
+	expected := cockroach.PlayersListQuery + " ORDER BY created ASC LIMIT 10000"
 	if expected != actual {
 		t.Errorf("\nExpected query: %s\nActual query   %s", expected, actual)
 	}
@@ -122,7 +447,7 @@ func TestPlayersListQuery(t *testing.T) {
 	id := uuid.New()
 	filter.LocationID = &id
 	actual = d.PlayersListQuery(filter)
-	expected = cockroach.PlayersListQuery + fmt.Sprintf(" WHERE location_id = '%s'", id)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" WHERE location_id = '%s' ORDER BY created ASC LIMIT 10000", id)
 	if expected != actual {
 		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
 	}
@@ -131,7 +456,7 @@ func TestPlayersListQuery(t *testing.T) {
 	filter.LocationID = nil
 	filter.Limit = limit
 	actual = d.PlayersListQuery(filter)
-	expected = cockroach.PlayersListQuery + fmt.Sprintf(" LIMIT %d", limit)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" ORDER BY created ASC LIMIT %d", limit)
 	if expected != actual {
 		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
 	}
@@ -140,7 +465,7 @@ func TestPlayersListQuery(t *testing.T) {
 	filter.Limit = 0
 	filter.Offset = offset
 	actual = d.PlayersListQuery(filter)
-	expected = cockroach.PlayersListQuery + fmt.Sprintf(" OFFSET %d", offset)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" ORDER BY created ASC LIMIT 10000 OFFSET %d", offset)
 	if expected != actual {
 		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
 	}
@@ -149,8 +474,261 @@ func TestPlayersListQuery(t *testing.T) {
 	filter.Limit = limit
 	filter.Offset = offset
 	actual = d.PlayersListQuery(filter)
-	expected = cockroach.PlayersListQuery + fmt.Sprintf(" WHERE location_id = '%s' LIMIT %d OFFSET %d", id, limit, offset)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" WHERE location_id = '%s' ORDER BY created ASC LIMIT %d OFFSET %d", id, limit, offset)
 	if expected != actual {
 		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
 	}
+
+	lastSeen := time.Date(2022, 10, 16, 12, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+	filter = arcade.PlayersFilter{LastSeenBefore: &lastSeen}
+	actual = d.PlayersListQuery(filter)
+	expected = cockroach.PlayersListQuery + " WHERE last_seen < '2022-10-16T16:00:00Z' ORDER BY created ASC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	filter.LocationID = &id
+	actual = d.PlayersListQuery(filter)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" WHERE location_id = '%s' AND last_seen < '2022-10-16T16:00:00Z' ORDER BY created ASC LIMIT 10000", id)
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	filter = arcade.PlayersFilter{Sort: arcade.Sort{Column: "updated", Desc: true}, Limit: limit}
+	actual = d.PlayersListQuery(filter)
+	expected = cockroach.PlayersListQuery + fmt.Sprintf(" ORDER BY updated DESC LIMIT %d", limit)
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
+func TestMaxListRows(t *testing.T) {
+	d := cockroach.Driver{MaxListRows: 50}
+
+	actual := d.RoomsListQuery(arcade.RoomsFilter{})
+	expected := cockroach.RoomsListQuery + " ORDER BY created ASC LIMIT 50"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = d.PlayersListQuery(arcade.PlayersFilter{Limit: 100})
+	expected = cockroach.PlayersListQuery + " ORDER BY created ASC LIMIT 50"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = d.PlayersListQuery(arcade.PlayersFilter{Limit: 20})
+	expected = cockroach.PlayersListQuery + " ORDER BY created ASC LIMIT 20"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	if d.ListRowsCap() != 50 || (cockroach.Driver{}).ListRowsCap() != cockroach.DefaultMaxListRows {
+		t.Errorf("Unexpected list rows cap: %d", d.ListRowsCap())
+	}
+}
+
+func TestItemsSearchQuery(t *testing.T) {
+	filter := arcade.ItemsSearchFilter{Query: "sword", Limit: 10, Offset: 20}
+
+	actual := cockroach.Driver{}.ItemsSearchQuery(filter)
+	expected := cockroach.ItemsSearchQuery + " LIMIT 10 OFFSET 20"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = cockroach.Driver{NoFullTextSearch: true}.ItemsSearchQuery(filter)
+	expected = cockroach.ItemsILikeSearchQuery + " LIMIT 10 OFFSET 20"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	// Each match escapes the LIKE metacharacters of the search query.
+	escaped := `replace(replace(replace($1, '\', '\\'), '%', '\%'), '_', '\_')`
+	if n := strings.Count(cockroach.ItemsILikeSearchQuery, escaped); n != 3 {
+		t.Errorf("Unexpected escaped matches: %d", n)
+	}
+}
+
+func TestListQuerySort(t *testing.T) {
+	d := cockroach.Driver{}
+
+	actual := d.RoomsListQuery(arcade.RoomsFilter{Sort: arcade.Sort{Column: "name"}})
+	expected := cockroach.RoomsListQuery + " ORDER BY name ASC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = d.ItemsListQuery(arcade.ItemsFilter{Sort: arcade.Sort{Column: "updated", Desc: true}})
+	expected = cockroach.ItemsListQuery + " ORDER BY updated DESC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+
+	actual = d.LinksListQuery(arcade.LinksFilter{Sort: arcade.Sort{Column: "created", Desc: true}})
+	expected = cockroach.LinksListQuery + " ORDER BY created DESC LIMIT 10000"
+	if expected != actual {
+		t.Errorf("\nExpected query: %s\nActual query:   %s", expected, actual)
+	}
+}
+
+func TestRoomsCascadeQueries(t *testing.T) {
+	// The preview selects exactly the rows counted as the references of a
+	// removed room.
+	for _, test := range []struct {
+		query, column string
+	}{
+		{cockroach.RoomsCascadeItemsQuery, "item_id"},
+		{cockroach.RoomsCascadeLinksQuery, "link_id"},
+	} {
+		from := strings.TrimSuffix(strings.TrimPrefix(test.query, "SELECT "+test.column+" "), " ORDER BY "+test.column)
+		if from == test.query || !strings.HasPrefix(from, "FROM ") {
+			t.Fatalf("Unexpected preview query: %s", test.query)
+		}
+		if !strings.Contains(cockroach.RoomsReferencesQuery, "(SELECT count(*) "+from+")") {
+			t.Errorf("Preview diverges from the references: %s", from)
+		}
+		if !strings.Contains(cockroach.RoomsDependentsQuery, "(SELECT count(*) "+from+")") {
+			t.Errorf("Preview diverges from the dependents: %s", from)
+		}
+	}
+}
+
+func TestItemsOrphanQueries(t *testing.T) {
+	// Each fix updates exactly the items found orphaned by its reference.
+	for _, query := range []string{
+		cockroach.ItemsFixOrphanedOwnersQuery,
+		cockroach.ItemsFixOrphanedLocationsQuery,
+		cockroach.ItemsFixOrphanedInventoriesQuery,
+	} {
+		_, where, found := strings.Cut(query, ", updated = now() WHERE ")
+		if !found {
+			t.Fatalf("Unexpected fix query: %s", query)
+		}
+		if !strings.Contains(cockroach.ItemsOrphansQuery, where) {
+			t.Errorf("Fix diverges from the orphans: %s", where)
+		}
+	}
+}
+
+func TestStaleReadQuery(t *testing.T) {
+	d := cockroach.Driver{}
+	const clause = cockroach.FollowerReadClause
+	createdBy := "system"
+
+	// Every query read stale by the storage, and queries which are not.
+	for _, test := range []struct {
+		name, query, expected string
+	}{
+		{
+			name:     "players list",
+			query:    d.PlayersListQuery(arcade.PlayersFilter{CreatedBy: &createdBy}),
+			expected: cockroach.PlayersListQuery + clause + " WHERE created_by = $1 ORDER BY created ASC LIMIT 10000",
+		},
+		{
+			name:     "players get",
+			query:    d.PlayersGetQuery(),
+			expected: cockroach.PlayersListQuery + clause + " WHERE player_id = $1",
+		},
+		{
+			name:     "players get many",
+			query:    d.PlayersGetManyQuery(),
+			expected: cockroach.PlayersListQuery + clause + " WHERE player_id = ANY($1)",
+		},
+		{
+			name:     "rooms list",
+			query:    d.RoomsListQuery(arcade.RoomsFilter{}),
+			expected: cockroach.RoomsListQuery + clause + " ORDER BY created ASC LIMIT 10000",
+		},
+		{
+			name:     "rooms get",
+			query:    d.RoomsGetQuery(),
+			expected: cockroach.RoomsListQuery + clause + " WHERE room_id = $1",
+		},
+		{
+			name:     "rooms get many",
+			query:    d.RoomsGetManyQuery(),
+			expected: cockroach.RoomsListQuery + clause + " WHERE room_id = ANY($1)",
+		},
+		{
+			name:     "links list",
+			query:    d.LinksListQuery(arcade.LinksFilter{}),
+			expected: cockroach.LinksListQuery + clause + " ORDER BY created ASC LIMIT 10000",
+		},
+		{
+			name:     "links get",
+			query:    d.LinksGetQuery(),
+			expected: cockroach.LinksListQuery + clause + " WHERE link_id = $1",
+		},
+		{
+			name:     "links get many",
+			query:    d.LinksGetManyQuery(),
+			expected: cockroach.LinksListQuery + clause + " WHERE link_id = ANY($1)",
+		},
+		{
+			name:     "links exits",
+			query:    d.LinksExitsQuery(),
+			expected: cockroach.LinksListQuery + clause + " WHERE location_id = ANY($1)",
+		},
+		{
+			name:  "links get with rooms",
+			query: d.LinksGetWithRoomsQuery(),
+			expected: "SELECT links.link_id, links.name, links.description, links.owner_id, links.location_id, links.destination_id, " +
+				"links.capacity, links.created_by, links.created, links.updated, " +
+				"COALESCE(location.name, ''), COALESCE(destination.name, '') FROM links " +
+				"LEFT JOIN rooms AS location ON location.room_id = links.location_id " +
+				"LEFT JOIN rooms AS destination ON destination.room_id = links.destination_id" +
+				clause + " WHERE links.link_id = $1",
+		},
+		{
+			name:  "links get reverse",
+			query: d.LinksGetReverseQuery(),
+			expected: "SELECT reverse.link_id, reverse.name, reverse.description, reverse.owner_id, reverse.location_id, reverse.destination_id, " +
+				"reverse.capacity, reverse.created_by, reverse.created, reverse.updated FROM links " +
+				"JOIN links AS reverse ON reverse.location_id = links.destination_id AND reverse.destination_id = links.location_id " +
+				"AND reverse.link_id != links.link_id" +
+				clause + " WHERE links.link_id = $1 ORDER BY reverse.created ASC, reverse.link_id ASC LIMIT 1",
+		},
+		{
+			name:     "items list",
+			query:    d.ItemsListQuery(arcade.ItemsFilter{}),
+			expected: cockroach.ItemsListQuery + clause + " ORDER BY created ASC LIMIT 10000",
+		},
+		{
+			name:     "items get",
+			query:    d.ItemsGetQuery(),
+			expected: cockroach.ItemsListQuery + clause + " WHERE item_id = $1",
+		},
+		{
+			name:     "items get many",
+			query:    d.ItemsGetManyQuery(),
+			expected: cockroach.ItemsListQuery + clause + " WHERE item_id = ANY($1)",
+		},
+		{
+			name:     "snapshot",
+			query:    d.ItemsListQuery(arcade.ItemsFilter{AsOf: &time.Time{}}),
+			expected: d.ItemsListQuery(arcade.ItemsFilter{AsOf: &time.Time{}}),
+		},
+		{
+			name:     "subquery",
+			query:    d.ItemsTotalWeightQuery(),
+			expected: d.ItemsTotalWeightQuery(),
+		},
+		{
+			name:     "recursive",
+			query:    d.LinksWithinHopsQuery(),
+			expected: d.LinksWithinHopsQuery(),
+		},
+		{
+			name:     "no table",
+			query:    d.SnapshotQuery(),
+			expected: d.SnapshotQuery(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := d.StaleReadQuery(test.query); actual != test.expected {
+				t.Errorf("\nExpected query: %s\nActual query:   %s", test.expected, actual)
+			}
+		})
+	}
 }
@@ -0,0 +1,41 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package cockroach // import "arcadium.dev/cockroach"
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WithApplicationName returns the given DSN setting the application_name of
+// its connections to the given name, so the queries of a service can be told
+// apart in pg_stat_activity. A DSN which already sets an application_name is
+// returned unchanged. Both URL and keyword/value DSNs are supported.
+func WithApplicationName(dsn, name string) string {
+	if name == "" || strings.Contains(dsn, "application_name") {
+		return dsn
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + "application_name=" + url.QueryEscape(name)
+	}
+
+	value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(name)
+	return strings.TrimSpace(dsn + " application_name='" + value + "'")
+}
@@ -0,0 +1,53 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package cockroach_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgconn"
+
+	"arcadium.dev/arcade/storage/cockroach"
+)
+
+func TestWithApplicationName(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		appName  string
+		expected string
+	}{
+		{name: "url", dsn: "postgresql://arcadium@cockroach:26257/arcade", appName: "assets", expected: "assets"},
+		{name: "url with params", dsn: "postgres://arcadium@cockroach:26257/arcade?sslmode=disable", appName: "assets", expected: "assets"},
+		{name: "url escaped", dsn: "postgres://arcadium@cockroach:26257/arcade", appName: "arcade assets", expected: "arcade assets"},
+		{name: "keyword/value", dsn: "host=cockroach port=26257 user=arcadium dbname=arcade", appName: "users", expected: "users"},
+		{name: "keyword/value quoted", dsn: "host=cockroach", appName: "arcade's assets", expected: "arcade's assets"},
+		{name: "already set", dsn: "postgres://arcadium@cockroach:26257/arcade?application_name=custom", appName: "assets", expected: "custom"},
+		{name: "no name", dsn: "postgres://arcadium@cockroach:26257/arcade", appName: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := pgconn.ParseConfig(cockroach.WithApplicationName(test.dsn, test.appName))
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if cfg.RuntimeParams["application_name"] != test.expected {
+				t.Errorf("Unexpected application_name: '%s'", cfg.RuntimeParams["application_name"])
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS rooms_by_tags_index;
+
+ALTER TABLE rooms DROP COLUMN tags;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE rooms ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INVERTED INDEX rooms_by_tags_index ON rooms (tags);
+
+COMMIT;
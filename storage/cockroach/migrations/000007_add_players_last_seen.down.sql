@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS players_by_last_seen_index;
+
+ALTER TABLE players DROP COLUMN last_seen;
+
+COMMIT;
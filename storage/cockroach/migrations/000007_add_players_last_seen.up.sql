@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE players ADD COLUMN last_seen TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc');
+
+CREATE INDEX players_by_last_seen_index ON players (last_seen);
+
+COMMIT;
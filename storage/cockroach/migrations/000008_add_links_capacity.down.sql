@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE links DROP COLUMN occupancy;
+ALTER TABLE links DROP COLUMN capacity;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE links ADD COLUMN capacity  INT NOT NULL DEFAULT 0 CHECK (capacity >= 0);
+ALTER TABLE links ADD COLUMN occupancy INT NOT NULL DEFAULT 0 CHECK (occupancy >= 0);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE rooms ADD COLUMN capacity INT NOT NULL DEFAULT 0 CHECK (capacity >= 0);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS links_by_traversal_count_index;
+ALTER TABLE links DROP COLUMN traversal_count;
+
+COMMIT;
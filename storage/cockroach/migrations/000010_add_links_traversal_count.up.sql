@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE links ADD COLUMN traversal_count INT NOT NULL DEFAULT 0 CHECK (traversal_count >= 0);
+
+CREATE INDEX links_by_traversal_count_index ON links (traversal_count);
+
+COMMIT;
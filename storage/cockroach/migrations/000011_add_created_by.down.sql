@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS players_by_created_by_index;
+DROP INDEX IF EXISTS rooms_by_created_by_index;
+DROP INDEX IF EXISTS links_by_created_by_index;
+DROP INDEX IF EXISTS items_by_created_by_index;
+ALTER TABLE players DROP COLUMN created_by;
+ALTER TABLE rooms DROP COLUMN created_by;
+ALTER TABLE links DROP COLUMN created_by;
+ALTER TABLE items DROP COLUMN created_by;
+
+COMMIT;
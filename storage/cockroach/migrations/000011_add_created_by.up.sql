@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE players ADD COLUMN created_by TEXT NOT NULL DEFAULT 'system';
+ALTER TABLE rooms ADD COLUMN created_by TEXT NOT NULL DEFAULT 'system';
+ALTER TABLE links ADD COLUMN created_by TEXT NOT NULL DEFAULT 'system';
+ALTER TABLE items ADD COLUMN created_by TEXT NOT NULL DEFAULT 'system';
+
+CREATE INDEX players_by_created_by_index ON players (created_by);
+CREATE INDEX rooms_by_created_by_index ON rooms (created_by);
+CREATE INDEX links_by_created_by_index ON links (created_by);
+CREATE INDEX items_by_created_by_index ON items (created_by);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE players DROP COLUMN inventory_capacity;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE players ADD COLUMN inventory_capacity INT NOT NULL DEFAULT 0 CHECK (inventory_capacity >= 0);
+
+COMMIT;
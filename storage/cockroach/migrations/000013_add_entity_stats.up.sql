@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS entity_stats (
+  entity   TEXT NOT NULL,
+  count    INT8 NOT NULL,
+  recorded TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (entity, recorded)
+);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS item_transfers (
+  item_id     UUID NOT NULL REFERENCES items (item_id) ON DELETE CASCADE,
+  from_id     UUID NOT NULL,
+  to_id       UUID NOT NULL,
+  transferred TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (item_id, transferred)
+);
+
+COMMIT;
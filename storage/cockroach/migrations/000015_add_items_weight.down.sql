@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE items DROP COLUMN weight;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE items ADD COLUMN weight INT CHECK (weight >= 0);
+
+COMMIT;
@@ -0,0 +1,78 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	cerrors "arcadium.dev/core/errors"
+	"arcadium.dev/core/log"
+
+	"arcadium.dev/arcade"
+)
+
+// ExportChecksumAlgorithm is the algorithm of the export checksum.
+const ExportChecksumAlgorithm = "sha256"
+
+type (
+	// Export is used to export the persistent storage.
+	Export struct {
+		DB     *sql.DB
+		Driver arcade.StorageDriver
+	}
+)
+
+// Checksum returns a checksum over the id, entity and updated time of every
+// entity, ordered by id. The rows are hashed as they are read, rather than
+// serialized, so the checksum of a large world is cheap to compute.
+func (p Export) Checksum(ctx context.Context) (arcade.ExportChecksum, error) {
+	failMsg := "failed to checksum export"
+
+	log.LoggerFromContext(ctx).Info("msg", "checksum export")
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.ExportChecksumQuery())
+	if err != nil {
+		return arcade.ExportChecksum{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	h := sha256.New()
+	entities := 0
+	for rows.Next() {
+		var (
+			id, entity string
+			updated    time.Time
+		)
+		if err := rows.Scan(&id, &entity, &updated); err != nil {
+			return arcade.ExportChecksum{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		fmt.Fprintf(h, "%s %s %s\n", id, entity, updated.UTC().Format(time.RFC3339Nano))
+		entities++
+	}
+	if err := rows.Err(); err != nil {
+		return arcade.ExportChecksum{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return arcade.ExportChecksum{
+		Algorithm: ExportChecksumAlgorithm,
+		Checksum:  hex.EncodeToString(h.Sum(nil)),
+		Entities:  entities,
+	}, nil
+}
@@ -0,0 +1,126 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"arcadium.dev/arcade/storage"
+	"arcadium.dev/arcade/storage/cockroach"
+)
+
+func TestExportChecksum(t *testing.T) {
+	const checksumQ = `^SELECT player_id, 'player', updated FROM players UNION ALL (.+) ORDER BY 1, 2$`
+
+	var (
+		playerID = "00000000-0000-0000-0000-000000000001"
+		roomID   = "00000000-0000-0000-0000-000000000002"
+		updated  = time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	newRows := func(roomUpdated time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "entity", "updated"}).
+			AddRow(playerID, "player", updated).
+			AddRow(roomID, "room", roomUpdated)
+	}
+
+	t.Run("query error", func(t *testing.T) {
+		e, mock := setupExport(t)
+		mock.ExpectQuery(checksumQ).WillReturnError(errors.New("query error"))
+
+		_, err := e.Checksum(context.Background())
+
+		expected := "failed to checksum export: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		e, mock := setupExport(t)
+		mock.ExpectQuery(checksumQ).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "entity", "updated"}).AddRow(playerID, "player", "bad time"),
+		)
+
+		_, err := e.Checksum(context.Background())
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		e, mock := setupExport(t)
+		mock.ExpectQuery(checksumQ).WillReturnRows(newRows(updated))
+		mock.ExpectQuery(checksumQ).WillReturnRows(newRows(updated.In(time.FixedZone("EST", -5*60*60))))
+
+		first, err := e.Checksum(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		second, err := e.Checksum(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if first != second {
+			t.Errorf("\nExpected checksum: %+v\nActual checksum:   %+v", first, second)
+		}
+		if first.Algorithm != storage.ExportChecksumAlgorithm || first.Entities != 2 || len(first.Checksum) != 64 {
+			t.Errorf("Unexpected checksum: %+v", first)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("changes after update", func(t *testing.T) {
+		e, mock := setupExport(t)
+		mock.ExpectQuery(checksumQ).WillReturnRows(newRows(updated))
+		mock.ExpectQuery(checksumQ).WillReturnRows(newRows(updated.Add(time.Microsecond)))
+
+		before, err := e.Checksum(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		after, err := e.Checksum(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if before.Checksum == after.Checksum {
+			t.Errorf("Expected the checksum to change: %s", after.Checksum)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func setupExport(t *testing.T) (storage.Export, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Failed to create sqlmock db")
+	}
+
+	return storage.Export{DB: db, Driver: cockroach.Driver{}}, mock
+}
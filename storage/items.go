@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -28,11 +29,46 @@ import (
 	"arcadium.dev/arcade"
 )
 
+// lastItemID is the greatest itemID, after which no item is updated at the
+// same time.
+const lastItemID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
 type (
 	// Items is used to manage the persistent storage of item assets.
 	Items struct {
 		DB     *sql.DB
 		Driver arcade.StorageDriver
+
+		// Names restricts the names of created and updated items.
+		Names arcade.NamePolicy
+
+		// Timeouts are the time limits of the item operations.
+		Timeouts Timeouts
+
+		// World limits the number of entities created items join.
+		World WorldLimit
+
+		// ValidateMarkdown, when set, rejects item descriptions containing
+		// markup which cannot be safely rendered.
+		ValidateMarkdown bool
+
+		// DefaultOwnerID, when set, is the owner of a created item when the
+		// request does not give one.
+		DefaultOwnerID uuid.UUID
+
+		// StrictLocations, when set, checks that the locationID of a created
+		// item is a room and its inventoryID is a player, rather than relying
+		// upon the foreign key constraints, at the cost of extra queries.
+		StrictLocations bool
+
+		// RequireInventoryOwner, when set, rejects a created or updated item
+		// in the inventory of a player other than its owner.
+		RequireInventoryOwner bool
+
+		// Users, when set, validates the owner of a created or updated item
+		// against the users service. Otherwise an unknown owner is only
+		// rejected by the foreign key constraint.
+		Users arcade.UsersClient
 	}
 )
 
@@ -40,10 +76,269 @@ type (
 func (p Items) List(ctx context.Context, filter arcade.ItemsFilter) ([]arcade.Item, error) {
 	failMsg := "failed to list items"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "list items")
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.ItemsListQuery(filter)), listArgs(filter.CreatedBy)...)
+}
+
+// GetMany returns the items of the given itemIDs which exist, in no particular
+// order.
+func (p Items) GetMany(ctx context.Context, itemIDs []string) ([]arcade.Item, error) {
+	failMsg := "failed to get items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("itemIDs", len(itemIDs)).Info("msg", "get items")
+
+	ids, err := parseIDs("item", itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.ItemsGetManyQuery()), uuidArray(ids))
+}
+
+// Search returns a slice of items matching the search filter, ordered by
+// relevance.
+func (p Items) Search(ctx context.Context, filter arcade.ItemsSearchFilter) ([]arcade.Item, error) {
+	failMsg := "failed to search items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("query", filter.Query).Info("msg", "search items")
+
+	return p.list(ctx, failMsg, p.Driver.ItemsSearchQuery(filter), filter.Query)
+}
+
+// ChangedSince returns up to limit items after the watermark of the given
+// time and itemID, in order of update then itemID.
+func (p Items) ChangedSince(ctx context.Context, since arcade.Timestamp, sinceID string, limit int) ([]arcade.Item, error) {
+	failMsg := "failed to list changed items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("since", since, "sinceID", sinceID).Info("msg", "list changed items")
+
+	// Without an itemID, every item updated at the time itself is before
+	// the watermark.
+	if sinceID == "" {
+		sinceID = lastItemID
+	}
+	if max := p.Driver.ListRowsCap(); limit <= 0 || limit > max {
+		limit = max
+	}
+	return p.list(ctx, failMsg, p.Driver.ItemsChangedSinceQuery(), since, sinceID, limit)
+}
+
+// TopOwners returns the number of items of up to limit owners, in descending
+// order of count.
+func (p Items) TopOwners(ctx context.Context, limit int) ([]arcade.OwnerCount, error) {
+	failMsg := "failed to count items by owner"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx)
-	logger.Info("msg", "list items")
+	logger.Info("msg", "count items by owner")
 
-	rows, err := p.DB.QueryContext(ctx, p.Driver.ItemsListQuery(filter))
+	rows, err := p.DB.QueryContext(ctx, p.Driver.ItemsTopOwnersQuery(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("msg", "failed to close rows of top owners query", "error", err.Error())
+		}
+	}()
+
+	counts := make([]arcade.OwnerCount, 0)
+	for rows.Next() {
+		var count arcade.OwnerCount
+		if err := rows.Scan(&count.OwnerID, &count.Count); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return counts, nil
+}
+
+// TotalWeight returns the total weight of the items in the inventory of the
+// given player. An item without a weight weighs nothing.
+func (p Items) TotalWeight(ctx context.Context, playerID string) (int, error) {
+	failMsg := "failed to total item weight"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("playerID", playerID).Info("msg", "total item weight")
+
+	pid, err := uuid.Parse(playerID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: invalid player id: '%s'", failMsg, cerrors.ErrInvalidArgument, playerID)
+	}
+
+	// The sum is null when the player carries no weighed items.
+	var weight sql.NullInt64
+	err = p.DB.QueryRowContext(ctx, p.Driver.ItemsTotalWeightQuery(), pid).Scan(&weight)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return int(weight.Int64), nil
+}
+
+// ListGroupedByLocation returns the number of items of the given owner in each
+// location. An item in the inventory of a player is counted there, rather
+// than in its room.
+func (p Items) ListGroupedByLocation(ctx context.Context, ownerID string) (map[arcade.ItemLocationID]int, error) {
+	failMsg := "failed to group items by location"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("ownerID", ownerID)
+	logger.Info("msg", "group items by location")
+
+	oid, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: invalid owner id: '%s'", failMsg, cerrors.ErrInvalidArgument, ownerID)
+	}
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.ItemsGroupedByLocationQuery(), oid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("msg", "failed to close rows of grouped by location query", "error", err.Error())
+		}
+	}()
+
+	counts := make(map[arcade.ItemLocationID]int)
+	for rows.Next() {
+		var (
+			location arcade.ItemLocationID
+			count    int
+		)
+		if err := rows.Scan(&location.Type, &location.ID, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		counts[location] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return counts, nil
+}
+
+// FindOrphans returns the items whose owner, location or inventory does not
+// exist, e.g. after the players or rooms table was edited by hand.
+func (p Items) FindOrphans(ctx context.Context) ([]arcade.Item, error) {
+	failMsg := "failed to find orphaned items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "find orphaned items")
+
+	return p.list(ctx, failMsg, p.Driver.ItemsOrphansQuery())
+}
+
+// FixOrphans resets the dangling references of the orphaned items as their
+// foreign keys would have on delete: the owner and location to their
+// defaults, and the inventory to null. It returns the items as they were
+// found.
+func (p Items) FixOrphans(ctx context.Context) ([]arcade.Item, error) {
+	failMsg := "failed to fix orphaned items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx)
+	logger.Info("msg", "fix orphaned items")
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback orphans fix", "error", err.Error())
+		}
+	}()
+
+	items, err := p.listWith(ctx, tx, failMsg, p.Driver.ItemsOrphansQuery())
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	for _, query := range []string{
+		p.Driver.ItemsFixOrphanedOwnersQuery(),
+		p.Driver.ItemsFixOrphanedLocationsQuery(),
+		p.Driver.ItemsFixOrphanedInventoriesQuery(),
+	} {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	logger.Info("msg", "fixed orphaned items", "count", len(items))
+	return items, nil
+}
+
+// Snapshot returns the current time of the database, as of which a list of
+// items may be read consistently across pages.
+func (p Items) Snapshot(ctx context.Context) (time.Time, error) {
+	failMsg := "failed to snapshot items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "snapshot items")
+
+	var asOf time.Time
+	if err := p.DB.QueryRowContext(ctx, p.Driver.SnapshotQuery()).Scan(&asOf); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return asOf, nil
+}
+
+func (p Items) list(ctx context.Context, failMsg, query string, args ...interface{}) ([]arcade.Item, error) {
+	return p.listWith(ctx, p.DB, failMsg, query, args...)
+}
+
+// queryer is either of a database or a transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// listWith lists the items selected by the query of the given database or
+// transaction.
+func (p Items) listWith(ctx context.Context, db queryer, failMsg, query string, args ...interface{}) ([]arcade.Item, error) {
+	logger := log.LoggerFromContext(ctx)
+
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
@@ -62,7 +357,9 @@ func (p Items) List(ctx context.Context, filter arcade.ItemsFilter) ([]arcade.It
 			&item.Description,
 			&item.OwnerID,
 			&item.LocationID,
-			&item.InventoryID,
+			nullString{&item.InventoryID},
+			nullInt{&item.Weight},
+			&item.CreatedBy,
 			&item.Created,
 			&item.Updated,
 		)
@@ -74,6 +371,9 @@ func (p Items) List(ctx context.Context, filter arcade.ItemsFilter) ([]arcade.It
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
+	if max := p.Driver.ListRowsCap(); len(items) >= max {
+		logger.Warn("msg", "list reached the maximum rows", "max", max)
+	}
 
 	return items, nil
 }
@@ -82,6 +382,9 @@ func (p Items) List(ctx context.Context, filter arcade.ItemsFilter) ([]arcade.It
 func (p Items) Get(ctx context.Context, itemID string) (arcade.Item, error) {
 	failMsg := "failed to get item"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("itemID", itemID).Info("msg", "get item")
 
 	pid, err := uuid.Parse(itemID)
@@ -90,13 +393,15 @@ func (p Items) Get(ctx context.Context, itemID string) (arcade.Item, error) {
 	}
 
 	var item arcade.Item
-	err = p.DB.QueryRowContext(ctx, p.Driver.ItemsGetQuery(), pid).Scan(
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.ItemsGetQuery()), pid).Scan(
 		&item.ID,
 		&item.Name,
 		&item.Description,
 		&item.OwnerID,
 		&item.LocationID,
-		&item.InventoryID,
+		nullString{&item.InventoryID},
+		nullInt{&item.Weight},
+		&item.CreatedBy,
 		&item.Created,
 		&item.Updated,
 	)
@@ -114,28 +419,67 @@ func (p Items) Get(ctx context.Context, itemID string) (arcade.Item, error) {
 func (p Items) Create(ctx context.Context, req arcade.ItemRequest) (arcade.Item, error) {
 	failMsg := "failed to create item"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("name", req.Name)
 	logger.Info("msg", "create item")
 
-	ownerID, locationID, inventoryID, err := req.Validate()
+	if req.OwnerID == "" && p.DefaultOwnerID != uuid.Nil {
+		req.OwnerID = p.DefaultOwnerID.String()
+	}
+	ownerID, locationID, inventoryID, err := p.validateCreate(req)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if p.StrictLocations {
+		if err := p.checkLocations(ctx, locationID, inventoryID); err != nil {
+			return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+		}
+	}
+	if err := p.checkUser(ctx, ownerID); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback create", "error", err.Error())
+		}
+	}()
+
+	full, err := p.inventoryFull(ctx, tx, inventoryID, uuid.Nil)
 	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if full {
+		return arcade.Item{}, fmt.Errorf("%s: %w: player inventory is at capacity: '%s'", failMsg, arcade.ErrConflict, req.InventoryID)
+	}
+	if err := p.World.check(ctx, tx, p.Driver, 1); err != nil {
 		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
 
 	var item arcade.Item
-	err = p.DB.QueryRowContext(ctx, p.Driver.ItemsCreateQuery(),
+	err = tx.QueryRowContext(ctx, p.Driver.ItemsCreateQuery(),
 		req.Name,
 		req.Description,
 		ownerID,
 		locationID,
 		inventoryID,
+		req.Weight,
+		arcade.ActorFromContext(ctx),
 	).Scan(
 		&item.ID,
 		&item.Name,
 		&item.Description,
 		&item.OwnerID,
 		&item.LocationID,
-		&item.InventoryID,
+		nullString{&item.InventoryID},
+		nullInt{&item.Weight},
+		&item.CreatedBy,
 		&item.Created,
 		&item.Updated,
 	)
@@ -160,14 +504,237 @@ func (p Items) Create(ctx context.Context, req arcade.ItemRequest) (arcade.Item,
 		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err.Error())
 	}
 
+	if err := tx.Commit(); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
 	logger.With("itemID", item.ID).Info("msg", "created item")
 	return item, nil
 }
 
+// validateCreate returns an error for an invalid create request, or the
+// parsed owner, location and inventory ids of a valid one.
+func (p Items) validateCreate(req arcade.ItemRequest) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	ownerID, locationID, inventoryID, err := req.Validate()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+	if p.ValidateMarkdown {
+		if err := arcade.ValidateMarkdown(req.Description); err != nil {
+			return uuid.Nil, uuid.Nil, uuid.Nil, err
+		}
+	}
+	if err := p.checkOwner(ownerID, inventoryID); err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+	return ownerID, locationID, inventoryID, nil
+}
+
+// checkOwner returns an invalid argument error if inventory owners are
+// required and the given item owner is not the player holding it.
+func (p Items) checkOwner(ownerID, inventoryID uuid.UUID) error {
+	if p.RequireInventoryOwner && ownerID != inventoryID {
+		return fmt.Errorf("%w: item on a player must be owned by that player", cerrors.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// checkUser returns an invalid argument error if the users service, when
+// set, does not know the given owner.
+func (p Items) checkUser(ctx context.Context, ownerID uuid.UUID) error {
+	if p.Users == nil {
+		return nil
+	}
+	exists, err := p.Users.UserExists(ctx, ownerID.String())
+	if err != nil {
+		return fmt.Errorf("%w: %s", cerrors.ErrInternal, err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: owner does not exist", cerrors.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Import creates an item from each of the given rows, returning the result of
+// each row. A row failing validation or insertion is reported, and the other
+// rows are imported in transactions of up to arcade.ItemsImportBatchSize
+// rows. When strict, no item is created unless all of them are, the rows are
+// imported in a single transaction.
+func (p Items) Import(ctx context.Context, rows []arcade.ItemImportRow, strict bool) ([]arcade.ItemImportResult, error) {
+	failMsg := "failed to import items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("rows", len(rows), "strict", strict)
+	logger.Info("msg", "import items")
+
+	results := make([]arcade.ItemImportResult, len(rows))
+	valid := make([]importRow, 0, len(rows))
+	for i, row := range rows {
+		results[i].Line = row.Line
+
+		req := row.Request
+		if req.OwnerID == "" && p.DefaultOwnerID != uuid.Nil {
+			req.OwnerID = p.DefaultOwnerID.String()
+		}
+		ownerID, locationID, inventoryID, err := p.validateCreate(req)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		if p.StrictLocations {
+			err := p.checkLocations(ctx, locationID, inventoryID)
+			if errors.Is(err, cerrors.ErrInvalidArgument) {
+				results[i].Error = err.Error()
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", failMsg, err)
+			}
+		}
+		err = p.checkUser(ctx, ownerID)
+		if errors.Is(err, cerrors.ErrInvalidArgument) {
+			results[i].Error = err.Error()
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", failMsg, err)
+		}
+		valid = append(valid, importRow{
+			req:         req,
+			inventoryID: inventoryID,
+			args:        []interface{}{req.Name, req.Description, ownerID, locationID, inventoryID, req.Weight, arcade.ActorFromContext(ctx)},
+			result:      &results[i],
+		})
+	}
+	if strict && len(valid) < len(rows) {
+		return results, nil
+	}
+	if len(valid) > 0 {
+		if err := p.World.check(ctx, p.DB, p.Driver, len(valid)); err != nil {
+			return nil, fmt.Errorf("%s: %w", failMsg, err)
+		}
+	}
+
+	batchSize := arcade.ItemsImportBatchSize
+	if strict {
+		batchSize = len(valid)
+	}
+	for start := 0; start < len(valid); start += batchSize {
+		end := start + batchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		if err := p.importBatch(ctx, valid[start:end], strict); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	return results, nil
+}
+
+// importRow is a valid row of an import, with the arguments of its insert.
+type importRow struct {
+	req         arcade.ItemRequest
+	inventoryID uuid.UUID
+	args        []interface{}
+	result      *arcade.ItemImportResult
+}
+
+// importBatch inserts the given rows in a transaction. A row failing to be
+// inserted is rolled back to its savepoint, unless strict, when the whole
+// transaction is.
+func (p Items) importBatch(ctx context.Context, rows []importRow, strict bool) error {
+	logger := log.LoggerFromContext(ctx)
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback import", "error", err.Error())
+		}
+	}()
+
+	imported := make(map[*arcade.ItemImportResult]string, len(rows))
+	for _, row := range rows {
+		if !strict {
+			if _, err := tx.ExecContext(ctx, p.Driver.ItemsImportSavepointQuery()); err != nil {
+				return err
+			}
+		}
+
+		var item arcade.Item
+		full, err := p.inventoryFull(ctx, tx, row.inventoryID, uuid.Nil)
+		if err == nil && full {
+			err = fmt.Errorf("%w: player inventory is at capacity: '%s'", arcade.ErrConflict, row.req.InventoryID)
+		}
+		if err == nil {
+			err = tx.QueryRowContext(ctx, p.Driver.ItemsCreateQuery(), row.args...).Scan(
+				&item.ID,
+				&item.Name,
+				&item.Description,
+				&item.OwnerID,
+				&item.LocationID,
+				nullString{&item.InventoryID},
+				nullInt{&item.Weight},
+				&item.CreatedBy,
+				&item.Created,
+				&item.Updated,
+			)
+		}
+		switch {
+		case errors.Is(err, arcade.ErrConflict):
+			row.result.Error = err.Error()
+		case p.Driver.IsForeignKeyViolation(err):
+			row.result.Error = fmt.Errorf(
+				"%w: the given ownerID, locationID, or inventoryID does not exist: ownerID '%s', locationID '%s', inventoryID '%s'",
+				cerrors.ErrInvalidArgument, row.req.OwnerID, row.req.LocationID, row.req.InventoryID,
+			).Error()
+		case p.Driver.IsUniqueViolation(err):
+			row.result.Error = fmt.Errorf("%w: item already exists", cerrors.ErrAlreadyExists).Error()
+		case err != nil:
+			row.result.Error = fmt.Errorf("%w: %s", cerrors.ErrInternal, err).Error()
+		}
+
+		if err != nil {
+			if strict {
+				return nil
+			}
+			if _, err := tx.ExecContext(ctx, p.Driver.ItemsImportRollbackQuery()); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strict {
+			if _, err := tx.ExecContext(ctx, p.Driver.ItemsImportReleaseQuery()); err != nil {
+				return err
+			}
+		}
+		imported[row.result] = item.ID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for result, itemID := range imported {
+		result.Imported, result.ItemID = true, itemID
+	}
+	return nil
+}
+
 // Update a item given the item request, returning the updated item.
 func (p Items) Update(ctx context.Context, itemID string, req arcade.ItemRequest) (arcade.Item, error) {
 	failMsg := "failed to update item"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("itemID", itemID, "name", req.Name)
 	logger.Info("msg", "update item")
 
@@ -179,22 +746,57 @@ func (p Items) Update(ctx context.Context, itemID string, req arcade.ItemRequest
 	if err != nil {
 		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if p.ValidateMarkdown {
+		if err := arcade.ValidateMarkdown(req.Description); err != nil {
+			return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+		}
+	}
+	if err := p.checkOwner(ownerID, inventoryID); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.checkUser(ctx, ownerID); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback update", "error", err.Error())
+		}
+	}()
+
+	full, err := p.inventoryFull(ctx, tx, inventoryID, pid)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if full {
+		return arcade.Item{}, fmt.Errorf("%s: %w: player inventory is at capacity: '%s'", failMsg, arcade.ErrConflict, req.InventoryID)
+	}
 
 	var item arcade.Item
-	err = p.DB.QueryRowContext(ctx, p.Driver.ItemsUpdateQuery(),
+	err = tx.QueryRowContext(ctx, p.Driver.ItemsUpdateQuery(),
 		pid,
 		req.Name,
 		req.Description,
 		ownerID,
 		locationID,
 		inventoryID,
+		req.Weight,
 	).Scan(
 		&item.ID,
 		&item.Name,
 		&item.Description,
 		&item.OwnerID,
 		&item.LocationID,
-		&item.InventoryID,
+		nullString{&item.InventoryID},
+		nullInt{&item.Weight},
+		&item.CreatedBy,
 		&item.Created,
 		&item.Updated,
 	)
@@ -223,13 +825,38 @@ func (p Items) Update(ctx context.Context, itemID string, req arcade.ItemRequest
 		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err.Error())
 	}
 
+	if err := tx.Commit(); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
 	return item, nil
 }
 
+// inventoryFull returns whether the inventory of the given player is at its
+// capacity, and does not already hold the given item. The player is locked
+// until the end of the transaction, so concurrent placements cannot overfill
+// it. A player that does not exist is reported by the foreign key violation
+// of the insert or update.
+func (p Items) inventoryFull(ctx context.Context, tx *sql.Tx, inventoryID, itemID uuid.UUID) (bool, error) {
+	var capacity, count int
+	var held bool
+	err := tx.QueryRowContext(ctx, p.Driver.ItemsInventoryOccupancyQuery(), inventoryID, itemID).Scan(&capacity, &count, &held)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return capacity > 0 && !held && count >= capacity, nil
+}
+
 // Remove deletes the given item from persistent storage.
 func (p Items) Remove(ctx context.Context, itemID string) error {
 	failMsg := "failed to remove item"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpRemove)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("itemID", itemID).Info("msg", "remove item")
 
 	pid, err := uuid.Parse(itemID)
@@ -247,3 +874,235 @@ func (p Items) Remove(ctx context.Context, itemID string) error {
 
 	return nil
 }
+
+// RemoveMany deletes the given items from persistent storage, returning the
+// number removed. Duplicate ids are removed once, and items which do not
+// exist are skipped.
+func (p Items) RemoveMany(ctx context.Context, itemIDs []string) (int, error) {
+	failMsg := "failed to remove items"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpRemove)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("itemIDs", len(itemIDs)).Info("msg", "remove items")
+
+	ids, err := arcade.ItemsRemoveRequest{ItemIDs: itemIDs}.Validate()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	result, err := p.DB.ExecContext(ctx, p.Driver.ItemsRemoveManyQuery(), uuidArray(ids))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return int(count), nil
+}
+
+// Exists returns true if the given item exists, without reading the item.
+func (p Items) Exists(ctx context.Context, itemID string) (bool, error) {
+	failMsg := "failed to check item exists"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	pid, err := uuid.Parse(itemID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w: invalid item id: '%s'", failMsg, cerrors.ErrInvalidArgument, itemID)
+	}
+
+	var exists bool
+	err = p.DB.QueryRowContext(ctx, p.Driver.ItemsExistsQuery(), pid).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return exists, nil
+}
+
+// checkLocations returns an invalid argument error if the given locationID
+// is not a room, or the given inventoryID is not a player.
+func (p Items) checkLocations(ctx context.Context, locationID, inventoryID uuid.UUID) error {
+	checks := []struct {
+		query string
+		field string
+		id    uuid.UUID
+		kind  string
+	}{
+		{p.Driver.RoomsExistsQuery(), "locationID", locationID, "room"},
+		{p.Driver.PlayersExistsQuery(), "inventoryID", inventoryID, "player"},
+	}
+	for _, c := range checks {
+		var exists bool
+		if err := p.DB.QueryRowContext(ctx, c.query, c.id).Scan(&exists); err != nil {
+			return fmt.Errorf("%w: %s", cerrors.ErrInternal, err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: %s %s is not a %s", cerrors.ErrInvalidArgument, c.field, c.id, c.kind)
+		}
+	}
+	return nil
+}
+
+// Transfer reassigns the given item to the given owner, recording the
+// transfer from its previous owner, in a single transaction. Transferring an
+// item to its current owner is rejected.
+func (p Items) Transfer(ctx context.Context, itemID, ownerID string) (arcade.Item, error) {
+	failMsg := "failed to transfer item"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("itemID", itemID, "ownerID", ownerID)
+	logger.Info("msg", "transfer item")
+
+	iid, err := uuid.Parse(itemID)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: invalid item id: '%s'", failMsg, cerrors.ErrInvalidArgument, itemID)
+	}
+	oid, err := uuid.Parse(ownerID)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: invalid ownerID: '%s'", failMsg, cerrors.ErrInvalidArgument, ownerID)
+	}
+	if err := p.checkUser(ctx, oid); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback transfer", "error", err.Error())
+		}
+	}()
+
+	var (
+		previousID  string
+		inventoryID sql.NullString
+	)
+	err = tx.QueryRowContext(ctx, p.Driver.ItemsOwnerQuery(), iid).Scan(&previousID, &inventoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.Item{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if previousID == oid.String() {
+		return arcade.Item{}, fmt.Errorf("%s: %w: item is already owned by '%s'", failMsg, cerrors.ErrInvalidArgument, ownerID)
+	}
+	// An item held by a player may only be transferred to that player when
+	// inventory owners are required.
+	if p.RequireInventoryOwner && inventoryID.Valid && inventoryID.String != oid.String() {
+		return arcade.Item{}, fmt.Errorf("%s: %w: item on a player must be owned by that player", failMsg, cerrors.ErrInvalidArgument)
+	}
+
+	var item arcade.Item
+	err = tx.QueryRowContext(ctx, p.Driver.ItemsSetOwnerQuery(), iid, oid).Scan(
+		&item.ID,
+		&item.Name,
+		&item.Description,
+		&item.OwnerID,
+		&item.LocationID,
+		nullString{&item.InventoryID},
+		nullInt{&item.Weight},
+		&item.CreatedBy,
+		&item.Created,
+		&item.Updated,
+	)
+
+	// A ForeignKeyViolation means the given owner is not a player, thus we
+	// will return an invalid argument error.
+	if p.Driver.IsForeignKeyViolation(err) {
+		return arcade.Item{}, fmt.Errorf(
+			"%s: %w: the given ownerID does not exist: '%s'", failMsg, cerrors.ErrInvalidArgument, ownerID,
+		)
+	}
+	if err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, p.Driver.ItemsRecordTransferQuery(), iid, previousID, oid); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return arcade.Item{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	logger.With("previousID", previousID).Info("msg", "transferred item")
+	return item, nil
+}
+
+// SwapLocations exchanges the locations of the two given items atomically,
+// in a single transaction. If either item does not exist, neither is moved.
+func (p Items) SwapLocations(ctx context.Context, itemID, otherID string) error {
+	failMsg := "failed to swap item locations"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("itemID", itemID, "otherID", otherID)
+	logger.Info("msg", "swap item locations")
+
+	aid, bid, err := arcade.ItemsSwapRequest{ItemIDs: []string{itemID, otherID}}.Validate()
+	if err != nil {
+		return fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback swap", "error", err.Error())
+		}
+	}()
+
+	// An item outside of an inventory has a null inventoryID, which is
+	// written back as null.
+	type location struct {
+		locationID, inventoryID sql.NullString
+	}
+	var a, b location
+	for _, l := range []struct {
+		id  uuid.UUID
+		loc *location
+	}{{aid, &a}, {bid, &b}} {
+		err := tx.QueryRowContext(ctx, p.Driver.ItemsLocationQuery(), l.id).Scan(&l.loc.locationID, &l.loc.inventoryID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w: item '%s'", failMsg, cerrors.ErrNotFound, l.id)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	for _, u := range []struct {
+		id  uuid.UUID
+		loc location
+	}{{aid, b}, {bid, a}} {
+		result, err := tx.ExecContext(ctx, p.Driver.ItemsSetLocationQuery(), u.id, u.loc.locationID, u.loc.inventoryID)
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		if n == 0 {
+			return fmt.Errorf("%s: %w: item '%s'", failMsg, cerrors.ErrNotFound, u.id)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return nil
+}
@@ -33,7 +33,7 @@ import (
 
 func TestItemsList(t *testing.T) {
 	const (
-		listQ = "^SELECT item_id, name, description, owner_id, location_id, inventory_id, created, updated FROM items$"
+		listQ = "^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ORDER BY created ASC LIMIT 10000$"
 	)
 
 	var (
@@ -69,9 +69,9 @@ func TestItemsList(t *testing.T) {
 
 	t.Run("sql scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated",
+			"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated",
 		}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		l, mock := setupItems(t)
@@ -95,8 +95,8 @@ func TestItemsList(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
 
 		l, mock := setupItems(t)
 		mock.ExpectQuery(listQ).
@@ -126,65 +126,108 @@ func TestItemsList(t *testing.T) {
 	})
 }
 
-func TestItemsGet(t *testing.T) {
-	const (
-		getQ = "^SELECT item_id, name, description, owner_id, location_id, inventory_id, created, updated FROM items WHERE item_id = (.+)$"
-	)
-
-	var (
-		id          = uuid.NewString()
-		name        = "Nobody"
-		description = "No one of importance."
-		ownerID     = uuid.NewString()
-		locationID  = uuid.NewString()
-		inventoryID = uuid.NewString()
-		created     = time.Now()
-		updated     = time.Now()
-	)
+func TestItemsSnapshot(t *testing.T) {
+	const snapshotQ = `^SELECT now\(\)$`
 
-	t.Run("invalid itemID", func(t *testing.T) {
-		l, _ := setupItems(t)
+	t.Run("sql query error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(snapshotQ).
+			WillReturnError(errors.New("unknown error"))
 
-		_, err := l.Get(context.Background(), "42")
+		_, err := l.Snapshot(context.Background())
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to get item: invalid argument: invalid item id: '42'"
+		expected := "failed to snapshot items: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
 	})
 
-	t.Run("not found", func(t *testing.T) {
+	t.Run("stable pages", func(t *testing.T) {
+		var (
+			asOf    = time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+			columns = []string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}
+			first   = uuid.NewString()
+			second  = uuid.NewString()
+			listQ   = func(page string) string {
+				return "^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items " +
+					"AS OF SYSTEM TIME '2026-10-16 10:30:00\\+00:00' ORDER BY created ASC LIMIT 1" + page + "$"
+			}
+		)
+
 		l, mock := setupItems(t)
-		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(snapshotQ).
+			WillReturnRows(sqlmock.NewRows([]string{"now"}).AddRow(asOf))
+		mock.ExpectQuery(listQ("")).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(first, "first", "", "", "", "", 0, arcade.DefaultActor, asOf, asOf)).
+			RowsWillBeClosed()
+		mock.ExpectQuery(listQ(" OFFSET 1")).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(second, "second", "", "", "", "", 0, arcade.DefaultActor, asOf, asOf)).
+			RowsWillBeClosed()
 
-		_, err := l.Get(context.Background(), id)
+		snapshot, err := l.Snapshot(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !snapshot.Equal(asOf) {
+			t.Fatalf("Unexpected snapshot: %s", snapshot)
+		}
 
-		if err == nil {
-			t.Fatal("Expected an error")
+		var ids []string
+		for offset := 0; offset < 2; offset++ {
+			items, err := l.List(context.Background(), arcade.ItemsFilter{AsOf: &snapshot, Limit: 1, Offset: offset})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
 		}
-		expected := "failed to get item: not found"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		if len(ids) != 2 || ids[0] != first || ids[1] != second {
+			t.Errorf("Unexpected items: %v", ids)
 		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+}
 
-	t.Run("unknown error", func(t *testing.T) {
+func TestItemsSearch(t *testing.T) {
+	const (
+		searchQ = `^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ` +
+			`WHERE to_tsvector\('english', name \|\| ' ' \|\| description\) @@ plainto_tsquery\('english', \$1\) ` +
+			`ORDER BY ts_rank\(to_tsvector\('english', name \|\| ' ' \|\| description\), plainto_tsquery\('english', \$1\)\) DESC, item_id ` +
+			`LIMIT 10$`
+	)
+
+	var (
+		query   = "sword"
+		filter  = arcade.ItemsSearchFilter{Query: query, Limit: 10}
+		ids     = []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+		ownerID = uuid.NewString()
+		created = time.Now()
+		updated = time.Now()
+	)
+
+	t.Run("sql query error", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(errors.New("unknown error"))
+		mock.ExpectQuery(searchQ).
+			WithArgs(query).
+			WillReturnError(errors.New("unknown error"))
 
-		_, err := l.Get(context.Background(), id)
+		_, err := l.Search(context.Background(), filter)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to get item: internal error: unknown error"
+		expected := "failed to search items: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
@@ -195,24 +238,30 @@ func TestItemsGet(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
+		// Rows are returned by the database in order of relevance.
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(ids[0], "Sword", "A sword, sword.", ownerID, ownerID, ownerID, 0, arcade.DefaultActor, created, updated).
+			AddRow(ids[1], "Sword", "A plain blade.", ownerID, ownerID, ownerID, 0, arcade.DefaultActor, created, updated).
+			AddRow(ids[2], "Dagger", "Not quite a sword.", ownerID, ownerID, ownerID, 0, arcade.DefaultActor, created, updated)
 
 		l, mock := setupItems(t)
-		mock.ExpectQuery(getQ).WillReturnRows(rows)
+		mock.ExpectQuery(searchQ).
+			WithArgs(query).
+			WillReturnRows(rows).
+			RowsWillBeClosed()
 
-		item, err := l.Get(context.Background(), id)
+		items, err := l.Search(context.Background(), filter)
 
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err)
 		}
-		if item.ID != id ||
-			item.Name != name ||
-			item.Description != description ||
-			item.OwnerID != ownerID ||
-			item.LocationID != locationID ||
-			item.InventoryID != inventoryID {
-			t.Errorf("\nExpected item: %+v", item)
+		if len(items) != len(ids) {
+			t.Fatalf("Unexpected length of item list")
+		}
+		for i := range ids {
+			if items[i].ID != ids[i] {
+				t.Errorf("Unexpected item order: %d: %s", i, items[i].ID)
+			}
 		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
@@ -221,163 +270,156 @@ func TestItemsGet(t *testing.T) {
 	})
 }
 
-func TestItemsCreate(t *testing.T) {
+func TestItemsChangedSince(t *testing.T) {
 	const (
-		createQ = `^INSERT INTO items \(name, description, owner_id, location_id, inventory_id\) ` +
-			`VALUES \((.+), (.+), (.+), (.+)\) ` +
-			`RETURNING item_id, name, description, owner_id, location_id, inventory_id, created, updated$`
+		changesQ = `^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items ` +
+			`WHERE \(updated, item_id\) > \(\$1, \$2\) ORDER BY updated ASC, item_id ASC LIMIT \$3$`
 	)
 
 	var (
-		id          = uuid.NewString()
-		name        = "Nobody"
-		description = "No one of importance."
-		ownerID     = "00000000-0000-0000-0000-000000000001"
-		locationID  = "00000000-0000-0000-0000-000000000001"
-		inventoryID = "00000000-0000-0000-0000-000000000001"
-		created     = time.Now()
-		updated     = time.Now()
+		since   = time.Date(2022, time.October, 16, 16, 0, 0, 0, time.UTC)
+		ids     = []string{uuid.NewString(), uuid.NewString()}
+		ownerID = uuid.NewString()
 	)
 
-	t.Run("empty name", func(t *testing.T) {
-		req := arcade.ItemRequest{Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-
-		l, _ := setupItems(t)
+	t.Run("sql query error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(changesQ).
+			WithArgs(since, ids[0], 10).
+			WillReturnError(errors.New("unknown error"))
 
-		_, err := l.Create(context.Background(), req)
+		_, err := l.ChangedSince(context.Background(), arcade.Timestamp{Time: since}, ids[0], 10)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to create item: invalid argument: empty item name"
+		expected := "failed to list changed items: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
-	})
-
-	t.Run("long name", func(t *testing.T) {
-		n := ""
-		for i := 0; i <= arcade.MaxItemNameLen; i++ {
-			n += "a"
-		}
-		req := arcade.ItemRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-
-		l, _ := setupItems(t)
-
-		_, err := l.Create(context.Background(), req)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to create item: invalid argument: item name exceeds maximum length"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
 		}
 	})
 
-	t.Run("empty description", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+	t.Run("success", func(t *testing.T) {
+		// Rows are returned by the database in order of update.
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(ids[0], "Sword", "A sword.", ownerID, ownerID, ownerID, 0, arcade.DefaultActor, since, since.Add(time.Second)).
+			AddRow(ids[1], "Dagger", "A dagger.", ownerID, ownerID, ownerID, 0, arcade.DefaultActor, since, since.Add(time.Minute))
 
-		l, _ := setupItems(t)
+		l, mock := setupItems(t)
+		mock.ExpectQuery(changesQ).
+			WithArgs(since, "ffffffff-ffff-ffff-ffff-ffffffffffff", cockroach.DefaultMaxListRows).
+			WillReturnRows(rows).
+			RowsWillBeClosed()
 
-		_, err := l.Create(context.Background(), req)
+		// Without an itemID, the watermark follows every item updated at
+		// the time itself.
+		items, err := l.ChangedSince(context.Background(), arcade.Timestamp{Time: since}, "", 0)
 
-		if err == nil {
-			t.Fatal("Expected an error")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
 		}
-		expected := "failed to create item: invalid argument: empty item description"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		if len(items) != len(ids) {
+			t.Fatalf("Unexpected length of item list")
 		}
-	})
-
-	t.Run("long description", func(t *testing.T) {
-		d := ""
-		for i := 0; i <= arcade.MaxItemDescriptionLen; i++ {
-			d += "a"
+		for i := range ids {
+			if items[i].ID != ids[i] {
+				t.Errorf("Unexpected item order: %d: %s", i, items[i].ID)
+			}
 		}
-		req := arcade.ItemRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
 
-		l, _ := setupItems(t)
-
-		_, err := l.Create(context.Background(), req)
-
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to create item: invalid argument: item description exceeds maximum length"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+}
 
-	t.Run("invalid ownerID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, InventoryID: inventoryID}
+func TestItemsTopOwners(t *testing.T) {
+	const (
+		topOwnersQ = `^SELECT owner_id, count\(\*\) FROM items WHERE owner_id IS NOT NULL ` +
+			`GROUP BY owner_id ORDER BY count\(\*\) DESC, owner_id LIMIT \$1$`
+	)
 
-		l, _ := setupItems(t)
+	ownerIDs := []string{uuid.NewString(), uuid.NewString()}
 
-		_, err := l.Create(context.Background(), req)
+	t.Run("sql query error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(topOwnersQ).
+			WithArgs(10).
+			WillReturnError(errors.New("unknown error"))
+
+		_, err := l.TopOwners(context.Background(), 10)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to create item: invalid argument: invalid ownerID: '42'"
+		expected := "failed to count items by owner: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
 	})
 
-	t.Run("invalid locationID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", InventoryID: inventoryID}
+	t.Run("success", func(t *testing.T) {
+		// Rows are grouped and ordered by the database.
+		rows := sqlmock.NewRows([]string{"owner_id", "count"}).
+			AddRow(ownerIDs[0], 7).
+			AddRow(ownerIDs[1], 3)
 
-		l, _ := setupItems(t)
+		l, mock := setupItems(t)
+		mock.ExpectQuery(topOwnersQ).
+			WithArgs(10).
+			WillReturnRows(rows).
+			RowsWillBeClosed()
 
-		_, err := l.Create(context.Background(), req)
+		counts, err := l.TopOwners(context.Background(), 10)
 
-		if err == nil {
-			t.Fatal("Expected an error")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
 		}
-		expected := "failed to create item: invalid argument: invalid locationID: '42'"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		expected := []arcade.OwnerCount{{OwnerID: ownerIDs[0], Count: 7}, {OwnerID: ownerIDs[1], Count: 3}}
+		if len(counts) != len(expected) || counts[0] != expected[0] || counts[1] != expected[1] {
+			t.Errorf("Unexpected counts: %+v", counts)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+}
 
-	t.Run("invalid inventoryID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: "42"}
+func TestItemsTotalWeight(t *testing.T) {
+	const (
+		weightQ = `^SELECT \(SELECT sum\(weight\)::INT8 FROM items WHERE inventory_id = \$1\) FROM players WHERE player_id = \$1$`
+	)
 
+	playerID := uuid.NewString()
+
+	t.Run("invalid player id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		_, err := l.Create(context.Background(), req)
+		_, err := l.TotalWeight(context.Background(), "42")
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to create item: invalid argument: invalid inventoryID: '42'"
-		if err.Error() != expected {
+		expected := "failed to total item weight: invalid argument: invalid player id: '42'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
-
+	t.Run("player not found", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row).
-			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectQuery(weightQ).WithArgs(playerID).WillReturnError(sql.ErrNoRows)
 
-		_, err := l.Create(context.Background(), req)
+		_, err := l.TotalWeight(context.Background(), playerID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to create item: invalid argument: the given ownerID, locationID, or inventoryID does not exist: " +
-			"ownerID '00000000-0000-0000-0000-000000000001', locationID '00000000-0000-0000-0000-000000000001', inventoryID '00000000-0000-0000-0000-000000000001'"
-		if err.Error() != expected {
+		expected := "failed to total item weight: not found"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 
@@ -386,258 +428,1857 @@ func TestItemsCreate(t *testing.T) {
 		}
 	})
 
-	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
+	for _, test := range []struct {
+		name     string
+		sum      interface{}
+		expected int
+	}{
+		{"sum", int64(17), 17},
+		{"null sum", nil, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			l, mock := setupItems(t)
+			mock.ExpectQuery(weightQ).WithArgs(playerID).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(test.sum))
+
+			weight, err := l.TotalWeight(context.Background(), playerID)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if weight != test.expected {
+				t.Errorf("\nExpected weight: %d\nActual weight:   %d", test.expected, weight)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	}
+}
 
-		l, mock := setupItems(t)
-		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row).
-			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+func TestItemsListGroupedByLocation(t *testing.T) {
+	const groupedQ = `^SELECT CASE WHEN inventory_id IS NULL THEN 'room' ELSE 'player' END, ` +
+		`COALESCE\(inventory_id, location_id\), count\(\*\) FROM items ` +
+		`WHERE owner_id = \$1 AND \(inventory_id IS NOT NULL OR location_id IS NOT NULL\) GROUP BY 1, 2$`
 
-		_, err := l.Create(context.Background(), req)
+	var (
+		ownerID = uuid.NewString()
+		roomID  = uuid.NewString()
+		otherID = uuid.NewString()
+	)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to create item: already exists: item already exists"
-		if err.Error() != expected {
+	t.Run("invalid ownerID", func(t *testing.T) {
+		i, _ := setupItems(t)
+
+		_, err := i.ListGroupedByLocation(context.Background(), "42")
+
+		expected := "failed to group items by location: invalid argument: invalid owner id: '42'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+	})
 
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("Unexpected err: %s", err)
+	t.Run("query error", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectQuery(groupedQ).WithArgs(ownerID).WillReturnError(errors.New("query error"))
+
+		_, err := i.ListGroupedByLocation(context.Background(), ownerID)
+
+		expected := "failed to group items by location: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated).
-			RowError(0, errors.New("scan error"))
-
-		l, mock := setupItems(t)
-		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row)
+		i, mock := setupItems(t)
+		mock.ExpectQuery(groupedQ).WithArgs(ownerID).WillReturnRows(
+			sqlmock.NewRows([]string{"type", "location_id", "count"}).AddRow("room", roomID, "many"),
+		)
 
-		_, err := l.Create(context.Background(), req)
+		_, err := i.ListGroupedByLocation(context.Background(), ownerID)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to create item: internal error: scan error"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
-		}
-
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("Unexpected err: %s", err)
-		}
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
-
-		l, mock := setupItems(t)
-		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row)
+		i, mock := setupItems(t)
+		mock.ExpectQuery(groupedQ).WithArgs(ownerID).WillReturnRows(
+			sqlmock.NewRows([]string{"type", "location_id", "count"}).
+				AddRow("room", roomID, 2).
+				AddRow("player", ownerID, 3).
+				AddRow("room", otherID, 1),
+		)
 
-		item, err := l.Create(context.Background(), req)
+		grouped, err := i.ListGroupedByLocation(context.Background(), ownerID)
 
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err)
 		}
-		if item.ID != id ||
-			item.Name != name ||
-			item.Description != description ||
-			item.OwnerID != ownerID ||
-			item.LocationID != locationID ||
-			item.InventoryID != inventoryID {
-			t.Errorf("\nExpected item: %+v", item)
+		expected := map[arcade.ItemLocationID]int{
+			{Type: arcade.ItemLocationRoom, ID: roomID}:    2,
+			{Type: arcade.ItemLocationPlayer, ID: ownerID}: 3,
+			{Type: arcade.ItemLocationRoom, ID: otherID}:   1,
+		}
+		if len(grouped) != len(expected) {
+			t.Fatalf("Unexpected grouped counts: %+v", grouped)
+		}
+		for location, count := range expected {
+			if grouped[location] != count {
+				t.Errorf("Unexpected count of %+v: %d", location, grouped[location])
+			}
 		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
-}
+
+	t.Run("none", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectQuery(groupedQ).WithArgs(ownerID).WillReturnRows(sqlmock.NewRows([]string{"type", "location_id", "count"}))
+
+		grouped, err := i.ListGroupedByLocation(context.Background(), ownerID)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if grouped == nil || len(grouped) != 0 {
+			t.Errorf("Unexpected grouped counts: %+v", grouped)
+		}
+	})
+}
+
+func TestItemsFindOrphans(t *testing.T) {
+	const orphansQ = `^SELECT (.+) FROM items ` +
+		`WHERE NOT EXISTS \(SELECT 1 FROM players WHERE players.player_id = items.owner_id\) ` +
+		`OR \(items.location_id IS NOT NULL AND NOT EXISTS \(SELECT 1 FROM rooms WHERE rooms.room_id = items.location_id\)\) ` +
+		`OR \(items.inventory_id IS NOT NULL AND NOT EXISTS \(SELECT 1 FROM players WHERE players.player_id = items.inventory_id\)\) ` +
+		`ORDER BY created ASC LIMIT 10000$`
+
+	var (
+		columns     = []string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}
+		itemID      = uuid.NewString()
+		missingID   = uuid.NewString()
+		locationID  = uuid.NewString()
+		inventoryID = uuid.NewString()
+		created     = time.Now()
+	)
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(orphansQ).WillReturnError(errors.New("query error"))
+
+		_, err := l.FindOrphans(context.Background())
+
+		expected := "failed to find orphaned items: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("missing owner", func(t *testing.T) {
+		l, mock := setupItems(t)
+		rows := sqlmock.NewRows(columns).
+			AddRow(itemID, "Lamp", "A lamp.", missingID, locationID, inventoryID, 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(orphansQ).WillReturnRows(rows)
+
+		items, err := l.FindOrphans(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(items) != 1 || items[0].ID != itemID || items[0].OwnerID != missingID {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("none orphaned", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(orphansQ).WillReturnRows(sqlmock.NewRows(columns))
+
+		items, err := l.FindOrphans(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if items == nil || len(items) != 0 {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsFixOrphans(t *testing.T) {
+	const (
+		orphansQ        = `^SELECT (.+) FROM items WHERE NOT EXISTS (.+) ORDER BY created ASC LIMIT 10000$`
+		fixOwnersQ      = `^UPDATE items SET owner_id = DEFAULT, updated = now\(\) WHERE NOT EXISTS \(SELECT 1 FROM players WHERE players.player_id = items.owner_id\)$`
+		fixLocationsQ   = `^UPDATE items SET location_id = DEFAULT, updated = now\(\) WHERE \(items.location_id IS NOT NULL AND (.+)\)$`
+		fixInventoriesQ = `^UPDATE items SET inventory_id = NULL, updated = now\(\) WHERE \(items.inventory_id IS NOT NULL AND (.+)\)$`
+	)
+
+	var (
+		columns     = []string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}
+		itemID      = uuid.NewString()
+		ownerID     = uuid.NewString()
+		missingID   = uuid.NewString()
+		locationID  = uuid.NewString()
+		inventoryID = uuid.NewString()
+		created     = time.Now()
+	)
+
+	t.Run("fix", func(t *testing.T) {
+		l, mock := setupItems(t)
+		rows := sqlmock.NewRows(columns).
+			AddRow(itemID, "Lamp", "A lamp.", missingID, locationID, inventoryID, 0, arcade.DefaultActor, created, created)
+		mock.ExpectBegin()
+		mock.ExpectQuery(orphansQ).WillReturnRows(rows)
+		mock.ExpectExec(fixOwnersQ).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(fixLocationsQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(fixInventoriesQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		items, err := l.FixOrphans(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(items) != 1 || items[0].ID != itemID || items[0].OwnerID != missingID {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("list after fix", func(t *testing.T) {
+		const listQ = `^SELECT item_id, (.+) FROM items ORDER BY created ASC LIMIT 10000$`
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(orphansQ).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(itemID, "Lamp", "A lamp.", ownerID, locationID, missingID, 0, arcade.DefaultActor, created, created))
+		mock.ExpectExec(fixOwnersQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(fixLocationsQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(fixInventoriesQ).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(listQ).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(itemID, "Lamp", "A lamp.", ownerID, locationID, nil, 0, arcade.DefaultActor, created, created))
+
+		if _, err := l.FixOrphans(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		items, err := l.List(context.Background(), arcade.ItemsFilter{})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(items) != 1 || items[0].ID != itemID || items[0].InventoryID != "" {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("none orphaned", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(orphansQ).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		items, err := l.FixOrphans(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if items == nil || len(items) != 0 {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		rows := sqlmock.NewRows(columns).
+			AddRow(itemID, "Lamp", "A lamp.", missingID, locationID, inventoryID, 0, arcade.DefaultActor, created, created)
+		mock.ExpectBegin()
+		mock.ExpectQuery(orphansQ).WillReturnRows(rows)
+		mock.ExpectExec(fixOwnersQ).WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
+
+		_, err := l.FixOrphans(context.Background())
+
+		expected := "failed to fix orphaned items: internal error: update error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsGetMany(t *testing.T) {
+	const getManyQ = `^SELECT (.+) FROM items WHERE item_id = ANY\(\$1\)$`
+
+	var (
+		id      = uuid.NewString()
+		otherID = uuid.NewString()
+		created = time.Now()
+	)
+
+	t.Run("invalid itemID", func(t *testing.T) {
+		l, _ := setupItems(t)
+
+		_, err := l.GetMany(context.Background(), []string{id, "42"})
+
+		expected := "failed to get items: invalid argument: invalid item id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "}").WillReturnError(errors.New("query error"))
+
+		_, err := l.GetMany(context.Background(), []string{id})
+
+		expected := "failed to get items: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupItems(t)
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, "Sword", "A sword.", uuid.NewString(), uuid.NewString(), uuid.NewString(), 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "," + otherID + "}").WillReturnRows(rows)
+
+		items, err := l.GetMany(context.Background(), []string{id, otherID})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(items) != 1 || items[0].ID != id {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsGet(t *testing.T) {
+	const (
+		getQ = "^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items WHERE item_id = (.+)$"
+	)
+
+	var (
+		id          = uuid.NewString()
+		name        = "Nobody"
+		description = "No one of importance."
+		ownerID     = uuid.NewString()
+		locationID  = uuid.NewString()
+		inventoryID = uuid.NewString()
+		created     = time.Now()
+		updated     = time.Now()
+	)
+
+	t.Run("invalid itemID", func(t *testing.T) {
+		l, _ := setupItems(t)
+
+		_, err := l.Get(context.Background(), "42")
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to get item: invalid argument: invalid item id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		_, err := l.Get(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to get item: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(errors.New("unknown error"))
+
+		_, err := l.Get(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to get item: internal error: unknown error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectQuery(getQ).WillReturnRows(rows)
+
+		item, err := l.Get(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if item.ID != id ||
+			item.Name != name ||
+			item.Description != description ||
+			item.OwnerID != ownerID ||
+			item.LocationID != locationID ||
+			item.InventoryID != inventoryID {
+			t.Errorf("\nExpected item: %+v", item)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("stale read", func(t *testing.T) {
+		const staleGetQ = `^SELECT (.+) FROM items AS OF SYSTEM TIME follower_read_timestamp\(\) WHERE item_id = \$1$`
+		rows := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectQuery(staleGetQ).WithArgs(id).WillReturnRows(rows)
+
+		if _, err := l.Get(arcade.WithStaleReads(context.Background()), id); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsCreate(t *testing.T) {
+	const (
+		createQ = `^INSERT INTO items \(name, description, owner_id, location_id, inventory_id, weight, created_by\) ` +
+			`VALUES \((.+), (.+), (.+), (.+)\) ` +
+			`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated$`
+	)
+
+	var (
+		id          = uuid.NewString()
+		name        = "Nobody"
+		description = "No one of importance."
+		ownerID     = "00000000-0000-0000-0000-000000000001"
+		locationID  = "00000000-0000-0000-0000-000000000001"
+		inventoryID = "00000000-0000-0000-0000-000000000001"
+		created     = time.Now()
+		updated     = time.Now()
+	)
+
+	t.Run("empty name", func(t *testing.T) {
+		req := arcade.ItemRequest{Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: empty item name"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("configured max name length", func(t *testing.T) {
+		n := ""
+		for i := 0; i < 21; i++ {
+			n += "a"
+		}
+		req := arcade.ItemRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+		l.Names.MaxLen = 20
+
+		_, err := l.Create(context.Background(), req)
+
+		expected := "failed to create item: invalid argument: name exceeds maximum length of 20"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("long name", func(t *testing.T) {
+		n := ""
+		for i := 0; i <= arcade.MaxItemNameLen; i++ {
+			n += "a"
+		}
+		req := arcade.ItemRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: item name exceeds maximum length"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("empty description", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: empty item description"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("long description", func(t *testing.T) {
+		d := ""
+		for i := 0; i <= arcade.MaxItemDescriptionLen; i++ {
+			d += "a"
+		}
+		req := arcade.ItemRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: item description exceeds maximum length"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid ownerID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: invalid ownerID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid locationID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: invalid locationID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid inventoryID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: "42"}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: invalid inventoryID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("foreign key voilation", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).
+			WillReturnRows(row).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectRollback()
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: the given ownerID, locationID, or inventoryID does not exist: " +
+			"ownerID '00000000-0000-0000-0000-000000000001', locationID '00000000-0000-0000-0000-000000000001', inventoryID '00000000-0000-0000-0000-000000000001'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unique violation", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).
+			WillReturnRows(row).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectRollback()
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: already exists: item already exists"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated).
+			RowError(0, errors.New("scan error"))
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).
+			WillReturnRows(row)
+		mock.ExpectRollback()
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: internal error: scan error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("disallowed markup", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: "<script>alert(1)</script>", OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+		l.ValidateMarkdown = true
+
+		_, err := l.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create item: invalid argument: description contains disallowed markup"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("markdown description", func(t *testing.T) {
+		description := "A *shiny* [sword](https://example.com/sword.png)."
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		l.ValidateMarkdown = true
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		item, err := l.Create(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if item.Description != description {
+			t.Errorf("Unexpected description: %s", item.Description)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		item, err := l.Create(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if item.ID != id ||
+			item.Name != name ||
+			item.Description != description ||
+			item.OwnerID != ownerID ||
+			item.LocationID != locationID ||
+			item.InventoryID != inventoryID ||
+			item.CreatedBy != arcade.DefaultActor {
+			t.Errorf("\nExpected item: %+v", item)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("actor", func(t *testing.T) {
+		const actor = "player:42"
+
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, actor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, locationID, inventoryID, 0, actor).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		item, err := l.Create(arcade.WithActor(context.Background(), actor), req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if item.CreatedBy != actor {
+			t.Errorf("Unexpected created by: %s", item.CreatedBy)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("default owner", func(t *testing.T) {
+		defaultOwnerID := uuid.New()
+
+		for _, test := range []struct {
+			name, ownerID, expected string
+		}{
+			{"omitted", "", defaultOwnerID.String()},
+			{"explicit", ownerID, ownerID},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				req := arcade.ItemRequest{Name: name, Description: description, OwnerID: test.ownerID, LocationID: locationID, InventoryID: inventoryID}
+				row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+					AddRow(id, name, description, test.expected, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+				l, mock := setupItems(t)
+				l.DefaultOwnerID = defaultOwnerID
+				mock.ExpectBegin()
+				expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+				mock.ExpectQuery(createQ).
+					WithArgs(name, description, test.expected, locationID, inventoryID, 0, arcade.DefaultActor).
+					WillReturnRows(row)
+				mock.ExpectCommit()
+
+				item, err := l.Create(context.Background(), req)
+
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if item.OwnerID != test.expected {
+					t.Errorf("Unexpected ownerID: %s", item.OwnerID)
+				}
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("Unexpected err: %s", err)
+				}
+			})
+		}
+	})
+
+	t.Run("strict locations", func(t *testing.T) {
+		const (
+			roomExistsQ   = `^SELECT EXISTS\(SELECT 1 FROM rooms WHERE room_id = \$1\)$`
+			playerExistsQ = `^SELECT EXISTS\(SELECT 1 FROM players WHERE player_id = \$1\)$`
+		)
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		t.Run("location is not a room", func(t *testing.T) {
+			l, mock := setupItems(t)
+			l.StrictLocations = true
+			mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: invalid argument: locationID " + locationID + " is not a room"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("inventory is not a player", func(t *testing.T) {
+			l, mock := setupItems(t)
+			l.StrictLocations = true
+			mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			mock.ExpectQuery(playerExistsQ).WithArgs(inventoryID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: invalid argument: inventoryID " + inventoryID + " is not a player"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("check error", func(t *testing.T) {
+			l, mock := setupItems(t)
+			l.StrictLocations = true
+			mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnError(errors.New("unknown error"))
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: internal error: unknown error"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			l.StrictLocations = true
+			mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			mock.ExpectQuery(playerExistsQ).WithArgs(inventoryID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+			mock.ExpectQuery(createQ).WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			if _, err := l.Create(context.Background(), req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+
+	t.Run("inventory owner", func(t *testing.T) {
+		t.Run("mismatch", func(t *testing.T) {
+			req := arcade.ItemRequest{Name: name, Description: description, OwnerID: uuid.NewString(), LocationID: locationID, InventoryID: inventoryID}
+
+			l, mock := setupItems(t)
+			l.RequireInventoryOwner = true
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: invalid argument: item on a player must be owned by that player"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("consistent", func(t *testing.T) {
+			req := arcade.ItemRequest{Name: name, Description: description, OwnerID: inventoryID, LocationID: locationID, InventoryID: inventoryID}
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, inventoryID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			l.RequireInventoryOwner = true
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+			mock.ExpectQuery(createQ).WithArgs(name, description, inventoryID, locationID, inventoryID, 0, arcade.DefaultActor).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			item, err := l.Create(context.Background(), req)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if item.OwnerID != item.InventoryID {
+				t.Errorf("Unexpected item: %+v", item)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+
+	t.Run("inventory capacity", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		t.Run("full", func(t *testing.T) {
+			l, mock := setupItems(t)
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, uuid.Nil.String(), 2, 2, false)
+			mock.ExpectRollback()
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: conflict: player inventory is at capacity: '" + inventoryID + "'"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("occupancy error", func(t *testing.T) {
+			l, mock := setupItems(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(`^SELECT inventory_capacity`).WillReturnError(errors.New("unknown error"))
+			mock.ExpectRollback()
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: internal error: unknown error"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("room left", func(t *testing.T) {
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, uuid.Nil.String(), 2, 1, false)
+			mock.ExpectQuery(createQ).WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			if _, err := l.Create(context.Background(), req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+
+	t.Run("users service", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		t.Run("absent", func(t *testing.T) {
+			l, mock := setupItems(t)
+			l.Users = fakeUsers{}
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: invalid argument: owner does not exist"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("error", func(t *testing.T) {
+			l, _ := setupItems(t)
+			l.Users = failingUsers{err: errors.New("users unavailable")}
+
+			_, err := l.Create(context.Background(), req)
+
+			expected := "failed to create item: internal error: users unavailable"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+		})
+
+		t.Run("present", func(t *testing.T) {
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			l.Users = fakeUsers{ownerID: true}
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, uuid.Nil.String(), 0, 0, false)
+			mock.ExpectQuery(createQ).WithArgs(name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			if _, err := l.Create(context.Background(), req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+}
 
 func TestItemsUpdate(t *testing.T) {
 	const (
-		// updateQ = `^UPDATE items SET (.+) WHERE (.+) RETURNING (.+)$`
-		updateQ = `^UPDATE items SET name = (.+), description = (.+), owner_id = (.+), location_id = (.+), inventory_id = (.+) ` +
-			`WHERE item_id = (.+) ` +
-			`RETURNING item_id, name, description, owner_id, location_id, inventory_id, created, updated$`
+		// updateQ = `^UPDATE items SET (.+) WHERE (.+) RETURNING (.+)$`
+		updateQ = `^UPDATE items SET name = (.+), description = (.+), owner_id = (.+), location_id = (.+), inventory_id = (.+) ` +
+			`WHERE item_id = (.+) ` +
+			`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated$`
+	)
+
+	var (
+		id          = uuid.NewString()
+		name        = "Nobody"
+		description = "No one of importance."
+		ownerID     = "00000000-0000-0000-0000-000000000001"
+		locationID  = "00000000-0000-0000-0000-000000000001"
+		inventoryID = "00000000-0000-0000-0000-000000000001"
+		created     = time.Now()
+		updated     = time.Now()
+	)
+
+	t.Run("invalid item id", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), "42", req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: invalid item id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		req := arcade.ItemRequest{Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: empty item name"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("long name", func(t *testing.T) {
+		n := ""
+		for i := 0; i <= arcade.MaxItemNameLen; i++ {
+			n += "a"
+		}
+		req := arcade.ItemRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: item name exceeds maximum length"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("empty description", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: empty item description"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("long description", func(t *testing.T) {
+		d := ""
+		for i := 0; i <= arcade.MaxItemDescriptionLen; i++ {
+			d += "a"
+		}
+		req := arcade.ItemRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: item description exceeds maximum length"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid ownerID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: invalid ownerID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid locationID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", InventoryID: inventoryID}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: invalid locationID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid inventoryID", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: "42"}
+
+		l, _ := setupItems(t)
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: invalid inventoryID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, id, 0, 0, false)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("foreign key voilation", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, id, 0, 0, false)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).
+			WillReturnRows(row).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectRollback()
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: invalid argument: the given ownerID, locationID, or inventoryID does not exist: " +
+			"ownerID '00000000-0000-0000-0000-000000000001', locationID '00000000-0000-0000-0000-000000000001', inventoryID '00000000-0000-0000-0000-000000000001'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unique violation", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, id, 0, 0, false)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).
+			WillReturnRows(row).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectRollback()
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: already exists: item name is not unique"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated).
+			RowError(0, errors.New("scan error"))
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, id, 0, 0, false)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).
+			WillReturnRows(row)
+		mock.ExpectRollback()
+
+		_, err := l.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update item: internal error: scan error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, inventoryID, id, 0, 0, false)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		item, err := l.Update(context.Background(), id, req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if item.ID != id ||
+			item.Name != name ||
+			item.Description != description ||
+			item.OwnerID != ownerID ||
+			item.LocationID != locationID ||
+			item.InventoryID != inventoryID {
+			t.Errorf("\nExpected item: %+v", item)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("inventory owner", func(t *testing.T) {
+		t.Run("mismatch", func(t *testing.T) {
+			req := arcade.ItemRequest{Name: name, Description: description, OwnerID: uuid.NewString(), LocationID: locationID, InventoryID: inventoryID}
+
+			l, mock := setupItems(t)
+			l.RequireInventoryOwner = true
+
+			_, err := l.Update(context.Background(), id, req)
+
+			expected := "failed to update item: invalid argument: item on a player must be owned by that player"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("consistent", func(t *testing.T) {
+			req := arcade.ItemRequest{Name: name, Description: description, OwnerID: inventoryID, LocationID: locationID, InventoryID: inventoryID}
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, inventoryID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			l.RequireInventoryOwner = true
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, id, 0, 0, false)
+			mock.ExpectQuery(updateQ).WithArgs(id, name, description, inventoryID, locationID, inventoryID, 0).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			item, err := l.Update(context.Background(), id, req)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if item.OwnerID != item.InventoryID {
+				t.Errorf("Unexpected item: %+v", item)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+
+	t.Run("inventory capacity", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		t.Run("full", func(t *testing.T) {
+			l, mock := setupItems(t)
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, id, 2, 2, false)
+			mock.ExpectRollback()
+
+			_, err := l.Update(context.Background(), id, req)
+
+			expected := "failed to update item: conflict: player inventory is at capacity: '" + inventoryID + "'"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("already held", func(t *testing.T) {
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, id, 2, 2, true)
+			mock.ExpectQuery(updateQ).WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			if _, err := l.Update(context.Background(), id, req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+
+	t.Run("users service", func(t *testing.T) {
+		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+
+		t.Run("absent", func(t *testing.T) {
+			l, mock := setupItems(t)
+			l.Users = fakeUsers{}
+
+			_, err := l.Update(context.Background(), id, req)
+
+			expected := "failed to update item: invalid argument: owner does not exist"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("present", func(t *testing.T) {
+			row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(id, name, description, ownerID, locationID, inventoryID, 0, arcade.DefaultActor, created, updated)
+
+			l, mock := setupItems(t)
+			l.Users = fakeUsers{ownerID: true}
+			mock.ExpectBegin()
+			expectOccupancy(mock, inventoryID, id, 0, 0, false)
+			mock.ExpectQuery(updateQ).WithArgs(id, name, description, ownerID, locationID, inventoryID, 0).WillReturnRows(row)
+			mock.ExpectCommit()
+
+			if _, err := l.Update(context.Background(), id, req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+}
+
+func TestItemsRemove(t *testing.T) {
+	const (
+		removeQ = `^DELETE FROM items WHERE item_id = (.+)$`
 	)
 
 	var (
-		id          = uuid.NewString()
-		name        = "Nobody"
-		description = "No one of importance."
-		ownerID     = "00000000-0000-0000-0000-000000000001"
-		locationID  = "00000000-0000-0000-0000-000000000001"
-		inventoryID = "00000000-0000-0000-0000-000000000001"
-		created     = time.Now()
-		updated     = time.Now()
+		id = uuid.NewString()
 	)
 
 	t.Run("invalid item id", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-
 		l, _ := setupItems(t)
 
-		_, err := l.Update(context.Background(), "42", req)
+		err := l.Remove(context.Background(), "42")
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to update item: invalid argument: invalid item id: '42'"
+		expected := "failed to remove item: invalid argument: invalid item id: '42'"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("empty name", func(t *testing.T) {
-		req := arcade.ItemRequest{Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectExec(removeQ).
+			WithArgs(id).
+			WillReturnError(sql.ErrNoRows)
 
-		l, _ := setupItems(t)
+		err := l.Remove(context.Background(), id)
 
-		_, err := l.Update(context.Background(), id, req)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to remove item: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectExec(removeQ).
+			WithArgs(id).
+			WillReturnError(errors.New("unknown error"))
+
+		err := l.Remove(context.Background(), id)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to update item: invalid argument: empty item name"
+		expected := "failed to remove item: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
 	})
 
-	t.Run("long name", func(t *testing.T) {
-		n := ""
-		for i := 0; i <= arcade.MaxItemNameLen; i++ {
-			n += "a"
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectExec(removeQ).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := l.Remove(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected err: %s", err)
 		}
-		req := arcade.ItemRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
 
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsRemoveMany(t *testing.T) {
+	const (
+		removeQ = `^DELETE FROM items WHERE item_id = ANY\(\$1\)$`
+	)
+
+	var (
+		id      = uuid.NewString()
+		otherID = uuid.NewString()
+	)
+
+	t.Run("invalid item id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.RemoveMany(context.Background(), []string{id, "42"})
+
+		expected := "failed to remove items: invalid argument: invalid itemID: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectExec(removeQ).
+			WithArgs("{" + id + "}").
+			WillReturnError(errors.New("unknown error"))
+
+		_, err := l.RemoveMany(context.Background(), []string{id})
+
+		expected := "failed to remove items: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("affected count", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectExec(removeQ).
+			WithArgs("{" + id + "," + otherID + "}").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		count, err := l.RemoveMany(context.Background(), []string{id, otherID, id})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if count != 1 {
+			t.Errorf("Unexpected count: %d", count)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestItemsSwapLocations(t *testing.T) {
+	const (
+		locationQ    = `^SELECT location_id, inventory_id FROM items WHERE item_id = \$1 FOR UPDATE$`
+		setLocationQ = `^UPDATE items SET location_id = \$2, inventory_id = \$3, updated = now\(\) WHERE item_id = \$1$`
+	)
+
+	var (
+		itemID       = uuid.NewString()
+		otherID      = uuid.NewString()
+		roomID       = uuid.NewString()
+		playerID     = uuid.NewString()
+		nobody       = "00000000-0000-0000-0000-000000000001"
+		locationRows = func(locationID, inventoryID string) *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"location_id", "inventory_id"}).AddRow(locationID, inventoryID)
+		}
+	)
+
+	t.Run("self swap", func(t *testing.T) {
+		l, mock := setupItems(t)
+
+		err := l.SwapLocations(context.Background(), itemID, itemID)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to update item: invalid argument: item name exceeds maximum length"
+		expected := "failed to swap item locations: invalid argument: cannot swap an item with itself"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
-	})
 
-	t.Run("empty description", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 
+	t.Run("invalid item id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		_, err := l.Update(context.Background(), id, req)
+		err := l.SwapLocations(context.Background(), itemID, "42")
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to update item: invalid argument: empty item description"
+		expected := "failed to swap item locations: invalid argument: invalid itemID: '42'"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("long description", func(t *testing.T) {
-		d := ""
-		for i := 0; i <= arcade.MaxItemDescriptionLen; i++ {
-			d += "a"
+	t.Run("item not found", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(locationQ).WithArgs(itemID).WillReturnRows(locationRows(roomID, nobody))
+		mock.ExpectQuery(locationQ).WithArgs(otherID).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := l.SwapLocations(context.Background(), itemID, otherID)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to swap item locations: not found: item '" + otherID + "'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
-		req := arcade.ItemRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
 
-		l, _ := setupItems(t)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("rollback when second update not found", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(locationQ).WithArgs(itemID).WillReturnRows(locationRows(roomID, nobody))
+		mock.ExpectQuery(locationQ).WithArgs(otherID).WillReturnRows(locationRows(nobody, playerID))
+		mock.ExpectExec(setLocationQ).WithArgs(itemID, nobody, playerID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(setLocationQ).WithArgs(otherID, roomID, nobody).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := l.SwapLocations(context.Background(), itemID, otherID)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to swap item locations: not found: item '" + otherID + "'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(locationQ).WithArgs(itemID).WillReturnRows(locationRows(roomID, nobody))
+		mock.ExpectQuery(locationQ).WithArgs(otherID).WillReturnRows(locationRows(nobody, playerID))
+		mock.ExpectExec(setLocationQ).WithArgs(itemID, nobody, playerID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(setLocationQ).WithArgs(otherID, roomID, nobody).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := l.SwapLocations(context.Background(), itemID, otherID); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 
-		_, err := l.Update(context.Background(), id, req)
+	t.Run("null inventory", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(locationQ).WithArgs(itemID).WillReturnRows(
+			sqlmock.NewRows([]string{"location_id", "inventory_id"}).AddRow(roomID, nil),
+		)
+		mock.ExpectQuery(locationQ).WithArgs(otherID).WillReturnRows(locationRows(nobody, playerID))
+		mock.ExpectExec(setLocationQ).WithArgs(itemID, nobody, playerID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(setLocationQ).WithArgs(otherID, roomID, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		if err == nil {
-			t.Fatal("Expected an error")
+		if err := l.SwapLocations(context.Background(), itemID, otherID); err != nil {
+			t.Errorf("Unexpected error: %s", err)
 		}
-		expected := "failed to update item: invalid argument: item description exceeds maximum length"
-		if err.Error() != expected {
-			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+}
 
-	t.Run("invalid ownerID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, InventoryID: inventoryID}
+func TestItemsTransfer(t *testing.T) {
+	const (
+		ownerQ    = `^SELECT owner_id, inventory_id FROM items WHERE item_id = \$1 FOR UPDATE$`
+		setOwnerQ = `^UPDATE items SET owner_id = \$2, updated = now\(\) WHERE item_id = \$1 ` +
+			`RETURNING item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated$`
+		recordQ = `^INSERT INTO item_transfers \(item_id, from_id, to_id\) VALUES \(\$1, \$2, \$3\)$`
+	)
+
+	var (
+		itemID    = uuid.NewString()
+		fromID    = uuid.NewString()
+		toID      = uuid.NewString()
+		nobody    = "00000000-0000-0000-0000-000000000001"
+		created   = time.Now()
+		updated   = time.Now()
+		ownerRows = func(ownerID string, inventoryID interface{}) *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"owner_id", "inventory_id"}).AddRow(ownerID, inventoryID)
+		}
+		itemRows = func() *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+				AddRow(itemID, "Lantern", "A brass lantern.", toID, nobody, nobody, 0, "", created, updated)
+		}
+	)
 
+	t.Run("invalid item id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), "42", toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: invalid argument: invalid ownerID: '42'"
-		if err.Error() != expected {
+		expected := "failed to transfer item: invalid argument: invalid item id: '42'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("invalid locationID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", InventoryID: inventoryID}
-
+	t.Run("invalid owner id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, "42")
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: invalid argument: invalid locationID: '42'"
-		if err.Error() != expected {
+		expected := "failed to transfer item: invalid argument: invalid ownerID: '42'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("invalid inventoryID", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: "42"}
-
-		l, _ := setupItems(t)
+	t.Run("item not found", func(t *testing.T) {
+		l, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: invalid argument: invalid inventoryID: '42'"
-		if err.Error() != expected {
+		expected := "failed to transfer item: not found"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
-	})
 
-	t.Run("not found", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 
+	t.Run("current owner", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, inventoryID).
-			WillReturnError(sql.ErrNoRows)
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnRows(ownerRows(toID, nil))
+		mock.ExpectRollback()
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: not found"
-		if err.Error() != expected {
+		expected := "failed to transfer item: invalid argument: item is already owned by '" + toID + "'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 
@@ -646,25 +2287,17 @@ func TestItemsUpdate(t *testing.T) {
 		}
 	})
 
-	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
-
+	t.Run("held by another player", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row).
-			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		l.RequireInventoryOwner = true
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnRows(ownerRows(fromID, fromID))
+		mock.ExpectRollback()
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: invalid argument: the given ownerID, locationID, or inventoryID does not exist: " +
-			"ownerID '00000000-0000-0000-0000-000000000001', locationID '00000000-0000-0000-0000-000000000001', inventoryID '00000000-0000-0000-0000-000000000001'"
-		if err.Error() != expected {
+		expected := "failed to transfer item: invalid argument: item on a player must be owned by that player"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 
@@ -673,24 +2306,18 @@ func TestItemsUpdate(t *testing.T) {
 		}
 	})
 
-	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
-
+	t.Run("unknown owner", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row).
-			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnRows(ownerRows(fromID, nil))
+		mock.ExpectQuery(setOwnerQ).WithArgs(itemID, toID).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectRollback()
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: already exists: item name is not unique"
-		if err.Error() != expected {
+		expected := "failed to transfer item: invalid argument: the given ownerID does not exist: '" + toID + "'"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 
@@ -699,24 +2326,18 @@ func TestItemsUpdate(t *testing.T) {
 		}
 	})
 
-	t.Run("scan error", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated).
-			RowError(0, errors.New("scan error"))
-
+	t.Run("rollback when record fails", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row)
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnRows(ownerRows(fromID, nil))
+		mock.ExpectQuery(setOwnerQ).WithArgs(itemID, toID).WillReturnRows(itemRows())
+		mock.ExpectExec(recordQ).WithArgs(itemID, fromID, toID).WillReturnError(errors.New("record failure"))
+		mock.ExpectRollback()
 
-		_, err := l.Update(context.Background(), id, req)
+		_, err := l.Transfer(context.Background(), itemID, toID)
 
-		if err == nil {
-			t.Fatal("Expected an error")
-		}
-		expected := "failed to update item: internal error: scan error"
-		if err.Error() != expected {
+		expected := "failed to transfer item: internal error: record failure"
+		if err == nil || err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 
@@ -726,27 +2347,20 @@ func TestItemsUpdate(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.ItemRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, InventoryID: inventoryID}
-		row := sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, inventoryID, created, updated)
-
 		l, mock := setupItems(t)
-		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, inventoryID).
-			WillReturnRows(row)
+		mock.ExpectBegin()
+		mock.ExpectQuery(ownerQ).WithArgs(itemID).WillReturnRows(ownerRows(fromID, nil))
+		mock.ExpectQuery(setOwnerQ).WithArgs(itemID, toID).WillReturnRows(itemRows())
+		mock.ExpectExec(recordQ).WithArgs(itemID, fromID, toID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		item, err := l.Update(context.Background(), id, req)
+		item, err := l.Transfer(context.Background(), itemID, toID)
 
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err)
 		}
-		if item.ID != id ||
-			item.Name != name ||
-			item.Description != description ||
-			item.OwnerID != ownerID ||
-			item.LocationID != locationID ||
-			item.InventoryID != inventoryID {
-			t.Errorf("\nExpected item: %+v", item)
+		if item.ID != itemID || item.OwnerID != toID {
+			t.Errorf("\nExpected item: %s owned by %s\nActual item:   %s owned by %s", itemID, toID, item.ID, item.OwnerID)
 		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
@@ -755,9 +2369,9 @@ func TestItemsUpdate(t *testing.T) {
 	})
 }
 
-func TestItemsRemove(t *testing.T) {
+func TestItemsExists(t *testing.T) {
 	const (
-		removeQ = `^DELETE FROM items WHERE item_id = (.+)$`
+		existsQ = `^SELECT EXISTS\(SELECT 1 FROM items WHERE item_id = \$1\)$`
 	)
 
 	var (
@@ -767,29 +2381,27 @@ func TestItemsRemove(t *testing.T) {
 	t.Run("invalid item id", func(t *testing.T) {
 		l, _ := setupItems(t)
 
-		err := l.Remove(context.Background(), "42")
+		_, err := l.Exists(context.Background(), "42")
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to remove item: invalid argument: invalid item id: '42'"
+		expected := "failed to check item exists: invalid argument: invalid item id: '42'"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
 	})
 
-	t.Run("not found", func(t *testing.T) {
+	t.Run("unknown error", func(t *testing.T) {
 		l, mock := setupItems(t)
-		mock.ExpectExec(removeQ).
-			WithArgs(id).
-			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnError(errors.New("unknown error"))
 
-		err := l.Remove(context.Background(), id)
+		_, err := l.Exists(context.Background(), id)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to remove item: not found"
+		expected := "failed to check item exists: internal error: unknown error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
@@ -799,43 +2411,280 @@ func TestItemsRemove(t *testing.T) {
 		}
 	})
 
-	t.Run("unknown error", func(t *testing.T) {
+	for _, expected := range []bool{true, false} {
 		l, mock := setupItems(t)
-		mock.ExpectExec(removeQ).
+		mock.ExpectQuery(existsQ).
 			WithArgs(id).
-			WillReturnError(errors.New("unknown error"))
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(expected))
 
-		err := l.Remove(context.Background(), id)
+		exists, err := l.Exists(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if exists != expected {
+			t.Errorf("Unexpected exists: %t", exists)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	}
+}
+
+func TestItemsImport(t *testing.T) {
+	const (
+		createQ    = `^INSERT INTO items \(name, description, owner_id, location_id, inventory_id, weight, created_by\) VALUES (.+) RETURNING (.+)$`
+		savepointQ = `^SAVEPOINT item_import$`
+		rollbackQ  = `^ROLLBACK TO SAVEPOINT item_import$`
+		releaseQ   = `^RELEASE SAVEPOINT item_import$`
+	)
+
+	var (
+		ownerID    = uuid.NewString()
+		locationID = uuid.NewString()
+		created    = time.Now()
+	)
+	row := func(line int, name string) arcade.ItemImportRow {
+		return arcade.ItemImportRow{Line: line, Request: arcade.ItemRequest{
+			Name: name, Description: "A " + name + ".", OwnerID: ownerID, LocationID: locationID, InventoryID: ownerID,
+		}}
+	}
+	inserted := func(id, name string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"item_id", "name", "description", "owner_id", "location_id", "inventory_id", "weight", "created_by", "created", "updated"}).
+			AddRow(id, name, "A "+name+".", ownerID, locationID, ownerID, 0, arcade.DefaultActor, created, created)
+	}
+
+	t.Run("clean import", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectBegin()
+		for _, name := range []string{"Sword", "Shield"} {
+			mock.ExpectExec(savepointQ).WillReturnResult(sqlmock.NewResult(0, 0))
+			expectOccupancy(mock, ownerID, uuid.Nil.String(), 0, 0, false)
+			mock.ExpectQuery(createQ).WithArgs(name, "A "+name+".", ownerID, locationID, ownerID, 0, arcade.DefaultActor).WillReturnRows(inserted("id-"+name, name))
+			mock.ExpectExec(releaseQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectCommit()
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword"), row(3, "Shield")}, false)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := []arcade.ItemImportResult{
+			{Line: 2, Imported: true, ItemID: "id-Sword"},
+			{Line: 3, Imported: true, ItemID: "id-Shield"},
+		}
+		if len(results) != len(expected) || results[0] != expected[0] || results[1] != expected[1] {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("bad rows", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(savepointQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectOccupancy(mock, ownerID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).WithArgs("Sword", "A Sword.", ownerID, locationID, ownerID, 0, arcade.DefaultActor).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectExec(rollbackQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(savepointQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectOccupancy(mock, ownerID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).WithArgs("Helm", "A Helm.", ownerID, locationID, ownerID, 0, arcade.DefaultActor).WillReturnRows(inserted("id-Helm", "Helm"))
+		mock.ExpectExec(releaseQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword"), row(3, ""), row(4, "Helm")}, false)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Unexpected results: %+v", results)
+		}
+		if r := results[0]; r.Line != 2 || r.Imported || r.Error == "" {
+			t.Errorf("Unexpected result: %+v", r)
+		}
+		if r := results[1]; r.Line != 3 || r.Imported || r.Error != "invalid argument: empty item name" {
+			t.Errorf("Unexpected result: %+v", r)
+		}
+		if r := results[2]; r.Line != 4 || !r.Imported || r.ItemID != "id-Helm" {
+			t.Errorf("Unexpected result: %+v", r)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("full inventory", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(savepointQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectOccupancy(mock, ownerID, uuid.Nil.String(), 1, 1, false)
+		mock.ExpectExec(rollbackQ).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, false)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := "conflict: player inventory is at capacity: '" + ownerID + "'"
+		if len(results) != 1 || results[0].Imported || results[0].Error != expected {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("strict invalid row", func(t *testing.T) {
+		i, mock := setupItems(t)
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword"), row(3, "")}, true)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(results) != 2 || results[0].Imported || results[1].Error != "invalid argument: empty item name" {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("strict failed insert", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectBegin()
+		expectOccupancy(mock, ownerID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).WithArgs("Sword", "A Sword.", ownerID, locationID, ownerID, 0, arcade.DefaultActor).WillReturnRows(inserted("id-Sword", "Sword"))
+		expectOccupancy(mock, ownerID, uuid.Nil.String(), 0, 0, false)
+		mock.ExpectQuery(createQ).WithArgs("Shield", "A Shield.", ownerID, locationID, ownerID, 0, arcade.DefaultActor).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectRollback()
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword"), row(3, "Shield")}, true)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(results) != 2 || results[0].Imported || results[1].Imported || results[1].Error != "already exists: item already exists" {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("begin error", func(t *testing.T) {
+		i, mock := setupItems(t)
+		mock.ExpectBegin().WillReturnError(errors.New("begin error"))
+
+		_, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, false)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
-		expected := "failed to remove item: internal error: unknown error"
+		expected := "failed to import items: internal error: begin error"
 		if err.Error() != expected {
 			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+	})
+
+	t.Run("strict locations", func(t *testing.T) {
+		const roomExistsQ = `^SELECT EXISTS\(SELECT 1 FROM rooms WHERE room_id = \$1\)$`
+
+		i, mock := setupItems(t)
+		i.StrictLocations = true
+		mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, true)
 
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := "invalid argument: locationID " + locationID + " is not a room"
+		if len(results) != 1 || results[0].Imported || results[0].Error != expected {
+			t.Errorf("Unexpected results: %+v", results)
+		}
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
 
-	t.Run("success", func(t *testing.T) {
-		l, mock := setupItems(t)
-		mock.ExpectExec(removeQ).
-			WithArgs(id).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+	t.Run("strict locations check error", func(t *testing.T) {
+		const roomExistsQ = `^SELECT EXISTS\(SELECT 1 FROM rooms WHERE room_id = \$1\)$`
 
-		err := l.Remove(context.Background(), id)
+		i, mock := setupItems(t)
+		i.StrictLocations = true
+		mock.ExpectQuery(roomExistsQ).WithArgs(locationID).WillReturnError(errors.New("unknown error"))
 
-		if err != nil {
-			t.Fatalf("Unexpected err: %s", err)
+		_, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, false)
+
+		expected := "failed to import items: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
 		}
+	})
+
+	t.Run("unknown owner", func(t *testing.T) {
+		i, mock := setupItems(t)
+		i.Users = fakeUsers{}
 
+		results, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, false)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(results) != 1 || results[0].Imported || results[0].Error != "invalid argument: owner does not exist" {
+			t.Errorf("Unexpected results: %+v", results)
+		}
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+
+	t.Run("users error", func(t *testing.T) {
+		i, _ := setupItems(t)
+		i.Users = failingUsers{err: errors.New("users unavailable")}
+
+		_, err := i.Import(context.Background(), []arcade.ItemImportRow{row(2, "Sword")}, false)
+
+		expected := "failed to import items: internal error: users unavailable"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+}
+
+// fakeUsers is a users service knowing the users set to true.
+type fakeUsers map[string]bool
+
+func (f fakeUsers) UserExists(_ context.Context, userID string) (bool, error) {
+	return f[userID], nil
+}
+
+// failingUsers is a users service that cannot be reached.
+type failingUsers struct{ err error }
+
+func (f failingUsers) UserExists(context.Context, string) (bool, error) {
+	return false, f.err
+}
+
+func expectOccupancy(mock sqlmock.Sqlmock, inventoryID, itemID string, capacity, count int, held bool) {
+	const occupancyQ = `^SELECT inventory_capacity, \(SELECT count\(\*\) FROM items WHERE inventory_id = \$1 AND item_id != \$2\), ` +
+		`EXISTS\(SELECT 1 FROM items WHERE item_id = \$2 AND inventory_id = \$1\) FROM players WHERE player_id = \$1 FOR UPDATE$`
+
+	mock.ExpectQuery(occupancyQ).WithArgs(inventoryID, itemID).
+		WillReturnRows(sqlmock.NewRows([]string{"inventory_capacity", "count", "held"}).AddRow(capacity, count, held))
 }
 
 func setupItems(t *testing.T) (storage.Items, sqlmock.Sqlmock) {
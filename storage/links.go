@@ -33,6 +33,15 @@ type (
 	Links struct {
 		DB     *sql.DB
 		Driver arcade.StorageDriver
+
+		// Names restricts the names of created and updated links.
+		Names arcade.NamePolicy
+
+		// Timeouts are the time limits of the link operations.
+		Timeouts Timeouts
+
+		// World limits the number of entities created links join.
+		World WorldLimit
 	}
 )
 
@@ -40,10 +49,49 @@ type (
 func (p Links) List(ctx context.Context, filter arcade.LinksFilter) ([]arcade.Link, error) {
 	failMsg := "failed to list links"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "list links")
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.LinksListQuery(filter)), listArgs(filter.CreatedBy)...)
+}
+
+// GetMany returns the links of the given linkIDs which exist, in no particular
+// order.
+func (p Links) GetMany(ctx context.Context, linkIDs []string) ([]arcade.Link, error) {
+	failMsg := "failed to get links"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("linkIDs", len(linkIDs)).Info("msg", "get links")
+
+	ids, err := parseIDs("link", linkIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.LinksGetManyQuery()), uuidArray(ids))
+}
+
+// FindDangling returns the links whose location or destination room does not
+// exist, e.g. after the rooms table was edited by hand.
+func (p Links) FindDangling(ctx context.Context) ([]arcade.Link, error) {
+	failMsg := "failed to find dangling links"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "find dangling links")
+
+	return p.list(ctx, failMsg, p.Driver.LinksDanglingQuery())
+}
+
+func (p Links) list(ctx context.Context, failMsg, query string, args ...interface{}) ([]arcade.Link, error) {
 	logger := log.LoggerFromContext(ctx)
-	logger.Info("msg", "list links")
 
-	rows, err := p.DB.QueryContext(ctx, p.Driver.LinksListQuery(filter))
+	rows, err := p.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
@@ -63,6 +111,8 @@ func (p Links) List(ctx context.Context, filter arcade.LinksFilter) ([]arcade.Li
 			&link.OwnerID,
 			&link.LocationID,
 			&link.DestinationID,
+			&link.Capacity,
+			&link.CreatedBy,
 			&link.Created,
 			&link.Updated,
 		)
@@ -74,6 +124,9 @@ func (p Links) List(ctx context.Context, filter arcade.LinksFilter) ([]arcade.Li
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
+	if max := p.Driver.ListRowsCap(); len(links) >= max {
+		logger.Warn("msg", "list reached the maximum rows", "max", max)
+	}
 
 	return links, nil
 }
@@ -82,6 +135,9 @@ func (p Links) List(ctx context.Context, filter arcade.LinksFilter) ([]arcade.Li
 func (p Links) Get(ctx context.Context, linkID string) (arcade.Link, error) {
 	failMsg := "failed to get link"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "get link")
 
 	pid, err := uuid.Parse(linkID)
@@ -90,13 +146,95 @@ func (p Links) Get(ctx context.Context, linkID string) (arcade.Link, error) {
 	}
 
 	var link arcade.Link
-	err = p.DB.QueryRowContext(ctx, p.Driver.LinksGetQuery(), pid).Scan(
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.LinksGetQuery()), pid).Scan(
+		&link.ID,
+		&link.Name,
+		&link.Description,
+		&link.OwnerID,
+		&link.LocationID,
+		&link.DestinationID,
+		&link.Capacity,
+		&link.CreatedBy,
+		&link.Created,
+		&link.Updated,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return link, nil
+}
+
+// GetWithRooms returns a single link given the linkID, with the names of its
+// location and destination rooms. The name of a room which does not exist is
+// empty.
+func (p Links) GetWithRooms(ctx context.Context, linkID string) (arcade.Link, error) {
+	failMsg := "failed to get link"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "get link with rooms")
+
+	pid, err := uuid.Parse(linkID)
+	if err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w: invalid link id: '%s'", failMsg, cerrors.ErrInvalidArgument, linkID)
+	}
+
+	var link arcade.Link
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.LinksGetWithRoomsQuery()), pid).Scan(
+		&link.ID,
+		&link.Name,
+		&link.Description,
+		&link.OwnerID,
+		&link.LocationID,
+		&link.DestinationID,
+		&link.Capacity,
+		&link.CreatedBy,
+		&link.Created,
+		&link.Updated,
+		&link.LocationName,
+		&link.DestinationName,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return link, nil
+}
+
+// GetReverse returns the link whose location and destination are swapped
+// relative to the link given by linkID. Of several such links the oldest is
+// returned, so the result is deterministic.
+func (p Links) GetReverse(ctx context.Context, linkID string) (arcade.Link, error) {
+	failMsg := "failed to get reverse link"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "get reverse link")
+
+	pid, err := uuid.Parse(linkID)
+	if err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w: invalid link id: '%s'", failMsg, cerrors.ErrInvalidArgument, linkID)
+	}
+
+	var link arcade.Link
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.LinksGetReverseQuery()), pid).Scan(
 		&link.ID,
 		&link.Name,
 		&link.Description,
 		&link.OwnerID,
 		&link.LocationID,
 		&link.DestinationID,
+		&link.Capacity,
+		&link.CreatedBy,
 		&link.Created,
 		&link.Updated,
 	)
@@ -114,6 +252,9 @@ func (p Links) Get(ctx context.Context, linkID string) (arcade.Link, error) {
 func (p Links) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link, error) {
 	failMsg := "failed to create link"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("name", req.Name)
 	logger.Info("msg", "create link")
 
@@ -121,6 +262,12 @@ func (p Links) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link,
 	if err != nil {
 		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.World.check(ctx, p.DB, p.Driver, 1); err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
 
 	var link arcade.Link
 	err = p.DB.QueryRowContext(ctx, p.Driver.LinksCreateQuery(),
@@ -129,6 +276,8 @@ func (p Links) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link,
 		ownerID,
 		locationID,
 		destinationID,
+		req.Capacity,
+		arcade.ActorFromContext(ctx),
 	).Scan(
 		&link.ID,
 		&link.Name,
@@ -136,6 +285,8 @@ func (p Links) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link,
 		&link.OwnerID,
 		&link.LocationID,
 		&link.DestinationID,
+		&link.Capacity,
+		&link.CreatedBy,
 		&link.Created,
 		&link.Updated,
 	)
@@ -168,6 +319,9 @@ func (p Links) Create(ctx context.Context, req arcade.LinkRequest) (arcade.Link,
 func (p Links) Update(ctx context.Context, linkID string, req arcade.LinkRequest) (arcade.Link, error) {
 	failMsg := "failed to update link"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("linkID", linkID, "name", req.Name)
 	logger.Info("msg", "update link")
 
@@ -179,6 +333,9 @@ func (p Links) Update(ctx context.Context, linkID string, req arcade.LinkRequest
 	if err != nil {
 		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Link{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
 
 	var link arcade.Link
 	err = p.DB.QueryRowContext(ctx, p.Driver.LinksUpdateQuery(),
@@ -188,6 +345,7 @@ func (p Links) Update(ctx context.Context, linkID string, req arcade.LinkRequest
 		ownerID,
 		locationID,
 		destinationID,
+		req.Capacity,
 	).Scan(
 		&link.ID,
 		&link.Name,
@@ -195,6 +353,8 @@ func (p Links) Update(ctx context.Context, linkID string, req arcade.LinkRequest
 		&link.OwnerID,
 		&link.LocationID,
 		&link.DestinationID,
+		&link.Capacity,
+		&link.CreatedBy,
 		&link.Created,
 		&link.Updated,
 	)
@@ -230,6 +390,9 @@ func (p Links) Update(ctx context.Context, linkID string, req arcade.LinkRequest
 func (p Links) Remove(ctx context.Context, linkID string) error {
 	failMsg := "failed to remove link"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpRemove)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "remove link")
 
 	pid, err := uuid.Parse(linkID)
@@ -247,3 +410,161 @@ func (p Links) Remove(ctx context.Context, linkID string) error {
 
 	return nil
 }
+
+// TryTraverse occupies a slot of the given link, returning ErrConflict when
+// the link is at capacity. The capacity check and the increment of the
+// occupancy are a single statement, so concurrent traversals cannot exceed
+// the capacity.
+func (p Links) TryTraverse(ctx context.Context, linkID string) error {
+	failMsg := "failed to traverse link"
+
+	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "traverse link")
+
+	updated, err := p.updateCounter(ctx, failMsg, p.Driver.LinksTraverseQuery(), linkID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("%s: %w: link is at capacity", failMsg, arcade.ErrConflict)
+	}
+
+	return nil
+}
+
+// Release frees a slot of the given link. Releasing an unoccupied link is a
+// no-op.
+func (p Links) Release(ctx context.Context, linkID string) error {
+	failMsg := "failed to release link"
+
+	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "release link")
+
+	_, err := p.updateCounter(ctx, failMsg, p.Driver.LinksReleaseQuery(), linkID)
+	return err
+}
+
+// IncrementTraversal counts a traversal of the given link. The count is kept
+// for analytics, apart from the occupancy.
+func (p Links) IncrementTraversal(ctx context.Context, linkID string) error {
+	failMsg := "failed to increment link traversal"
+
+	log.LoggerFromContext(ctx).With("linkID", linkID).Info("msg", "increment link traversal")
+
+	_, err := p.updateCounter(ctx, failMsg, p.Driver.LinksIncrementTraversalQuery(), linkID)
+	return err
+}
+
+// WithinHops returns the rooms reachable from the given room by following at
+// most hops links, nearest first, with the fewest links leading to each.
+func (p Links) WithinHops(ctx context.Context, roomID string, hops int) ([]arcade.RoomHops, error) {
+	failMsg := "failed to list rooms within hops"
+
+	logger := log.LoggerFromContext(ctx).With("roomID", roomID, "hops", hops)
+	logger.Info("msg", "list rooms within hops")
+
+	rid, err := uuid.Parse(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, roomID)
+	}
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.LinksWithinHopsQuery(), rid, hops)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("msg", "failed to close rows of within hops query", "error", err.Error())
+		}
+	}()
+
+	rooms := make([]arcade.RoomHops, 0)
+	for rows.Next() {
+		var room arcade.RoomHops
+		if err := rows.Scan(&room.RoomID, &room.Hops); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		rooms = append(rooms, room)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return rooms, nil
+}
+
+// Route returns the route with the fewest links from one room to another,
+// following at most hops links. The exits of the rooms reached are read a
+// ring at a time, so no route is missed however many links the world holds.
+func (p Links) Route(ctx context.Context, fromID, toID string, hops int) (arcade.Route, error) {
+	failMsg := "failed to find route"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("fromID", fromID, "toID", toID, "hops", hops).Info("msg", "find route")
+
+	fid, err := uuid.Parse(fromID)
+	if err != nil {
+		return arcade.Route{}, fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, fromID)
+	}
+	tid, err := uuid.Parse(toID)
+	if err != nil {
+		return arcade.Route{}, fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, toID)
+	}
+
+	var links []arcade.Link
+	reached := map[uuid.UUID]bool{fid: true}
+	ring := []uuid.UUID{fid}
+	for i := 0; i < hops && len(ring) > 0 && !reached[tid]; i++ {
+		exits, err := p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.LinksExitsQuery()), uuidArray(ring))
+		if err != nil {
+			return arcade.Route{}, err
+		}
+		links = append(links, exits...)
+
+		var next []uuid.UUID
+		for _, l := range exits {
+			did, err := uuid.Parse(l.DestinationID)
+			if err != nil {
+				return arcade.Route{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+			}
+			if !reached[did] {
+				reached[did] = true
+				next = append(next, did)
+			}
+		}
+		ring = next
+	}
+
+	return arcade.NewRoute(links, fid.String(), tid.String())
+}
+
+// updateCounter executes the given query updating a counter of the link,
+// returning false when the link exists but the counter was not updated.
+func (p Links) updateCounter(ctx context.Context, failMsg, query, linkID string) (bool, error) {
+	pid, err := uuid.Parse(linkID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w: invalid link id: '%s'", failMsg, cerrors.ErrInvalidArgument, linkID)
+	}
+
+	result, err := p.DB.ExecContext(ctx, query, pid)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if n > 0 {
+		return true, nil
+	}
+
+	// Nothing was updated, either the link does not exist or the counter is
+	// at a limit.
+	if _, err := p.Get(ctx, linkID); err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return false, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+		}
+		return false, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	return false, nil
+}
@@ -33,7 +33,7 @@ import (
 
 func TestLinksList(t *testing.T) {
 	const (
-		listQ = "^SELECT link_id, name, description, owner_id, location_id, destination_id, created, updated FROM links$"
+		listQ = "^SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links ORDER BY created ASC LIMIT 10000$"
 	)
 
 	var (
@@ -43,6 +43,7 @@ func TestLinksList(t *testing.T) {
 		ownerID       = uuid.NewString()
 		locationID    = uuid.NewString()
 		destinationID = uuid.NewString()
+		capacity      = 2
 		created       = time.Now()
 		updated       = time.Now()
 	)
@@ -69,9 +70,9 @@ func TestLinksList(t *testing.T) {
 
 	t.Run("sql scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated",
+			"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated",
 		}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		l, mock := setupLinks(t)
@@ -95,8 +96,8 @@ func TestLinksList(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(listQ).
@@ -124,11 +125,110 @@ func TestLinksList(t *testing.T) {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+
+	t.Run("owner filter", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
+
+		owner := uuid.MustParse(ownerID)
+		l, mock := setupLinks(t)
+		mock.ExpectQuery("^SELECT (.+) FROM links WHERE owner_id = '" + ownerID + "' ORDER BY created ASC LIMIT 10000$").
+			WillReturnRows(rows).
+			RowsWillBeClosed()
+
+		links, err := l.List(context.Background(), arcade.LinksFilter{OwnerID: &owner})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(links) != 1 || links[0].OwnerID != ownerID {
+			t.Errorf("Unexpected links: %+v", links)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("owner and location filter", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"})
+
+		owner, location := uuid.MustParse(ownerID), uuid.MustParse(locationID)
+		l, mock := setupLinks(t)
+		mock.ExpectQuery("^SELECT (.+) FROM links WHERE owner_id = '" + ownerID + "' AND location_id = '" + locationID + "' ORDER BY created ASC LIMIT 10000$").
+			WillReturnRows(rows).
+			RowsWillBeClosed()
+
+		links, err := l.List(context.Background(), arcade.LinksFilter{OwnerID: &owner, LocationID: &location})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(links) != 0 {
+			t.Errorf("Unexpected links: %+v", links)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksGetMany(t *testing.T) {
+	const getManyQ = `^SELECT (.+) FROM links WHERE link_id = ANY\(\$1\)$`
+
+	var (
+		id      = uuid.NewString()
+		otherID = uuid.NewString()
+		created = time.Now()
+	)
+
+	t.Run("invalid linkID", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		_, err := l.GetMany(context.Background(), []string{id, "42"})
+
+		expected := "failed to get links: invalid argument: invalid link id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "}").WillReturnError(errors.New("query error"))
+
+		_, err := l.GetMany(context.Background(), []string{id})
+
+		expected := "failed to get links: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, "Door", "A door.", uuid.NewString(), uuid.NewString(), uuid.NewString(), 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "," + otherID + "}").WillReturnRows(rows)
+
+		links, err := l.GetMany(context.Background(), []string{id, otherID})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(links) != 1 || links[0].ID != id {
+			t.Errorf("Unexpected links: %+v", links)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 }
 
 func TestLinksGet(t *testing.T) {
 	const (
-		getQ = "^SELECT link_id, name, description, owner_id, location_id, destination_id, created, updated FROM links WHERE link_id = (.+)$"
+		getQ = "^SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links WHERE link_id = (.+)$"
 	)
 
 	var (
@@ -138,6 +238,7 @@ func TestLinksGet(t *testing.T) {
 		ownerID       = uuid.NewString()
 		locationID    = uuid.NewString()
 		destinationID = uuid.NewString()
+		capacity      = 2
 		created       = time.Now()
 		updated       = time.Now()
 	)
@@ -195,8 +296,8 @@ func TestLinksGet(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(getQ).WillReturnRows(rows)
@@ -221,11 +322,159 @@ func TestLinksGet(t *testing.T) {
 	})
 }
 
+func TestLinksGetWithRooms(t *testing.T) {
+	const (
+		getQ = `^SELECT links.link_id, (.+), COALESCE\(location.name, ''\), COALESCE\(destination.name, ''\) FROM links ` +
+			`LEFT JOIN rooms AS location ON location.room_id = links.location_id ` +
+			`LEFT JOIN rooms AS destination ON destination.room_id = links.destination_id ` +
+			`WHERE links.link_id = \$1$`
+	)
+
+	var (
+		id            = uuid.NewString()
+		ownerID       = uuid.NewString()
+		locationID    = uuid.NewString()
+		destinationID = uuid.NewString()
+		created       = time.Now()
+		updated       = time.Now()
+	)
+
+	t.Run("invalid linkID", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		_, err := l.GetWithRooms(context.Background(), "42")
+
+		expected := "failed to get link: invalid argument: invalid link id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		_, err := l.GetWithRooms(context.Background(), id)
+
+		expected := "failed to get link: not found"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated", "location_name", "destination_name"}).
+			AddRow(id, "Archway", "A stone archway.", ownerID, locationID, destinationID, 2, arcade.DefaultActor, created, updated, "Hall", "Garden")
+
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnRows(rows)
+
+		link, err := l.GetWithRooms(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if link.ID != id || link.LocationID != locationID || link.DestinationID != destinationID {
+			t.Errorf("\nUnexpected link: %+v", link)
+		}
+		if link.LocationName != "Hall" || link.DestinationName != "Garden" {
+			t.Errorf("\nUnexpected room names: %s, %s", link.LocationName, link.DestinationName)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksGetReverse(t *testing.T) {
+	const (
+		reverseQ = `^SELECT reverse.link_id, (.+) FROM links ` +
+			`JOIN links AS reverse ON reverse.location_id = links.destination_id AND reverse.destination_id = links.location_id ` +
+			`AND reverse.link_id != links.link_id ` +
+			`WHERE links.link_id = \$1 ORDER BY reverse.created ASC, reverse.link_id ASC LIMIT 1$`
+	)
+
+	var (
+		id            = uuid.NewString()
+		reverseID     = uuid.NewString()
+		ownerID       = uuid.NewString()
+		locationID    = uuid.NewString()
+		destinationID = uuid.NewString()
+		created       = time.Now()
+		updated       = time.Now()
+	)
+
+	t.Run("invalid linkID", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		_, err := l.GetReverse(context.Background(), "42")
+
+		expected := "failed to get reverse link: invalid argument: invalid link id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(reverseQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		_, err := l.GetReverse(context.Background(), id)
+
+		expected := "failed to get reverse link: not found"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(reverseQ).WithArgs(id).WillReturnError(errors.New("query error"))
+
+		_, err := l.GetReverse(context.Background(), id)
+
+		expected := "failed to get reverse link: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(reverseID, "Archway", "A stone archway.", ownerID, destinationID, locationID, 2, arcade.DefaultActor, created, updated)
+
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(reverseQ).WithArgs(id).WillReturnRows(rows)
+
+		link, err := l.GetReverse(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if link.ID != reverseID || link.LocationID != destinationID || link.DestinationID != locationID {
+			t.Errorf("\nUnexpected link: %+v", link)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
 func TestLinksCreate(t *testing.T) {
 	const (
-		createQ = `^INSERT INTO links \(name, description, owner_id, location_id, destination_id\) ` +
+		createQ = `^INSERT INTO links \(name, description, owner_id, location_id, destination_id, capacity, created_by\) ` +
 			`VALUES \((.+), (.+), (.+), (.+)\) ` +
-			`RETURNING link_id, name, description, owner_id, location_id, destination_id, created, updated$`
+			`RETURNING link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated$`
 	)
 
 	var (
@@ -235,12 +484,13 @@ func TestLinksCreate(t *testing.T) {
 		ownerID       = "00000000-0000-0000-0000-000000000001"
 		locationID    = "00000000-0000-0000-0000-000000000001"
 		destinationID = "00000000-0000-0000-0000-000000000001"
+		capacity      = 2
 		created       = time.Now()
 		updated       = time.Now()
 	)
 
 	t.Run("empty name", func(t *testing.T) {
-		req := arcade.LinkRequest{Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -255,12 +505,30 @@ func TestLinksCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("configured max name length", func(t *testing.T) {
+		n := ""
+		for i := 0; i < 17; i++ {
+			n += "a"
+		}
+		req := arcade.LinkRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+
+		l, _ := setupLinks(t)
+		l.Names.MaxLen = 16
+
+		_, err := l.Create(context.Background(), req)
+
+		expected := "failed to create link: invalid argument: name exceeds maximum length of 16"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("long name", func(t *testing.T) {
 		n := ""
 		for i := 0; i <= arcade.MaxLinkNameLen; i++ {
 			n += "a"
 		}
-		req := arcade.LinkRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -276,7 +544,7 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("empty description", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -296,7 +564,7 @@ func TestLinksCreate(t *testing.T) {
 		for i := 0; i <= arcade.MaxLinkDescriptionLen; i++ {
 			d += "a"
 		}
-		req := arcade.LinkRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -312,7 +580,7 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("invalid ownerID", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -328,7 +596,7 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("invalid locationID", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -360,13 +628,13 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, destinationID).
+			WithArgs(name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
 
@@ -387,13 +655,13 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, destinationID).
+			WithArgs(name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 
@@ -413,14 +681,14 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated).
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, destinationID).
+			WithArgs(name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		_, err := l.Create(context.Background(), req)
@@ -439,13 +707,13 @@ func TestLinksCreate(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, locationID, destinationID).
+			WithArgs(name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		link, err := l.Create(context.Background(), req)
@@ -471,9 +739,9 @@ func TestLinksCreate(t *testing.T) {
 func TestLinksUpdate(t *testing.T) {
 	const (
 		// updateQ = `^UPDATE links SET (.+) WHERE (.+) RETURNING (.+)$`
-		updateQ = `^UPDATE links SET name = (.+), description = (.+), owner_id = (.+), location_id = (.+), destination_id = (.+) ` +
+		updateQ = `^UPDATE links SET name = (.+), description = (.+), owner_id = (.+), location_id = (.+), destination_id = (.+), capacity = (.+) ` +
 			`WHERE link_id = (.+) ` +
-			`RETURNING link_id, name, description, owner_id, location_id, destination_id, created, updated$`
+			`RETURNING link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated$`
 	)
 
 	var (
@@ -483,12 +751,13 @@ func TestLinksUpdate(t *testing.T) {
 		ownerID       = "00000000-0000-0000-0000-000000000001"
 		locationID    = "00000000-0000-0000-0000-000000000001"
 		destinationID = "00000000-0000-0000-0000-000000000001"
+		capacity      = 2
 		created       = time.Now()
 		updated       = time.Now()
 	)
 
 	t.Run("invalid link id", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -504,7 +773,7 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("empty name", func(t *testing.T) {
-		req := arcade.LinkRequest{Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -524,7 +793,7 @@ func TestLinksUpdate(t *testing.T) {
 		for i := 0; i <= arcade.MaxLinkNameLen; i++ {
 			n += "a"
 		}
-		req := arcade.LinkRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: n, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -540,7 +809,7 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("empty description", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -560,7 +829,7 @@ func TestLinksUpdate(t *testing.T) {
 		for i := 0; i <= arcade.MaxLinkDescriptionLen; i++ {
 			d += "a"
 		}
-		req := arcade.LinkRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: d, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -576,7 +845,7 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("invalid ownerID", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: "42", LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -592,7 +861,7 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("invalid locationID", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: "42", DestinationID: destinationID, Capacity: capacity}
 
 		l, _ := setupLinks(t)
 
@@ -624,11 +893,11 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, destinationID).
+			WithArgs(id, name, description, ownerID, locationID, destinationID, capacity).
 			WillReturnError(sql.ErrNoRows)
 
 		_, err := l.Update(context.Background(), id, req)
@@ -647,13 +916,13 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, destinationID).
+			WithArgs(id, name, description, ownerID, locationID, destinationID, capacity).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
 
@@ -674,13 +943,13 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, destinationID).
+			WithArgs(id, name, description, ownerID, locationID, destinationID, capacity).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 
@@ -700,14 +969,14 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated).
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, destinationID).
+			WithArgs(id, name, description, ownerID, locationID, destinationID, capacity).
 			WillReturnRows(row)
 
 		_, err := l.Update(context.Background(), id, req)
@@ -726,13 +995,13 @@ func TestLinksUpdate(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID}
-		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, locationID, destinationID, created, updated)
+		req := arcade.LinkRequest{Name: name, Description: description, OwnerID: ownerID, LocationID: locationID, DestinationID: destinationID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
 
 		l, mock := setupLinks(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, locationID, destinationID).
+			WithArgs(id, name, description, ownerID, locationID, destinationID, capacity).
 			WillReturnRows(row)
 
 		link, err := l.Update(context.Background(), id, req)
@@ -848,3 +1117,429 @@ func setupLinks(t *testing.T) (storage.Links, sqlmock.Sqlmock) {
 
 	return storage.Links{DB: db, Driver: cockroach.Driver{}}, mock
 }
+
+func TestLinksTryTraverse(t *testing.T) {
+	const (
+		traverseQ = `^UPDATE links SET occupancy = occupancy \+ 1 WHERE link_id = \$1 AND \(capacity = 0 OR occupancy < capacity\)$`
+		releaseQ  = `^UPDATE links SET occupancy = occupancy - 1 WHERE link_id = \$1 AND occupancy > 0$`
+		getQ      = `^SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links WHERE link_id = (.+)$`
+	)
+
+	var (
+		id            = uuid.NewString()
+		name          = "Door"
+		description   = "A narrow door."
+		ownerID       = uuid.NewString()
+		locationID    = uuid.NewString()
+		destinationID = uuid.NewString()
+		capacity      = 2
+		created       = time.Now()
+		updated       = time.Now()
+	)
+
+	getRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, locationID, destinationID, capacity, arcade.DefaultActor, created, updated)
+	}
+
+	t.Run("invalid link id", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		err := l.TryTraverse(context.Background(), "42")
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to traverse link: invalid argument: invalid link id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectExec(traverseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		err := l.TryTraverse(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to traverse link: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectExec(traverseQ).WithArgs(id).WillReturnError(errors.New("unknown error"))
+
+		err := l.TryTraverse(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to traverse link: internal error: unknown error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("traverse to capacity, reject, and release", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		ctx := context.Background()
+
+		// Traverse up to capacity.
+		for i := 0; i < capacity; i++ {
+			mock.ExpectExec(traverseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+			if err := l.TryTraverse(ctx, id); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		// Over capacity is rejected with a conflict.
+		mock.ExpectExec(traverseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnRows(getRow())
+		err := l.TryTraverse(ctx, id)
+		if !errors.Is(err, arcade.ErrConflict) {
+			t.Fatalf("Expected a conflict error, actual error: %v", err)
+		}
+		expected := "failed to traverse link: conflict: link is at capacity"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		// Releasing frees a slot.
+		mock.ExpectExec(releaseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		if err := l.Release(ctx, id); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		mock.ExpectExec(traverseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		if err := l.TryTraverse(ctx, id); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("release unoccupied", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectExec(releaseQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnRows(getRow())
+
+		if err := l.Release(context.Background(), id); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksIncrementTraversal(t *testing.T) {
+	const (
+		incrementQ = `^UPDATE links SET traversal_count = traversal_count \+ 1 WHERE link_id = \$1$`
+		getQ       = `^SELECT link_id, name, description, owner_id, location_id, destination_id, capacity, created_by, created, updated FROM links WHERE link_id = (.+)$`
+	)
+
+	id := uuid.NewString()
+
+	t.Run("invalid link id", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		err := l.IncrementTraversal(context.Background(), "42")
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to increment link traversal: invalid argument: invalid link id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectExec(incrementQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		err := l.IncrementTraversal(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to increment link traversal: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectExec(incrementQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := l.IncrementTraversal(context.Background(), id); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksWithinHops(t *testing.T) {
+	const withinHopsQ = `^WITH RECURSIVE reachable \(room_id, hops, path\) AS \(.+\) SELECT room_id, min\(hops\) FROM reachable WHERE room_id <> \$1 GROUP BY room_id ORDER BY min\(hops\), room_id$`
+
+	roomID := uuid.New()
+
+	t.Run("invalid room id", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		_, err := l.WithinHops(context.Background(), "42", 2)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to list rooms within hops: invalid argument: invalid room id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(withinHopsQ).WithArgs(roomID, 2).WillReturnError(errors.New("query error"))
+
+		_, err := l.WithinHops(context.Background(), roomID.String(), 2)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to list rooms within hops: internal error: query error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		// A small graph: room -> a -> b, room -> b, b -> c, c -> room.
+		a, b, c := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+		l, mock := setupLinks(t)
+		rows := sqlmock.NewRows([]string{"room_id", "min"}).
+			AddRow(a, 1).
+			AddRow(b, 1).
+			AddRow(c, 2)
+		mock.ExpectQuery(withinHopsQ).WithArgs(roomID, 2).WillReturnRows(rows)
+
+		rooms, err := l.WithinHops(context.Background(), roomID.String(), 2)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := []arcade.RoomHops{{RoomID: a, Hops: 1}, {RoomID: b, Hops: 1}, {RoomID: c, Hops: 2}}
+		if len(rooms) != len(expected) {
+			t.Fatalf("Unexpected rooms: %+v", rooms)
+		}
+		for i := range expected {
+			if rooms[i] != expected[i] {
+				t.Errorf("Unexpected room %d: %+v", i, rooms[i])
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("cyclic graph", func(t *testing.T) {
+		// Two way links: room <-> a <-> b, followed far beyond the rooms
+		// there are, each room is reached once along a path not revisiting
+		// another.
+		a, b := uuid.NewString(), uuid.NewString()
+
+		l, mock := setupLinks(t)
+		rows := sqlmock.NewRows([]string{"room_id", "min"}).
+			AddRow(a, 1).
+			AddRow(b, 2)
+		mock.ExpectQuery(withinHopsQ).WithArgs(roomID, 50).WillReturnRows(rows)
+
+		rooms, err := l.WithinHops(context.Background(), roomID.String(), 50)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := []arcade.RoomHops{{RoomID: a, Hops: 1}, {RoomID: b, Hops: 2}}
+		if len(rooms) != len(expected) {
+			t.Fatalf("Unexpected rooms: %+v", rooms)
+		}
+		for i := range expected {
+			if rooms[i] != expected[i] {
+				t.Errorf("Unexpected room %d: %+v", i, rooms[i])
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksRoute(t *testing.T) {
+	const exitsQ = `^SELECT (.+) FROM links WHERE location_id = ANY\(\$1\)$`
+
+	var (
+		columns = []string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}
+		ownerID = uuid.NewString()
+		created = time.Now()
+	)
+	// A chain of rooms: from -> a -> to, with a dead end from -> b.
+	from, a, b, to := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	link := func(rows *sqlmock.Rows, id, locationID, destinationID string) *sqlmock.Rows {
+		return rows.AddRow(id, id, "A link.", ownerID, locationID, destinationID, 0, arcade.DefaultActor, created, created)
+	}
+
+	t.Run("invalid room id", func(t *testing.T) {
+		l, _ := setupLinks(t)
+
+		_, err := l.Route(context.Background(), from, "42", 5)
+
+		expected := "failed to find route: invalid argument: invalid room id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(exitsQ).WillReturnError(errors.New("query error"))
+
+		_, err := l.Route(context.Background(), from, to, 5)
+
+		expected := "failed to find route: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(exitsQ).WillReturnRows(link(link(sqlmock.NewRows(columns), "l1", from, a), "l2", from, b))
+		mock.ExpectQuery(exitsQ).WillReturnRows(link(sqlmock.NewRows(columns), "l3", a, to))
+
+		route, err := l.Route(context.Background(), from, to, 5)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(route.Rooms) != 3 || route.Rooms[0] != from || route.Rooms[1] != a || route.Rooms[2] != to {
+			t.Errorf("Unexpected rooms: %v", route.Rooms)
+		}
+		if len(route.Links) != 2 || route.Links[0] != "l1" || route.Links[1] != "l3" {
+			t.Errorf("Unexpected links: %v", route.Links)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("beyond max hops", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(exitsQ).WillReturnRows(link(sqlmock.NewRows(columns), "l1", from, a))
+
+		_, err := l.Route(context.Background(), from, to, 1)
+
+		expected := "not found: no route from room '" + from + "' to room '" + to + "'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestLinksFindDangling(t *testing.T) {
+	const danglingQ = `^SELECT (.+) FROM links ` +
+		`WHERE NOT EXISTS \(SELECT 1 FROM rooms WHERE rooms.room_id = links.location_id\) ` +
+		`OR NOT EXISTS \(SELECT 1 FROM rooms WHERE rooms.room_id = links.destination_id\) ` +
+		`ORDER BY created ASC LIMIT 10000$`
+
+	var (
+		columns    = []string{"link_id", "name", "description", "owner_id", "location_id", "destination_id", "capacity", "created_by", "created", "updated"}
+		linkID     = uuid.NewString()
+		ownerID    = uuid.NewString()
+		locationID = uuid.NewString()
+		missingID  = uuid.NewString()
+		created    = time.Now()
+	)
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(danglingQ).WillReturnError(errors.New("query error"))
+
+		_, err := l.FindDangling(context.Background())
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to find dangling links: internal error: query error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		rows := sqlmock.NewRows(columns).
+			AddRow(linkID, "Door", "A door.", ownerID, locationID, missingID, 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(danglingQ).WillReturnRows(rows)
+
+		links, err := l.FindDangling(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(links) != 1 || links[0].ID != linkID || links[0].DestinationID != missingID {
+			t.Errorf("Unexpected links: %+v", links)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("none dangling", func(t *testing.T) {
+		l, mock := setupLinks(t)
+		mock.ExpectQuery(danglingQ).WillReturnRows(sqlmock.NewRows(columns))
+
+		links, err := l.FindDangling(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if links == nil || len(links) != 0 {
+			t.Errorf("Unexpected links: %+v", links)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
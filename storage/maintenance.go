@@ -0,0 +1,68 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	cerrors "arcadium.dev/core/errors"
+	"arcadium.dev/core/log"
+
+	"arcadium.dev/arcade"
+)
+
+// EntityTables are the tables holding the assets, in the order they are
+// maintained.
+var EntityTables = []string{"players", "rooms", "links", "items"}
+
+type (
+	// Maintenance is used to maintain the persistent storage.
+	Maintenance struct {
+		DB     *sql.DB
+		Driver arcade.StorageDriver
+	}
+)
+
+// Analyze refreshes the statistics of each entity table, so that query plans
+// reflect its current contents. A table failing to be analyzed does not stop
+// the others. Analyze is a no-op when the driver does not support it.
+func (p Maintenance) Analyze(ctx context.Context) ([]arcade.TableAnalysis, error) {
+	failMsg := "failed to analyze tables"
+
+	logger := log.LoggerFromContext(ctx)
+	logger.Info("msg", "analyze tables")
+
+	results := make([]arcade.TableAnalysis, 0, len(EntityTables))
+	for _, table := range EntityTables {
+		query := p.Driver.AnalyzeQuery(table)
+		if query == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+
+		result := arcade.TableAnalysis{Table: table, Analyzed: true}
+		if _, err := p.DB.ExecContext(ctx, query); err != nil {
+			logger.Error("msg", "failed to analyze table", "table", table, "error", err.Error())
+			result.Analyzed, result.Error = false, err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,94 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"arcadium.dev/arcade/storage"
+	"arcadium.dev/arcade/storage/cockroach"
+)
+
+// noAnalyzeDriver is a driver without support for analyzing tables.
+type noAnalyzeDriver struct {
+	cockroach.Driver
+}
+
+func (noAnalyzeDriver) AnalyzeQuery(string) string { return "" }
+
+func TestMaintenanceAnalyze(t *testing.T) {
+	t.Run("analyze each table", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal("Failed to create sqlmock db")
+		}
+		m := storage.Maintenance{DB: db, Driver: cockroach.Driver{}}
+
+		mock.ExpectExec("^ANALYZE players$").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("^ANALYZE rooms$").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("^ANALYZE links$").WillReturnError(errors.New("analyze error"))
+		mock.ExpectExec("^ANALYZE items$").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tables, err := m.Analyze(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(tables) != len(storage.EntityTables) {
+			t.Fatalf("Unexpected tables: %+v", tables)
+		}
+		for i, table := range tables {
+			if table.Table != storage.EntityTables[i] {
+				t.Errorf("Unexpected table: %s", table.Table)
+			}
+			failed := table.Table == "links"
+			if table.Analyzed == failed {
+				t.Errorf("Unexpected analyzed for %s: %t", table.Table, table.Analyzed)
+			}
+			if failed && table.Error != "analyze error" {
+				t.Errorf("Unexpected error for %s: %s", table.Table, table.Error)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal("Failed to create sqlmock db")
+		}
+		m := storage.Maintenance{DB: db, Driver: noAnalyzeDriver{}}
+
+		tables, err := m.Analyze(context.Background())
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(tables) != 0 {
+			t.Errorf("Unexpected tables: %+v", tables)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"database/sql"
+)
+
+type (
+	// nullString scans a nullable column into a string, a null becoming
+	// the empty string. An item's inventory_id is null once the player
+	// holding it is removed, or its dangling inventory is fixed.
+	nullString struct {
+		s *string
+	}
+
+	// nullInt scans a nullable column into an int, a null becoming zero.
+	// An item's weight is null unless set.
+	nullInt struct {
+		i *int
+	}
+)
+
+// Scan implements the sql.Scanner interface.
+func (n nullString) Scan(src interface{}) error {
+	var ns sql.NullString
+	if err := ns.Scan(src); err != nil {
+		return err
+	}
+	*n.s = ns.String
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (n nullInt) Scan(src interface{}) error {
+	var ni sql.NullInt64
+	if err := ni.Scan(src); err != nil {
+		return err
+	}
+	*n.i = int(ni.Int64)
+	return nil
+}
@@ -0,0 +1,135 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"arcadium.dev/core/log"
+)
+
+type (
+	// Pinger is implemented by a *sql.DB.
+	Pinger interface {
+		PingContext(ctx context.Context) error
+	}
+
+	// PingCache is a pinger which caches the outcome of a ping, whether
+	// success or failure, for a short time to live, so rapid readiness
+	// probes reuse a recent ping rather than each pinging the database. A
+	// failure is seen by the probes within the time to live. A ping cut
+	// short by the context of its probe is not cached.
+	//
+	// Probes arriving while a ping is in flight wait for it, each for no
+	// longer than its own context allows.
+	PingCache struct {
+		db  Pinger
+		ttl time.Duration
+
+		mu       sync.Mutex
+		err      error
+		expires  time.Time
+		inflight *inflightPing
+	}
+
+	// inflightPing is a ping in flight, done once it returns.
+	inflightPing struct {
+		done chan struct{}
+		err  error
+
+		// cut is set when the ping was cut short by the context of the
+		// probe making it, so its outcome is not that of the database.
+		cut bool
+	}
+)
+
+// NewPingCache returns a cache of the pings of the given pinger, with the
+// outcome of a ping cached for the given time to live.
+func NewPingCache(db Pinger, ttl time.Duration) *PingCache {
+	return &PingCache{db: db, ttl: ttl}
+}
+
+// PingContext returns the outcome of the last ping while it is cached, and
+// otherwise pings the database, or waits for the ping in flight.
+func (c *PingCache) PingContext(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if time.Now().Before(c.expires) {
+			err := c.err
+			c.mu.Unlock()
+			return err
+		}
+		p := c.inflight
+		if p == nil {
+			p = &inflightPing{done: make(chan struct{})}
+			c.inflight = p
+			c.mu.Unlock()
+			return c.ping(ctx, p)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.done:
+			if !p.cut {
+				return p.err
+			}
+			// The probe making the ping gave up, ping again.
+		}
+	}
+}
+
+// ping pings the database as the given ping in flight, caching its outcome
+// unless the ping is cut short by the given context.
+func (c *PingCache) ping(ctx context.Context, p *inflightPing) error {
+	err := c.db.PingContext(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p.err, p.cut = err, ctx.Err() != nil
+	if !p.cut {
+		// A probe giving up says nothing of the database.
+		c.err = err
+		c.expires = time.Now().Add(c.ttl)
+	}
+	c.inflight = nil
+	close(p.done)
+	return err
+}
+
+// Ping periodically pings the database at the given interval, keeping the
+// connection pool warm and evicting dead connections, until the context is
+// cancelled. A failed ping is logged, and pinging continues.
+func Ping(ctx context.Context, db Pinger, interval time.Duration) {
+	logger := log.LoggerFromContext(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := db.PingContext(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("msg", "failed to ping db", "error", err.Error())
+			}
+		}
+	}
+}
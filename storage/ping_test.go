@@ -0,0 +1,232 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade/storage"
+)
+
+type mockPinger struct {
+	pings int32
+	err   error
+
+	// block, when set, holds a ping until it is closed or the ping's
+	// context is done.
+	block chan struct{}
+}
+
+func (m *mockPinger) PingContext(ctx context.Context) error {
+	atomic.AddInt32(&m.pings, 1)
+	if m.block != nil {
+		select {
+		case <-m.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return m.err
+}
+
+func TestPing(t *testing.T) {
+	m := &mockPinger{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		storage.Ping(ctx, m, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&m.pings) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if atomic.LoadInt32(&m.pings) < 2 {
+		t.Fatal("Expected the db to be pinged")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the pinger to stop on context cancellation")
+	}
+
+	pings := atomic.LoadInt32(&m.pings)
+	time.Sleep(10 * time.Millisecond)
+	if atomic.LoadInt32(&m.pings) != pings {
+		t.Error("Unexpected ping after the pinger stopped")
+	}
+}
+
+func TestPingCache(t *testing.T) {
+	t.Run("within ttl", func(t *testing.T) {
+		m := &mockPinger{}
+		c := storage.NewPingCache(m, time.Hour)
+
+		if err := c.PingContext(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := c.PingContext(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if pings := atomic.LoadInt32(&m.pings); pings != 1 {
+			t.Errorf("Expected a single ping, actual pings: %d", pings)
+		}
+	})
+
+	t.Run("failure cached", func(t *testing.T) {
+		m := &mockPinger{err: errors.New("ping error")}
+		c := storage.NewPingCache(m, time.Hour)
+
+		for i := 0; i < 2; i++ {
+			if err := c.PingContext(context.Background()); err == nil || err.Error() != "ping error" {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		}
+
+		if pings := atomic.LoadInt32(&m.pings); pings != 1 {
+			t.Errorf("Expected a single ping, actual pings: %d", pings)
+		}
+	})
+
+	t.Run("probe cancelled", func(t *testing.T) {
+		m := &mockPinger{err: context.Canceled}
+		c := storage.NewPingCache(m, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := c.PingContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		// The next probe pings again rather than see the cancellation.
+		m.err = nil
+		if err := c.PingContext(context.Background()); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if pings := atomic.LoadInt32(&m.pings); pings != 2 {
+			t.Errorf("Expected two pings, actual pings: %d", pings)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		m := &mockPinger{}
+		c := storage.NewPingCache(m, time.Millisecond)
+
+		if err := c.PingContext(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		m.err = errors.New("ping error")
+		if err := c.PingContext(context.Background()); err == nil {
+			t.Error("Expected the failure to be seen once the ping expired")
+		}
+
+		if pings := atomic.LoadInt32(&m.pings); pings != 2 {
+			t.Errorf("Expected two pings, actual pings: %d", pings)
+		}
+	})
+
+	t.Run("waiters share the ping in flight", func(t *testing.T) {
+		m := &mockPinger{block: make(chan struct{})}
+		c := storage.NewPingCache(m, time.Hour)
+
+		errs := make(chan error, 3)
+		for i := 0; i < 3; i++ {
+			go func() { errs <- c.PingContext(context.Background()) }()
+		}
+		waitForPings(t, m, 1)
+		close(m.block)
+
+		for i := 0; i < 3; i++ {
+			if err := <-errs; err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		}
+		if pings := atomic.LoadInt32(&m.pings); pings != 1 {
+			t.Errorf("Expected a single ping, actual pings: %d", pings)
+		}
+	})
+
+	t.Run("waiter deadline", func(t *testing.T) {
+		m := &mockPinger{block: make(chan struct{})}
+		defer close(m.block)
+		c := storage.NewPingCache(m, time.Hour)
+
+		go func() { _ = c.PingContext(context.Background()) }()
+		waitForPings(t, m, 1)
+
+		// A waiter gives up at its own deadline, not once the ping returns.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- c.PingContext(ctx) }()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the waiter to give up at its deadline")
+		}
+	})
+
+	t.Run("ping in flight cut short", func(t *testing.T) {
+		m := &mockPinger{block: make(chan struct{})}
+		c := storage.NewPingCache(m, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error, 1)
+		go func() { first <- c.PingContext(ctx) }()
+		waitForPings(t, m, 1)
+
+		second := make(chan error, 1)
+		go func() { second <- c.PingContext(context.Background()) }()
+
+		// The waiter pings again once the probe in flight gives up, rather
+		// than see its cancellation.
+		cancel()
+		if err := <-first; !errors.Is(err, context.Canceled) {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		waitForPings(t, m, 2)
+		close(m.block)
+		if err := <-second; err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+}
+
+func waitForPings(t *testing.T, m *mockPinger, pings int32) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&m.pings) < pings && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if actual := atomic.LoadInt32(&m.pings); actual < pings {
+		t.Fatalf("Expected %d pings, actual pings: %d", pings, actual)
+	}
+}
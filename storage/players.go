@@ -33,6 +33,31 @@ type (
 	Players struct {
 		DB     *sql.DB
 		Driver arcade.StorageDriver
+
+		// Names restricts the names of created and updated players.
+		Names arcade.NamePolicy
+
+		// RoomNames restricts the names of the rooms created by a spawn.
+		RoomNames arcade.NamePolicy
+
+		// RoomsCache, when set, is invalidated by the room created by a
+		// spawn.
+		RoomsCache *RoomsCache
+
+		// Timeouts are the time limits of the player operations.
+		Timeouts Timeouts
+
+		// World limits the number of entities created players join.
+		World WorldLimit
+
+		// RequireHome, when set, rejects a created or updated player without
+		// a home. Otherwise such a player is homed in Limbo.
+		RequireHome bool
+
+		// ReturnExisting, when set, answers a create of a taken player name
+		// with the player holding the name, rather than an already exists
+		// error.
+		ReturnExisting bool
 	}
 )
 
@@ -40,10 +65,36 @@ type (
 func (p Players) List(ctx context.Context, filter arcade.PlayersFilter) ([]arcade.Player, error) {
 	failMsg := "failed to list players"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "list players")
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.PlayersListQuery(filter)), listArgs(filter.CreatedBy)...)
+}
+
+// GetMany returns the players of the given playerIDs which exist, in no
+// particular order.
+func (p Players) GetMany(ctx context.Context, playerIDs []string) ([]arcade.Player, error) {
+	failMsg := "failed to get players"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("playerIDs", len(playerIDs)).Info("msg", "get players")
+
+	ids, err := parseIDs("player", playerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.PlayersGetManyQuery()), uuidArray(ids))
+}
+
+func (p Players) list(ctx context.Context, failMsg, query string, args ...interface{}) ([]arcade.Player, error) {
 	logger := log.LoggerFromContext(ctx)
-	logger.Info("msg", "list players")
 
-	rows, err := p.DB.QueryContext(ctx, p.Driver.PlayersListQuery(filter))
+	rows, err := p.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
@@ -62,6 +113,8 @@ func (p Players) List(ctx context.Context, filter arcade.PlayersFilter) ([]arcad
 			&player.Description,
 			&player.HomeID,
 			&player.LocationID,
+			&player.InventoryCapacity,
+			&player.CreatedBy,
 			&player.Created,
 			&player.Updated,
 		)
@@ -73,6 +126,9 @@ func (p Players) List(ctx context.Context, filter arcade.PlayersFilter) ([]arcad
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
+	if max := p.Driver.ListRowsCap(); len(players) >= max {
+		logger.Warn("msg", "list reached the maximum rows", "max", max)
+	}
 
 	return players, nil
 }
@@ -81,6 +137,9 @@ func (p Players) List(ctx context.Context, filter arcade.PlayersFilter) ([]arcad
 func (p Players) Get(ctx context.Context, playerID string) (arcade.Player, error) {
 	failMsg := "failed to get player"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("playerID", playerID).Info("msg", "get player")
 
 	pid, err := uuid.Parse(playerID)
@@ -89,12 +148,14 @@ func (p Players) Get(ctx context.Context, playerID string) (arcade.Player, error
 	}
 
 	var player arcade.Player
-	err = p.DB.QueryRowContext(ctx, p.Driver.PlayersGetQuery(), pid).Scan(
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.PlayersGetQuery()), pid).Scan(
 		&player.ID,
 		&player.Name,
 		&player.Description,
 		&player.HomeID,
 		&player.LocationID,
+		&player.InventoryCapacity,
+		&player.CreatedBy,
 		&player.Created,
 		&player.Updated,
 	)
@@ -112,13 +173,28 @@ func (p Players) Get(ctx context.Context, playerID string) (arcade.Player, error
 func (p Players) Create(ctx context.Context, req arcade.PlayerRequest) (arcade.Player, error) {
 	failMsg := "failed to create player"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("name", req.Name)
 	logger.Info("msg", "create player")
 
+	if req.HomeID == "" {
+		if p.RequireHome {
+			return arcade.Player{}, fmt.Errorf("%s: %w: player home is required", failMsg, cerrors.ErrInvalidArgument)
+		}
+		req.HomeID = arcade.LimboRoomID
+	}
 	homeID, locationID, err := req.Validate()
 	if err != nil {
 		return arcade.Player{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.World.check(ctx, p.DB, p.Driver, 1); err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
 
 	var player arcade.Player
 	err = p.DB.QueryRowContext(ctx, p.Driver.PlayersCreateQuery(),
@@ -126,12 +202,16 @@ func (p Players) Create(ctx context.Context, req arcade.PlayerRequest) (arcade.P
 		req.Description,
 		homeID,
 		locationID,
+		req.InventoryCapacity,
+		arcade.ActorFromContext(ctx),
 	).Scan(
 		&player.ID,
 		&player.Name,
 		&player.Description,
 		&player.HomeID,
 		&player.LocationID,
+		&player.InventoryCapacity,
+		&player.CreatedBy,
 		&player.Created,
 		&player.Updated,
 	)
@@ -147,8 +227,13 @@ func (p Players) Create(ctx context.Context, req arcade.PlayerRequest) (arcade.P
 
 	// A UniqueViolation means the inserted player violated a uniqueness
 	// constraint. The player record already exists in the table or the name
-	// is not unique.
+	// is not unique. The constraint, rather than a prior read, decides
+	// between concurrent creates of the same name, so exactly one of them
+	// inserts the player.
 	if p.Driver.IsUniqueViolation(err) {
+		if p.ReturnExisting {
+			return p.existing(ctx, failMsg, req.Name)
+		}
 		return arcade.Player{}, fmt.Errorf("%s: %w: player already exists", failMsg, cerrors.ErrAlreadyExists)
 	}
 
@@ -160,10 +245,41 @@ func (p Players) Create(ctx context.Context, req arcade.PlayerRequest) (arcade.P
 	return player, nil
 }
 
+// existing returns the player with the given name, the player a create of
+// the name lost to.
+func (p Players) existing(ctx context.Context, failMsg, name string) (arcade.Player, error) {
+	var player arcade.Player
+	err := p.DB.QueryRowContext(ctx, p.Driver.PlayersGetByNameQuery(), name).Scan(
+		&player.ID,
+		&player.Name,
+		&player.Description,
+		&player.HomeID,
+		&player.LocationID,
+		&player.InventoryCapacity,
+		&player.CreatedBy,
+		&player.Created,
+		&player.Updated,
+	)
+
+	// The player holding the name was removed since the insert failed.
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.Player{}, fmt.Errorf("%s: %w: player already exists", failMsg, cerrors.ErrAlreadyExists)
+	}
+	if err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	log.LoggerFromContext(ctx).With("playerID", player.ID).Info("msg", "returned existing player")
+	return player, nil
+}
+
 // Update a player given the player request, returning the updated player.
 func (p Players) Update(ctx context.Context, playerID string, req arcade.PlayerRequest) (arcade.Player, error) {
 	failMsg := "failed to update player"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("playerID", playerID, "name", req.Name)
 	logger.Info("msg", "update player")
 
@@ -171,24 +287,59 @@ func (p Players) Update(ctx context.Context, playerID string, req arcade.PlayerR
 	if err != nil {
 		return arcade.Player{}, fmt.Errorf("%s: %w: invalid player id: '%s'", failMsg, cerrors.ErrInvalidArgument, playerID)
 	}
+	if req.HomeID == "" {
+		if p.RequireHome {
+			return arcade.Player{}, fmt.Errorf("%s: %w: player home is required", failMsg, cerrors.ErrInvalidArgument)
+		}
+		req.HomeID = arcade.LimboRoomID
+	}
 	homeID, locationID, err := req.Validate()
 	if err != nil {
 		return arcade.Player{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback update", "error", err.Error())
+		}
+	}()
+
+	// Enforce the capacity of the player's location. The room is locked until
+	// the end of the transaction, so concurrent updates cannot overfill it. A
+	// location that does not exist is reported by the update's foreign key
+	// violation.
+	var capacity, occupants int
+	err = tx.QueryRowContext(ctx, p.Driver.PlayersRoomOccupancyQuery(), locationID, pid).Scan(&capacity, &occupants)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return arcade.Player{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if err == nil && capacity > 0 && occupants >= capacity {
+		return arcade.Player{}, fmt.Errorf("%s: %w: room is at capacity: '%s'", failMsg, arcade.ErrConflict, req.LocationID)
+	}
 
 	var player arcade.Player
-	err = p.DB.QueryRowContext(ctx, p.Driver.PlayersUpdateQuery(),
+	err = tx.QueryRowContext(ctx, p.Driver.PlayersUpdateQuery(),
 		pid,
 		req.Name,
 		req.Description,
 		homeID,
 		locationID,
+		req.InventoryCapacity,
 	).Scan(
 		&player.ID,
 		&player.Name,
 		&player.Description,
 		&player.HomeID,
 		&player.LocationID,
+		&player.InventoryCapacity,
+		&player.CreatedBy,
 		&player.Created,
 		&player.Updated,
 	)
@@ -217,13 +368,101 @@ func (p Players) Update(ctx context.Context, playerID string, req arcade.PlayerR
 		return arcade.Player{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err.Error())
 	}
 
+	if err := tx.Commit(); err != nil {
+		return arcade.Player{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
 	return player, nil
 }
 
+// Spawn creates a room given the room request and moves the given player into
+// it, in a single transaction, so neither happens unless both do.
+func (p Players) Spawn(ctx context.Context, playerID string, req arcade.RoomRequest) (arcade.PlayerSpawn, error) {
+	failMsg := "failed to spawn player"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("playerID", playerID, "name", req.Name)
+	logger.Info("msg", "spawn player")
+
+	pid, err := uuid.Parse(playerID)
+	if err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w: invalid player id: '%s'", failMsg, cerrors.ErrInvalidArgument, playerID)
+	}
+	ownerID, parentID, err := req.Validate()
+	if err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.RoomNames.Validate(req.Name); err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback spawn", "error", err.Error())
+		}
+	}()
+
+	// Check the player before creating a room for them.
+	var exists bool
+	if err := tx.QueryRowContext(ctx, p.Driver.PlayersExistsQuery(), pid).Scan(&exists); err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if !exists {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err := p.World.check(ctx, tx, p.Driver, 1); err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	var spawn arcade.PlayerSpawn
+	spawn.Room, err = createRoom(ctx, tx, p.Driver, req, ownerID, parentID)
+	if err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	// The room is new, so no capacity is exceeded by its first occupant.
+	err = tx.QueryRowContext(ctx, p.Driver.PlayersSetLocationQuery(), pid, spawn.Room.ID).Scan(
+		&spawn.Player.ID,
+		&spawn.Player.Name,
+		&spawn.Player.Description,
+		&spawn.Player.HomeID,
+		&spawn.Player.LocationID,
+		&spawn.Player.InventoryCapacity,
+		&spawn.Player.CreatedBy,
+		&spawn.Player.Created,
+		&spawn.Player.Updated,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return arcade.PlayerSpawn{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if p.RoomsCache != nil {
+		p.RoomsCache.invalidate()
+	}
+
+	logger.With("roomID", spawn.Room.ID).Info("msg", "spawned player")
+	return spawn, nil
+}
+
 // Remove deletes the given player from persistent storage.
 func (p Players) Remove(ctx context.Context, playerID string) error {
 	failMsg := "failed to remove player"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpRemove)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("playerID", playerID).Info("msg", "remove player")
 
 	pid, err := uuid.Parse(playerID)
@@ -241,3 +480,31 @@ func (p Players) Remove(ctx context.Context, playerID string) error {
 
 	return nil
 }
+
+// UpdateLastSeen sets the last seen time of the given player to now. This is
+// a single column write, intended to be called on every player presence
+// change.
+func (p Players) UpdateLastSeen(ctx context.Context, playerID string) error {
+	failMsg := "failed to update player last seen"
+
+	log.LoggerFromContext(ctx).With("playerID", playerID).Info("msg", "update player last seen")
+
+	pid, err := uuid.Parse(playerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w: invalid player id: '%s'", failMsg, cerrors.ErrInvalidArgument, playerID)
+	}
+
+	result, err := p.DB.ExecContext(ctx, p.Driver.PlayersUpdateLastSeenQuery(), pid)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+
+	return nil
+}
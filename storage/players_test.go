@@ -18,6 +18,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -26,6 +28,8 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 
+	cerrors "arcadium.dev/core/errors"
+
 	"arcadium.dev/arcade"
 	"arcadium.dev/arcade/storage"
 	"arcadium.dev/arcade/storage/cockroach"
@@ -33,7 +37,7 @@ import (
 
 func TestPlayersList(t *testing.T) {
 	const (
-		listQ = "^SELECT player_id, name, description, home_id, location_id, created, updated FROM players$"
+		listQ = "^SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players ORDER BY created ASC LIMIT 10000$"
 	)
 
 	var (
@@ -66,11 +70,26 @@ func TestPlayersList(t *testing.T) {
 		}
 	})
 
+	t.Run("created by bound", func(t *testing.T) {
+		createdBy := "o'brien"
+		p, mock := setupPlayers(t)
+		mock.ExpectQuery(`^SELECT (.+) FROM players WHERE created_by = \$1 ORDER BY created ASC LIMIT 10000$`).
+			WithArgs(createdBy).
+			WillReturnRows(sqlmock.NewRows([]string{"player_id"}))
+
+		if _, err := p.List(context.Background(), arcade.PlayersFilter{CreatedBy: &createdBy}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
 	t.Run("sql scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"player_id", "name", "description", "home_id", "location_id", "created", "updated",
+			"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated",
 		}).
-			AddRow(id, name, description, homeID, locationID, created, updated).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		p, mock := setupPlayers(t)
@@ -94,8 +113,8 @@ func TestPlayersList(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		rows := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(listQ).
@@ -124,9 +143,61 @@ func TestPlayersList(t *testing.T) {
 	})
 }
 
+func TestPlayersGetMany(t *testing.T) {
+	const getManyQ = `^SELECT (.+) FROM players WHERE player_id = ANY\(\$1\)$`
+
+	var (
+		id      = uuid.NewString()
+		otherID = uuid.NewString()
+		created = time.Now()
+	)
+
+	t.Run("invalid playerID", func(t *testing.T) {
+		l, _ := setupPlayers(t)
+
+		_, err := l.GetMany(context.Background(), []string{id, "42"})
+
+		expected := "failed to get players: invalid argument: invalid player id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupPlayers(t)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "}").WillReturnError(errors.New("query error"))
+
+		_, err := l.GetMany(context.Background(), []string{id})
+
+		expected := "failed to get players: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupPlayers(t)
+		rows := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, "Nobody", "No one of importance.", uuid.NewString(), uuid.NewString(), 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "," + otherID + "}").WillReturnRows(rows)
+
+		players, err := l.GetMany(context.Background(), []string{id, otherID})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(players) != 1 || players[0].ID != id {
+			t.Errorf("Unexpected players: %+v", players)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
 func TestPlayersGet(t *testing.T) {
 	const (
-		getQ = "^SELECT player_id, name, description, home_id, location_id, created, updated FROM players WHERE player_id = (.+)$"
+		getQ = "^SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players WHERE player_id = (.+)$"
 	)
 
 	var (
@@ -192,8 +263,8 @@ func TestPlayersGet(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		rows := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(getQ).WillReturnRows(rows)
@@ -219,9 +290,9 @@ func TestPlayersGet(t *testing.T) {
 
 func TestPlayersCreate(t *testing.T) {
 	const (
-		createQ = `^INSERT INTO players \(name, description, home_id, location_id\) ` +
+		createQ = `^INSERT INTO players \(name, description, home_id, location_id, inventory_capacity, created_by\) ` +
 			`VALUES \((.+), (.+), (.+), (.+)\) ` +
-			`RETURNING player_id, name, description, home_id, location_id, created, updated$`
+			`RETURNING player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated$`
 	)
 
 	var (
@@ -250,6 +321,24 @@ func TestPlayersCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("configured max name length", func(t *testing.T) {
+		n := ""
+		for i := 0; i < 9; i++ {
+			n += "a"
+		}
+		req := arcade.PlayerRequest{Name: n, Description: description, HomeID: homeID, LocationID: locationID}
+
+		p, _ := setupPlayers(t)
+		p.Names.MaxLen = 8
+
+		_, err := p.Create(context.Background(), req)
+
+		expected := "failed to create player: invalid argument: name exceeds maximum length of 8"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("long name", func(t *testing.T) {
 		n := ""
 		for i := 0; i <= arcade.MaxPlayerNameLen; i++ {
@@ -322,6 +411,47 @@ func TestPlayersCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("omitted home required", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, LocationID: locationID}
+
+		p, _ := setupPlayers(t)
+		p.RequireHome = true
+
+		_, err := p.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create player: invalid argument: player home is required"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("omitted home", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, LocationID: locationID}
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, arcade.LimboRoomID, locationID, 0, arcade.DefaultActor, created, updated)
+
+		p, mock := setupPlayers(t)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, arcade.LimboRoomID, locationID, 0, arcade.DefaultActor).
+			WillReturnRows(row)
+
+		player, err := p.Create(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if player.HomeID != arcade.LimboRoomID {
+			t.Errorf("Unexpected homeID: %s", player.HomeID)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
 	t.Run("invalid location", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: "42"}
 
@@ -340,12 +470,12 @@ func TestPlayersCreate(t *testing.T) {
 
 	t.Run("foreign key voilation", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, homeID, locationID).
+			WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
 
@@ -367,12 +497,12 @@ func TestPlayersCreate(t *testing.T) {
 
 	t.Run("unique violation", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, homeID, locationID).
+			WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 
@@ -391,15 +521,143 @@ func TestPlayersCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("return existing", func(t *testing.T) {
+		const getByNameQ = `^SELECT player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated FROM players WHERE name = \$1$`
+
+		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
+
+		t.Run("found", func(t *testing.T) {
+			existingID := uuid.NewString()
+			row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+				AddRow(existingID, name, "Someone else.", homeID, locationID, 0, "player:42", created, updated)
+
+			p, mock := setupPlayers(t)
+			p.ReturnExisting = true
+			mock.ExpectQuery(createQ).
+				WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
+				WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+			mock.ExpectQuery(getByNameQ).WithArgs(name).WillReturnRows(row)
+
+			player, err := p.Create(context.Background(), req)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if player.ID != existingID || player.Description != "Someone else." {
+				t.Errorf("Unexpected player: %+v", player)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("removed", func(t *testing.T) {
+			p, mock := setupPlayers(t)
+			p.ReturnExisting = true
+			mock.ExpectQuery(createQ).
+				WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
+				WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+			mock.ExpectQuery(getByNameQ).WithArgs(name).WillReturnError(sql.ErrNoRows)
+
+			_, err := p.Create(context.Background(), req)
+
+			expected := "failed to create player: already exists: player already exists"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("get error", func(t *testing.T) {
+			p, mock := setupPlayers(t)
+			p.ReturnExisting = true
+			mock.ExpectQuery(createQ).
+				WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
+				WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+			mock.ExpectQuery(getByNameQ).WithArgs(name).WillReturnError(errors.New("unknown error"))
+
+			_, err := p.Create(context.Background(), req)
+
+			expected := "failed to create player: internal error: unknown error"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+		})
+	})
+
+	t.Run("concurrent duplicates", func(t *testing.T) {
+		const getByNameQ = `^SELECT (.+) FROM players WHERE name = \$1$`
+
+		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
+
+		for _, returnExisting := range []bool{false, true} {
+			returnExisting := returnExisting
+			t.Run(fmt.Sprintf("return existing %t", returnExisting), func(t *testing.T) {
+				row := func() *sqlmock.Rows {
+					return sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+						AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
+				}
+
+				// The pre-checks of both creates pass, the unique constraint
+				// admits whichever insert arrives first.
+				p, mock := setupPlayers(t)
+				p.ReturnExisting = returnExisting
+				mock.MatchExpectationsInOrder(false)
+				mock.ExpectQuery(createQ).WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).WillReturnRows(row())
+				mock.ExpectQuery(createQ).WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
+					WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+				if returnExisting {
+					mock.ExpectQuery(getByNameQ).WithArgs(name).WillReturnRows(row())
+				}
+
+				var (
+					wg      sync.WaitGroup
+					players [2]arcade.Player
+					errs    [2]error
+				)
+				for i := range players {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						players[i], errs[i] = p.Create(context.Background(), req)
+					}(i)
+				}
+				wg.Wait()
+
+				failed := 0
+				for i, err := range errs {
+					switch {
+					case err == nil:
+						if players[i].ID != id {
+							t.Errorf("Unexpected player: %+v", players[i])
+						}
+					case errors.Is(err, cerrors.ErrAlreadyExists) && !returnExisting:
+						failed++
+					default:
+						t.Errorf("Unexpected error: %s", err)
+					}
+				}
+				if !returnExisting && failed != 1 {
+					t.Errorf("Unexpected failed creates: %d", failed)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("Unexpected err: %s", err)
+				}
+			})
+		}
+	})
+
 	t.Run("scan error", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated).
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, homeID, locationID).
+			WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		_, err := p.Create(context.Background(), req)
@@ -419,12 +677,12 @@ func TestPlayersCreate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, homeID, locationID).
+			WithArgs(name, description, homeID, locationID, 0, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		player, err := p.Create(context.Background(), req)
@@ -451,7 +709,9 @@ func TestPlayersUpdate(t *testing.T) {
 		// updateQ = `^UPDATE players SET (.+) WHERE (.+) RETURNING (.+)$`
 		updateQ = `^UPDATE players SET name = (.+), description = (.+), home_id = (.+), location_id = (.+) ` +
 			`WHERE player_id = (.+) ` +
-			`RETURNING player_id, name, description, home_id, location_id, created, updated$`
+			`RETURNING player_id, name, description, home_id, location_id, inventory_capacity, created_by, created, updated$`
+		occupancyQ = `^SELECT capacity, \(SELECT count\(\*\) FROM players WHERE location_id = \$1 AND player_id != \$2\) ` +
+			`FROM rooms WHERE room_id = \$1 FOR UPDATE$`
 	)
 
 	var (
@@ -464,6 +724,13 @@ func TestPlayersUpdate(t *testing.T) {
 		updated     = time.Now()
 	)
 
+	expectOccupancy := func(mock sqlmock.Sqlmock, capacity, occupants int) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(occupancyQ).
+			WithArgs(locationID, id).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "count"}).AddRow(capacity, occupants))
+	}
+
 	t.Run("invalid player id", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
 
@@ -568,6 +835,49 @@ func TestPlayersUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("omitted home required", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, LocationID: locationID}
+
+		p, _ := setupPlayers(t)
+		p.RequireHome = true
+
+		_, err := p.Update(context.Background(), id, req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update player: invalid argument: player home is required"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("omitted home", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, LocationID: locationID}
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, arcade.LimboRoomID, locationID, 0, arcade.DefaultActor, created, updated)
+
+		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, arcade.LimboRoomID, locationID, 0).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		player, err := p.Update(context.Background(), id, req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if player.HomeID != arcade.LimboRoomID {
+			t.Errorf("Unexpected homeID: %s", player.HomeID)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
 	t.Run("invalid location", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: "42"}
 
@@ -588,9 +898,11 @@ func TestPlayersUpdate(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
 
 		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, homeID, locationID).
+			WithArgs(id, name, description, homeID, locationID, 0).
 			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
 		_, err := p.Update(context.Background(), id, req)
 
@@ -609,14 +921,16 @@ func TestPlayersUpdate(t *testing.T) {
 
 	t.Run("foreign key voilation", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, homeID, locationID).
+			WithArgs(id, name, description, homeID, locationID, 0).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
+		mock.ExpectRollback()
 
 		_, err := p.Update(context.Background(), id, req)
 
@@ -636,14 +950,16 @@ func TestPlayersUpdate(t *testing.T) {
 
 	t.Run("unique violation", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, homeID, locationID).
+			WithArgs(id, name, description, homeID, locationID, 0).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectRollback()
 
 		_, err := p.Update(context.Background(), id, req)
 
@@ -662,14 +978,16 @@ func TestPlayersUpdate(t *testing.T) {
 
 	t.Run("scan error", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated).
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, homeID, locationID).
+			WithArgs(id, name, description, homeID, locationID, 0).
 			WillReturnRows(row)
+		mock.ExpectRollback()
 
 		_, err := p.Update(context.Background(), id, req)
 
@@ -688,13 +1006,15 @@ func TestPlayersUpdate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
-		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "created", "updated"}).
-			AddRow(id, name, description, homeID, locationID, created, updated)
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
 
 		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 0, 0)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, homeID, locationID).
+			WithArgs(id, name, description, homeID, locationID, 0).
 			WillReturnRows(row)
+		mock.ExpectCommit()
 
 		player, err := p.Update(context.Background(), id, req)
 
@@ -713,6 +1033,49 @@ func TestPlayersUpdate(t *testing.T) {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+
+	t.Run("room at capacity", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
+
+		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 2, 2)
+		mock.ExpectRollback()
+
+		_, err := p.Update(context.Background(), id, req)
+
+		if !errors.Is(err, arcade.ErrConflict) {
+			t.Fatalf("Expected a conflict error, actual error: %v", err)
+		}
+		expected := "failed to update player: conflict: room is at capacity: '00000000-0000-0000-0000-000000000001'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("room below capacity", func(t *testing.T) {
+		req := arcade.PlayerRequest{Name: name, Description: description, HomeID: homeID, LocationID: locationID}
+		row := sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, homeID, locationID, 0, arcade.DefaultActor, created, updated)
+
+		p, mock := setupPlayers(t)
+		expectOccupancy(mock, 2, 1)
+		mock.ExpectQuery(updateQ).
+			WithArgs(id, name, description, homeID, locationID, 0).
+			WillReturnRows(row)
+		mock.ExpectCommit()
+
+		if _, err := p.Update(context.Background(), id, req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 }
 
 func TestPlayersRemove(t *testing.T) {
@@ -808,3 +1171,193 @@ func setupPlayers(t *testing.T) (storage.Players, sqlmock.Sqlmock) {
 
 	return storage.Players{DB: db, Driver: cockroach.Driver{}}, mock
 }
+
+func TestPlayersUpdateLastSeen(t *testing.T) {
+	const (
+		updateLastSeenQ = `^UPDATE players SET last_seen = now\(\) WHERE player_id = \$1$`
+	)
+
+	var (
+		id = uuid.NewString()
+	)
+
+	t.Run("invalid player id", func(t *testing.T) {
+		p, _ := setupPlayers(t)
+
+		err := p.UpdateLastSeen(context.Background(), "42")
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update player last seen: invalid argument: invalid player id: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectExec(updateLastSeenQ).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := p.UpdateLastSeen(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update player last seen: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectExec(updateLastSeenQ).
+			WithArgs(id).
+			WillReturnError(errors.New("unknown error"))
+
+		err := p.UpdateLastSeen(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to update player last seen: internal error: unknown error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectExec(updateLastSeenQ).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := p.UpdateLastSeen(context.Background(), id); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestPlayersSpawn(t *testing.T) {
+	const (
+		existsQ      = `^SELECT EXISTS\(SELECT 1 FROM players WHERE player_id = \$1\)$`
+		createRoomQ  = `^INSERT INTO rooms \(name, description, owner_id, parent_id, capacity, created_by\) (.+) RETURNING (.+)$`
+		setLocationQ = `^UPDATE players SET location_id = \$2, updated = now\(\) WHERE player_id = \$1 RETURNING (.+)$`
+	)
+
+	var (
+		id       = uuid.NewString()
+		roomID   = uuid.NewString()
+		ownerID  = "00000000-0000-0000-0000-000000000001"
+		parentID = "00000000-0000-0000-0000-000000000001"
+		homeID   = "00000000-0000-0000-0000-000000000001"
+		created  = time.Now()
+		req      = arcade.RoomRequest{Name: "Den", Description: "A new den.", OwnerID: ownerID, ParentID: parentID}
+	)
+
+	roomRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(roomID, req.Name, req.Description, ownerID, parentID, 0, arcade.DefaultActor, created, created)
+	}
+
+	t.Run("invalid player id", func(t *testing.T) {
+		p, _ := setupPlayers(t)
+
+		_, err := p.Spawn(context.Background(), "42", req)
+
+		expected := "failed to spawn player: invalid argument: invalid player id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("player not found", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		_, err := p.Spawn(context.Background(), id, req)
+
+		expected := "failed to spawn player: not found"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("location update failure", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(createRoomQ).
+			WithArgs(req.Name, req.Description, ownerID, parentID, 0, arcade.DefaultActor).
+			WillReturnRows(roomRow())
+		mock.ExpectQuery(setLocationQ).WithArgs(id, roomID).WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
+
+		_, err := p.Spawn(context.Background(), id, req)
+
+		expected := "failed to spawn player: internal error: update error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		// The room's create is rolled back rather than committed.
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p, mock := setupPlayers(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(createRoomQ).
+			WithArgs(req.Name, req.Description, ownerID, parentID, 0, arcade.DefaultActor).
+			WillReturnRows(roomRow())
+		mock.ExpectQuery(setLocationQ).WithArgs(id, roomID).WillReturnRows(
+			sqlmock.NewRows([]string{"player_id", "name", "description", "home_id", "location_id", "inventory_capacity", "created_by", "created", "updated"}).
+				AddRow(id, "Nobody", "No one of importance.", homeID, roomID, 0, arcade.DefaultActor, created, created),
+		)
+		mock.ExpectCommit()
+
+		rooms := &countingRooms{}
+		p.RoomsCache = storage.NewRoomsCache(rooms, time.Minute)
+		_, _ = p.RoomsCache.List(context.Background(), arcade.RoomsFilter{})
+
+		spawn, err := p.Spawn(context.Background(), id, req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if spawn.Room.ID != roomID || spawn.Room.Name != req.Name || spawn.Player.ID != id || spawn.Player.LocationID != roomID {
+			t.Errorf("Unexpected spawn: %+v", spawn)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+
+		// The spawned room is listed rather than hidden by the cache.
+		_, _ = p.RoomsCache.List(context.Background(), arcade.RoomsFilter{})
+		if rooms.lists != 2 {
+			t.Errorf("Unexpected lists: %d", rooms.lists)
+		}
+	})
+}
@@ -33,6 +33,24 @@ type (
 	Rooms struct {
 		DB     *sql.DB
 		Driver arcade.StorageDriver
+
+		// Names restricts the names of created and updated rooms.
+		Names arcade.NamePolicy
+
+		// Timeouts are the time limits of the room operations.
+		Timeouts Timeouts
+
+		// World limits the number of entities created rooms join.
+		World WorldLimit
+
+		// MaxMergeDependents, when non-zero, is the most items and links a
+		// merge may move or redirect. A larger merge is refused.
+		MaxMergeDependents int
+
+		// ProtectReferenced, when set, refuses to remove a room located in
+		// by items, or located in by or leading to links, unless the remove
+		// cascades.
+		ProtectReferenced bool
 	}
 )
 
@@ -40,10 +58,36 @@ type (
 func (p Rooms) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Room, error) {
 	failMsg := "failed to list rooms"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpList)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).Info("msg", "list rooms")
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.RoomsListQuery(filter)), listArgs(filter.CreatedBy)...)
+}
+
+// GetMany returns the rooms of the given roomIDs which exist, in no
+// particular order.
+func (p Rooms) GetMany(ctx context.Context, roomIDs []string) ([]arcade.Room, error) {
+	failMsg := "failed to get rooms"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	log.LoggerFromContext(ctx).With("roomIDs", len(roomIDs)).Info("msg", "get rooms")
+
+	ids, err := parseIDs("room", roomIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	return p.list(ctx, failMsg, readQuery(ctx, p.Driver, p.Driver.RoomsGetManyQuery()), uuidArray(ids))
+}
+
+func (p Rooms) list(ctx context.Context, failMsg, query string, args ...interface{}) ([]arcade.Room, error) {
 	logger := log.LoggerFromContext(ctx)
-	logger.Info("msg", "list rooms")
 
-	rows, err := p.DB.QueryContext(ctx, p.Driver.RoomsListQuery(filter))
+	rows, err := p.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
@@ -62,6 +106,8 @@ func (p Rooms) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Ro
 			&room.Description,
 			&room.OwnerID,
 			&room.ParentID,
+			&room.Capacity,
+			&room.CreatedBy,
 			&room.Created,
 			&room.Updated,
 		)
@@ -73,6 +119,9 @@ func (p Rooms) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Ro
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
 	}
+	if max := p.Driver.ListRowsCap(); len(rooms) >= max {
+		logger.Warn("msg", "list reached the maximum rows", "max", max)
+	}
 
 	return rooms, nil
 }
@@ -81,6 +130,9 @@ func (p Rooms) List(ctx context.Context, filter arcade.RoomsFilter) ([]arcade.Ro
 func (p Rooms) Get(ctx context.Context, roomID string) (arcade.Room, error) {
 	failMsg := "failed to get room"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("roomID", roomID).Info("msg", "get room")
 
 	pid, err := uuid.Parse(roomID)
@@ -89,12 +141,14 @@ func (p Rooms) Get(ctx context.Context, roomID string) (arcade.Room, error) {
 	}
 
 	var room arcade.Room
-	err = p.DB.QueryRowContext(ctx, p.Driver.RoomsGetQuery(), pid).Scan(
+	err = p.DB.QueryRowContext(ctx, readQuery(ctx, p.Driver, p.Driver.RoomsGetQuery()), pid).Scan(
 		&room.ID,
 		&room.Name,
 		&room.Description,
 		&room.OwnerID,
 		&room.ParentID,
+		&room.Capacity,
+		&room.CreatedBy,
 		&room.Created,
 		&room.Updated,
 	)
@@ -108,10 +162,70 @@ func (p Rooms) Get(ctx context.Context, roomID string) (arcade.Room, error) {
 	return room, nil
 }
 
+// GetByName returns a single room given its name.
+func (p Rooms) GetByName(ctx context.Context, name string) (arcade.Room, error) {
+	failMsg := "failed to get room by name"
+
+	logger := log.LoggerFromContext(ctx).With("name", name)
+	logger.Info("msg", "get room by name")
+
+	if name == "" {
+		return arcade.Room{}, fmt.Errorf("%s: %w: empty room name", failMsg, cerrors.ErrInvalidArgument)
+	}
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.RoomsGetByNameQuery(), name)
+	if err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("msg", "failed to close rows of get by name query", "error", err.Error())
+		}
+	}()
+
+	var rooms []arcade.Room
+	for rows.Next() {
+		var room arcade.Room
+		err := rows.Scan(
+			&room.ID,
+			&room.Name,
+			&room.Description,
+			&room.OwnerID,
+			&room.ParentID,
+			&room.Capacity,
+			&room.CreatedBy,
+			&room.Created,
+			&room.Updated,
+		)
+		if err != nil {
+			return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		rooms = append(rooms, room)
+	}
+	if err := rows.Err(); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	// Room names are not unique, so a name shared by more than one room is
+	// ambiguous, for the client to resolve by id rather than an arbitrary
+	// room being picked. At most two rows are read to tell.
+	switch len(rooms) {
+	case 0:
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	case 1:
+		return rooms[0], nil
+	default:
+		return arcade.Room{}, fmt.Errorf("%s: %w: ambiguous room name, more than one room named '%s'", failMsg, cerrors.ErrInvalidArgument, name)
+	}
+}
+
 // Create a room given the room request, returning the creating room.
 func (p Rooms) Create(ctx context.Context, req arcade.RoomRequest) (arcade.Room, error) {
 	failMsg := "failed to create room"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpCreate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("name", req.Name)
 	logger.Info("msg", "create room")
 
@@ -119,44 +233,64 @@ func (p Rooms) Create(ctx context.Context, req arcade.RoomRequest) (arcade.Room,
 	if err != nil {
 		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.World.check(ctx, p.DB, p.Driver, 1); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	room, err := createRoom(ctx, p.DB, p.Driver, req, ownerID, parentID)
+	if err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	logger.With("roomID", room.ID).Info("msg", "created room")
+	return room, nil
+}
 
+// createRoom inserts the room of the given request with the given database or
+// transaction, returning the created room.
+func createRoom(ctx context.Context, db queryer, driver arcade.StorageDriver, req arcade.RoomRequest, ownerID, parentID uuid.UUID) (arcade.Room, error) {
 	var room arcade.Room
-	err = p.DB.QueryRowContext(ctx, p.Driver.RoomsCreateQuery(),
+	err := db.QueryRowContext(ctx, driver.RoomsCreateQuery(),
 		req.Name,
 		req.Description,
 		ownerID,
 		parentID,
+		req.Capacity,
+		arcade.ActorFromContext(ctx),
 	).Scan(
 		&room.ID,
 		&room.Name,
 		&room.Description,
 		&room.OwnerID,
 		&room.ParentID,
+		&room.Capacity,
+		&room.CreatedBy,
 		&room.Created,
 		&room.Updated,
 	)
 
 	// A ForeignKeyViolation means the referenced ownerID or parentID does not exist
 	// in the rooms table, thus we will return an invalid argument error.
-	if p.Driver.IsForeignKeyViolation(err) {
+	if driver.IsForeignKeyViolation(err) {
 		return arcade.Room{}, fmt.Errorf(
-			"%s: %w: the given ownerID or parentID does not exist: ownerID '%s', parentID '%s'",
-			failMsg, cerrors.ErrInvalidArgument, req.OwnerID, req.ParentID,
+			"%w: the given ownerID or parentID does not exist: ownerID '%s', parentID '%s'",
+			cerrors.ErrInvalidArgument, req.OwnerID, req.ParentID,
 		)
 	}
 
 	// A UniqueViolation means the inserted room violated a uniqueness
 	// constraint. The room record already exists in the table or the name
 	// is not unique.
-	if p.Driver.IsUniqueViolation(err) {
-		return arcade.Room{}, fmt.Errorf("%s: %w: room already exists", failMsg, cerrors.ErrAlreadyExists)
+	if driver.IsUniqueViolation(err) {
+		return arcade.Room{}, fmt.Errorf("%w: room already exists", cerrors.ErrAlreadyExists)
 	}
 
 	if err != nil {
-		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err.Error())
+		return arcade.Room{}, fmt.Errorf("%w: %s", cerrors.ErrInternal, err.Error())
 	}
-
-	logger.With("roomID", room.ID).Info("msg", "created room")
 	return room, nil
 }
 
@@ -164,6 +298,9 @@ func (p Rooms) Create(ctx context.Context, req arcade.RoomRequest) (arcade.Room,
 func (p Rooms) Update(ctx context.Context, roomID string, req arcade.RoomRequest) (arcade.Room, error) {
 	failMsg := "failed to update room"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
 	logger := log.LoggerFromContext(ctx).With("roomID", roomID, "name", req.Name)
 	logger.Info("msg", "update room")
 
@@ -175,6 +312,9 @@ func (p Rooms) Update(ctx context.Context, roomID string, req arcade.RoomRequest
 	if err != nil {
 		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
 	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
 
 	var room arcade.Room
 	err = p.DB.QueryRowContext(ctx, p.Driver.RoomsUpdateQuery(),
@@ -183,12 +323,15 @@ func (p Rooms) Update(ctx context.Context, roomID string, req arcade.RoomRequest
 		req.Description,
 		ownerID,
 		parentID,
+		req.Capacity,
 	).Scan(
 		&room.ID,
 		&room.Name,
 		&room.Description,
 		&room.OwnerID,
 		&room.ParentID,
+		&room.Capacity,
+		&room.CreatedBy,
 		&room.Created,
 		&room.Updated,
 	)
@@ -220,16 +363,107 @@ func (p Rooms) Update(ctx context.Context, roomID string, req arcade.RoomRequest
 	return room, nil
 }
 
-// Remove deletes the given room from persistent storage.
-func (p Rooms) Remove(ctx context.Context, roomID string) error {
+// Rename changes the name of the given room, returning the renamed room. When
+// the name is taken and the request asks for it, the name is suffixed with
+// " (2)", " (3)" and so on, up to arcade.MaxRoomRenameSuffix, until a free
+// name is found.
+func (p Rooms) Rename(ctx context.Context, roomID string, req arcade.RoomRenameRequest) (arcade.Room, error) {
+	failMsg := "failed to rename room"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpUpdate)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("roomID", roomID, "name", req.Name)
+	logger.Info("msg", "rename room")
+
+	pid, err := uuid.Parse(roomID)
+	if err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, roomID)
+	}
+	if err := req.Validate(); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+	if err := p.Names.Validate(req.Name); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback rename", "error", err.Error())
+		}
+	}()
+
+	// Room names are not unique in the schema, so a name is taken when
+	// another room already has it.
+	name := req.Name
+	for suffix := 2; ; suffix++ {
+		var taken bool
+		if err := tx.QueryRowContext(ctx, p.Driver.RoomsNameTakenQuery(), name, pid).Scan(&taken); err != nil {
+			return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		if !taken {
+			break
+		}
+		if !req.AutoSuffix || suffix > arcade.MaxRoomRenameSuffix {
+			return arcade.Room{}, fmt.Errorf("%s: %w: room name is taken", failMsg, cerrors.ErrAlreadyExists)
+		}
+		name = fmt.Sprintf("%s (%d)", req.Name, suffix)
+		if len(name) > p.Names.MaxNameLen(arcade.MaxRoomNameLen) {
+			return arcade.Room{}, fmt.Errorf("%s: %w: room name exceeds maximum length", failMsg, cerrors.ErrInvalidArgument)
+		}
+	}
+
+	var room arcade.Room
+	err = tx.QueryRowContext(ctx, p.Driver.RoomsRenameQuery(), pid, name).Scan(
+		&room.ID,
+		&room.Name,
+		&room.Description,
+		&room.OwnerID,
+		&room.ParentID,
+		&room.Capacity,
+		&room.CreatedBy,
+		&room.Created,
+		&room.Updated,
+	)
+
+	// Tried to rename a room that doesn't exist.
+	if errors.Is(err, sql.ErrNoRows) {
+		return arcade.Room{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return arcade.Room{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	logger.Info("msg", "renamed room", "renamed", room.Name)
+	return room, nil
+}
+
+// Remove deletes the given room from persistent storage. The items and links
+// referencing the room are moved to the default room, which is refused when
+// referenced rooms are protected, unless cascade is set.
+func (p Rooms) Remove(ctx context.Context, roomID string, cascade bool) error {
 	failMsg := "failed to remove room"
 
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpRemove)
+	defer cancel()
+
 	log.LoggerFromContext(ctx).With("roomID", roomID).Info("msg", "remove room")
 
 	pid, err := uuid.Parse(roomID)
 	if err != nil {
 		return fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, roomID)
 	}
+	if p.ProtectReferenced && !cascade {
+		return p.removeUnreferenced(ctx, failMsg, pid)
+	}
 
 	_, err = p.DB.ExecContext(ctx, p.Driver.RoomsRemoveQuery(), pid)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -241,3 +475,276 @@ func (p Rooms) Remove(ctx context.Context, roomID string) error {
 
 	return nil
 }
+
+// removeUnreferenced deletes the given room in a transaction, returning an
+// invalid argument error giving the number of referencing items and links
+// when there are any.
+func (p Rooms) removeUnreferenced(ctx context.Context, failMsg string, roomID uuid.UUID) error {
+	logger := log.LoggerFromContext(ctx)
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback remove", "error", err.Error())
+		}
+	}()
+
+	var locked uuid.UUID
+	err = tx.QueryRowContext(ctx, p.Driver.RoomsLockQuery(), roomID).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	var items, links int
+	if err := tx.QueryRowContext(ctx, p.Driver.RoomsReferencesQuery(), roomID).Scan(&items, &links); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if items > 0 || links > 0 {
+		return fmt.Errorf(
+			"%s: %w: room is referenced by %d items and %d links",
+			failMsg, cerrors.ErrInvalidArgument, items, links,
+		)
+	}
+
+	if _, err := tx.ExecContext(ctx, p.Driver.RoomsRemoveQuery(), roomID); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return nil
+}
+
+// CascadePreview returns the items and links a removal of the given room
+// would move to the default room, without removing it. They are selected
+// with the predicates of the references counted when removing a room.
+func (p Rooms) CascadePreview(ctx context.Context, roomID string) (arcade.RoomCascadePreview, error) {
+	failMsg := "failed to preview room cascade"
+
+	ctx, cancel := p.Timeouts.WithTimeout(ctx, OpGet)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).With("roomID", roomID)
+	logger.Info("msg", "preview room cascade")
+
+	pid, err := uuid.Parse(roomID)
+	if err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, roomID)
+	}
+
+	// The reads share a transaction, so the ids are of a single point in
+	// time.
+	tx, err := p.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback preview", "error", err.Error())
+		}
+	}()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, p.Driver.RoomsExistsQuery(), pid).Scan(&exists); err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if !exists {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w", failMsg, cerrors.ErrNotFound)
+	}
+
+	preview := arcade.RoomCascadePreview{RoomID: pid.String()}
+	if preview.ItemIDs, err = selectIDs(ctx, tx, p.Driver.RoomsCascadeItemsQuery(), pid); err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if preview.LinkIDs, err = selectIDs(ctx, tx, p.Driver.RoomsCascadeLinksQuery(), pid); err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	preview.ItemsCount, preview.LinksCount = len(preview.ItemIDs), len(preview.LinkIDs)
+
+	if err := tx.Commit(); err != nil {
+		return arcade.RoomCascadePreview{}, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return preview, nil
+}
+
+// selectIDs returns the ids selected by the given query.
+func selectIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AddTag adds the tag to the given rooms, returning the number of rooms
+// changed. Rooms that already have the tag are unchanged.
+func (p Rooms) AddTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	failMsg := "failed to add room tag"
+
+	log.LoggerFromContext(ctx).With("tag", tag).Info("msg", "add room tag")
+
+	return p.updateTag(ctx, failMsg, p.Driver.RoomsAddTagQuery(), roomIDs, tag)
+}
+
+// RemoveTag removes the tag from the given rooms, returning the number of
+// rooms changed.
+func (p Rooms) RemoveTag(ctx context.Context, roomIDs []string, tag string) (int, error) {
+	failMsg := "failed to remove room tag"
+
+	log.LoggerFromContext(ctx).With("tag", tag).Info("msg", "remove room tag")
+
+	return p.updateTag(ctx, failMsg, p.Driver.RoomsRemoveTagQuery(), roomIDs, tag)
+}
+
+// Exists returns whether each of the given rooms exists, keyed by the given
+// room id.
+func (p Rooms) Exists(ctx context.Context, roomIDs []string) (map[string]bool, error) {
+	failMsg := "failed to check rooms exist"
+
+	logger := log.LoggerFromContext(ctx)
+	logger.Info("msg", "check rooms exist")
+
+	ids, err := arcade.RoomsExistsRequest{RoomIDs: roomIDs}.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.RoomsExistingQuery(), uuidArray(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("msg", "failed to close rows of exists query", "error", err.Error())
+		}
+	}()
+
+	present := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		present[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	exists := make(map[string]bool, len(roomIDs))
+	for i, id := range ids {
+		exists[roomIDs[i]] = present[id]
+	}
+	return exists, nil
+}
+
+// Merge moves the items and links in a room into another room, and redirects
+// the links to it, optionally removing the merged room, in a single
+// transaction.
+func (p Rooms) Merge(ctx context.Context, roomID, intoID string, remove bool) error {
+	failMsg := "failed to merge room"
+
+	logger := log.LoggerFromContext(ctx).With("roomID", roomID, "intoID", intoID)
+	logger.Info("msg", "merge room")
+
+	from, err := uuid.Parse(roomID)
+	if err != nil {
+		return fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, roomID)
+	}
+	into, err := uuid.Parse(intoID)
+	if err != nil {
+		return fmt.Errorf("%s: %w: invalid room id: '%s'", failMsg, cerrors.ErrInvalidArgument, intoID)
+	}
+	if from == into {
+		return fmt.Errorf("%s: %w: cannot merge a room into itself", failMsg, cerrors.ErrInvalidArgument)
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("msg", "failed to rollback merge", "error", err.Error())
+		}
+	}()
+
+	for _, id := range []uuid.UUID{from, into} {
+		var locked uuid.UUID
+		err := tx.QueryRowContext(ctx, p.Driver.RoomsLockQuery(), id).Scan(&locked)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w: room '%s'", failMsg, cerrors.ErrNotFound, id)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	if p.MaxMergeDependents > 0 {
+		var n int
+		if err := tx.QueryRowContext(ctx, p.Driver.RoomsDependentsQuery(), from).Scan(&n); err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		if n > p.MaxMergeDependents {
+			return fmt.Errorf(
+				"%s: %w: operation affects too many entities (%d > %d)",
+				failMsg, cerrors.ErrInvalidArgument, n, p.MaxMergeDependents,
+			)
+		}
+	}
+
+	for _, query := range []string{
+		p.Driver.ItemsMoveAllQuery(),
+		p.Driver.LinksMoveAllQuery(),
+		p.Driver.LinksRedirectAllQuery(),
+	} {
+		if _, err := tx.ExecContext(ctx, query, from, into); err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	if remove {
+		if _, err := tx.ExecContext(ctx, p.Driver.RoomsRemoveQuery(), from); err != nil {
+			return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return nil
+}
+
+func (p Rooms) updateTag(ctx context.Context, failMsg, query string, roomIDs []string, tag string) (int, error) {
+	ids, err := arcade.RoomsTagRequest{RoomIDs: roomIDs, Tag: tag}.Validate()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", failMsg, err)
+	}
+
+	result, err := p.DB.ExecContext(ctx, query, tag, uuidArray(ids))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+
+	return int(count), nil
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,7 +36,7 @@ import (
 
 func TestRoomsList(t *testing.T) {
 	const (
-		listQ = "^SELECT room_id, name, description, owner_id, parent_id, created, updated FROM rooms$"
+		listQ = "^SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms ORDER BY created ASC LIMIT 10000$"
 	)
 
 	var (
@@ -42,6 +45,7 @@ func TestRoomsList(t *testing.T) {
 		description = "No one of importance."
 		ownerID     = uuid.NewString()
 		parentID    = uuid.NewString()
+		capacity    = 2
 		created     = time.Now()
 		updated     = time.Now()
 	)
@@ -68,9 +72,9 @@ func TestRoomsList(t *testing.T) {
 
 	t.Run("sql scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"room_id", "name", "description", "owner_id", "parent_id", "created", "updated",
+			"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated",
 		}).
-			AddRow(id, name, description, ownerID, parentID, created, updated).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		r, mock := setupRooms(t)
@@ -94,8 +98,8 @@ func TestRoomsList(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		rows := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(listQ).
@@ -124,9 +128,61 @@ func TestRoomsList(t *testing.T) {
 	})
 }
 
+func TestRoomsGetMany(t *testing.T) {
+	const getManyQ = `^SELECT (.+) FROM rooms WHERE room_id = ANY\(\$1\)$`
+
+	var (
+		id      = uuid.NewString()
+		otherID = uuid.NewString()
+		created = time.Now()
+	)
+
+	t.Run("invalid roomID", func(t *testing.T) {
+		l, _ := setupRooms(t)
+
+		_, err := l.GetMany(context.Background(), []string{id, "42"})
+
+		expected := "failed to get rooms: invalid argument: invalid room id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		l, mock := setupRooms(t)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "}").WillReturnError(errors.New("query error"))
+
+		_, err := l.GetMany(context.Background(), []string{id})
+
+		expected := "failed to get rooms: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, mock := setupRooms(t)
+		rows := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, "Hall", "A hall.", uuid.NewString(), uuid.NewString(), 0, arcade.DefaultActor, created, created)
+		mock.ExpectQuery(getManyQ).WithArgs("{" + id + "," + otherID + "}").WillReturnRows(rows)
+
+		rooms, err := l.GetMany(context.Background(), []string{id, otherID})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(rooms) != 1 || rooms[0].ID != id {
+			t.Errorf("Unexpected rooms: %+v", rooms)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
 func TestRoomsGet(t *testing.T) {
 	const (
-		getQ = "^SELECT room_id, name, description, owner_id, parent_id, created, updated FROM rooms WHERE room_id = (.+)$"
+		getQ = "^SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms WHERE room_id = (.+)$"
 	)
 
 	var (
@@ -135,6 +191,7 @@ func TestRoomsGet(t *testing.T) {
 		description = "No one of importance."
 		ownerID     = uuid.NewString()
 		parentID    = uuid.NewString()
+		capacity    = 2
 		created     = time.Now()
 		updated     = time.Now()
 	)
@@ -192,8 +249,8 @@ func TestRoomsGet(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		rows := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(getQ).WillReturnRows(rows)
@@ -217,11 +274,114 @@ func TestRoomsGet(t *testing.T) {
 	})
 }
 
+func TestRoomsGetByName(t *testing.T) {
+	const (
+		getQ = "^SELECT room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated FROM rooms WHERE name = \\$1 LIMIT 2$"
+	)
+
+	var (
+		id          = uuid.NewString()
+		name        = "Nowhere"
+		description = "Nowhere of importance."
+		ownerID     = uuid.NewString()
+		parentID    = uuid.NewString()
+		capacity    = 2
+		created     = time.Now()
+		updated     = time.Now()
+	)
+
+	columns := []string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}
+
+	t.Run("empty name", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.GetByName(context.Background(), "")
+
+		expected := "failed to get room by name: invalid argument: empty room name"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(getQ).WithArgs(name).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := r.GetByName(context.Background(), name)
+
+		expected := "failed to get room by name: not found"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(getQ).WithArgs(name).WillReturnError(errors.New("unknown error"))
+
+		_, err := r.GetByName(context.Background(), name)
+
+		expected := "failed to get room by name: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("multiple rooms", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated).
+			AddRow(uuid.NewString(), name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
+
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(getQ).WithArgs(name).WillReturnRows(rows)
+
+		_, err := r.GetByName(context.Background(), name)
+
+		expected := "failed to get room by name: invalid argument: ambiguous room name, more than one room named 'Nowhere'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
+
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(getQ).WithArgs(name).WillReturnRows(rows)
+
+		room, err := r.GetByName(context.Background(), name)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if room.ID != id || room.Name != name {
+			t.Errorf("\nUnexpected room: %+v", room)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
 func TestRoomsCreate(t *testing.T) {
 	const (
-		createQ = `^INSERT INTO rooms \(name, description, owner_id, parent_id\) ` +
+		createQ = `^INSERT INTO rooms \(name, description, owner_id, parent_id, capacity, created_by\) ` +
 			`VALUES \((.+), (.+), (.+), (.+)\) ` +
-			`RETURNING room_id, name, description, owner_id, parent_id, created, updated$`
+			`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated$`
 	)
 
 	var (
@@ -230,12 +390,13 @@ func TestRoomsCreate(t *testing.T) {
 		description = "No one of importance."
 		ownerID     = "00000000-0000-0000-0000-000000000001"
 		parentID    = "00000000-0000-0000-0000-000000000001"
+		capacity    = 2
 		created     = time.Now()
 		updated     = time.Now()
 	)
 
 	t.Run("empty name", func(t *testing.T) {
-		req := arcade.RoomRequest{Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -250,12 +411,30 @@ func TestRoomsCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("configured max name length", func(t *testing.T) {
+		n := ""
+		for i := 0; i < 13; i++ {
+			n += "a"
+		}
+		req := arcade.RoomRequest{Name: n, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+
+		r, _ := setupRooms(t)
+		r.Names.MaxLen = 12
+
+		_, err := r.Create(context.Background(), req)
+
+		expected := "failed to create room: invalid argument: name exceeds maximum length of 12"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("long name", func(t *testing.T) {
 		n := ""
 		for i := 0; i <= arcade.MaxRoomNameLen; i++ {
 			n += "a"
 		}
-		req := arcade.RoomRequest{Name: n, Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: n, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -270,8 +449,25 @@ func TestRoomsCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("name pattern", func(t *testing.T) {
+		req := arcade.RoomRequest{Name: "nobody", Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+
+		r, _ := setupRooms(t)
+		r.Names = arcade.NamePolicy{Pattern: regexp.MustCompile(`^[A-Z]`)}
+
+		_, err := r.Create(context.Background(), req)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to create room: invalid argument: name does not match required pattern"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
 	t.Run("empty description", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -291,7 +487,7 @@ func TestRoomsCreate(t *testing.T) {
 		for i := 0; i <= arcade.MaxRoomDescriptionLen; i++ {
 			d += "a"
 		}
-		req := arcade.RoomRequest{Name: name, Description: d, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: d, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -307,7 +503,7 @@ func TestRoomsCreate(t *testing.T) {
 	})
 
 	t.Run("invalid ownerID", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: "42", ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: "42", ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -339,13 +535,13 @@ func TestRoomsCreate(t *testing.T) {
 	})
 
 	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, parentID).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
 
@@ -366,13 +562,13 @@ func TestRoomsCreate(t *testing.T) {
 	})
 
 	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, parentID).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 
@@ -392,14 +588,14 @@ func TestRoomsCreate(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated).
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, parentID).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		_, err := r.Create(context.Background(), req)
@@ -418,13 +614,13 @@ func TestRoomsCreate(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(createQ).
-			WithArgs(name, description, ownerID, parentID).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
 			WillReturnRows(row)
 
 		room, err := r.Create(context.Background(), req)
@@ -449,9 +645,9 @@ func TestRoomsCreate(t *testing.T) {
 func TestRoomsUpdate(t *testing.T) {
 	const (
 		// updateQ = `^UPDATE rooms SET (.+) WHERE (.+) RETURNING (.+)$`
-		updateQ = `^UPDATE rooms SET name = (.+), description = (.+), owner_id = (.+), parent_id = (.+) ` +
+		updateQ = `^UPDATE rooms SET name = (.+), description = (.+), owner_id = (.+), parent_id = (.+), capacity = (.+) ` +
 			`WHERE room_id = (.+) ` +
-			`RETURNING room_id, name, description, owner_id, parent_id, created, updated$`
+			`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated$`
 	)
 
 	var (
@@ -460,12 +656,13 @@ func TestRoomsUpdate(t *testing.T) {
 		description = "No one of importance."
 		ownerID     = "00000000-0000-0000-0000-000000000001"
 		parentID    = "00000000-0000-0000-0000-000000000001"
+		capacity    = 2
 		created     = time.Now()
 		updated     = time.Now()
 	)
 
 	t.Run("invalid room id", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -481,7 +678,7 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("empty name", func(t *testing.T) {
-		req := arcade.RoomRequest{Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -501,7 +698,7 @@ func TestRoomsUpdate(t *testing.T) {
 		for i := 0; i <= arcade.MaxRoomNameLen; i++ {
 			n += "a"
 		}
-		req := arcade.RoomRequest{Name: n, Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: n, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -517,7 +714,7 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("empty description", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -537,7 +734,7 @@ func TestRoomsUpdate(t *testing.T) {
 		for i := 0; i <= arcade.MaxRoomDescriptionLen; i++ {
 			d += "a"
 		}
-		req := arcade.RoomRequest{Name: name, Description: d, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: d, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -553,7 +750,7 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("invalid owner", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: "42", ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: "42", ParentID: parentID, Capacity: capacity}
 
 		r, _ := setupRooms(t)
 
@@ -585,11 +782,11 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, parentID).
+			WithArgs(id, name, description, ownerID, parentID, capacity).
 			WillReturnError(sql.ErrNoRows)
 
 		_, err := r.Update(context.Background(), id, req)
@@ -608,13 +805,13 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("foreign key voilation", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, parentID).
+			WithArgs(id, name, description, ownerID, parentID, capacity).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation})
 
@@ -635,13 +832,13 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("unique violation", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, parentID).
+			WithArgs(id, name, description, ownerID, parentID, capacity).
 			WillReturnRows(row).
 			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 
@@ -661,14 +858,14 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated).
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated).
 			RowError(0, errors.New("scan error"))
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, parentID).
+			WithArgs(id, name, description, ownerID, parentID, capacity).
 			WillReturnRows(row)
 
 		_, err := r.Update(context.Background(), id, req)
@@ -687,13 +884,13 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID}
-		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "created", "updated"}).
-			AddRow(id, name, description, ownerID, parentID, created, updated)
+		req := arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+		row := sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, created, updated)
 
 		r, mock := setupRooms(t)
 		mock.ExpectQuery(updateQ).
-			WithArgs(id, name, description, ownerID, parentID).
+			WithArgs(id, name, description, ownerID, parentID, capacity).
 			WillReturnRows(row)
 
 		room, err := r.Update(context.Background(), id, req)
@@ -715,6 +912,87 @@ func TestRoomsUpdate(t *testing.T) {
 	})
 }
 
+func TestRoomsCascadePreview(t *testing.T) {
+	const (
+		existsQ = `^SELECT EXISTS\(SELECT 1 FROM rooms WHERE room_id = \$1\)$`
+		itemsQ  = `^SELECT item_id FROM items WHERE location_id = \$1 ORDER BY item_id$`
+		linksQ  = `^SELECT link_id FROM links WHERE location_id = \$1 OR destination_id = \$1 ORDER BY link_id$`
+	)
+
+	var (
+		id = uuid.NewString()
+	)
+
+	t.Run("invalid room id", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.CascadePreview(context.Background(), "42")
+
+		expected := "failed to preview room cascade: invalid argument: invalid room id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		_, err := r.CascadePreview(context.Background(), id)
+
+		expected := "failed to preview room cascade: not found"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("links error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(itemsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"item_id"}))
+		mock.ExpectQuery(linksQ).WithArgs(id).WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
+
+		_, err := r.CascadePreview(context.Background(), id)
+
+		expected := "failed to preview room cascade: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(existsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(itemsQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"item_id"}).AddRow("item-1"))
+		mock.ExpectQuery(linksQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"link_id"}).AddRow("link-1").AddRow("link-2"))
+		mock.ExpectCommit()
+
+		preview, err := r.CascadePreview(context.Background(), id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if preview.RoomID != id || preview.ItemsCount != 1 || preview.LinksCount != 2 ||
+			preview.ItemIDs[0] != "item-1" || preview.LinkIDs[0] != "link-1" || preview.LinkIDs[1] != "link-2" {
+			t.Errorf("Unexpected preview: %+v", preview)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
 func TestRoomsRemove(t *testing.T) {
 	const (
 		removeQ = `^DELETE FROM rooms WHERE room_id = (.+)$`
@@ -727,7 +1005,7 @@ func TestRoomsRemove(t *testing.T) {
 	t.Run("invalid room id", func(t *testing.T) {
 		r, _ := setupRooms(t)
 
-		err := r.Remove(context.Background(), "42")
+		err := r.Remove(context.Background(), "42", false)
 
 		if err == nil {
 			t.Fatal("Expected an error")
@@ -744,7 +1022,7 @@ func TestRoomsRemove(t *testing.T) {
 			WithArgs(id).
 			WillReturnError(sql.ErrNoRows)
 
-		err := r.Remove(context.Background(), id)
+		err := r.Remove(context.Background(), id, false)
 
 		if err == nil {
 			t.Fatal("Expected an error")
@@ -765,7 +1043,7 @@ func TestRoomsRemove(t *testing.T) {
 			WithArgs(id).
 			WillReturnError(errors.New("unknown error"))
 
-		err := r.Remove(context.Background(), id)
+		err := r.Remove(context.Background(), id, false)
 
 		if err == nil {
 			t.Fatal("Expected an error")
@@ -786,7 +1064,7 @@ func TestRoomsRemove(t *testing.T) {
 			WithArgs(id).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := r.Remove(context.Background(), id)
+		err := r.Remove(context.Background(), id, false)
 
 		if err != nil {
 			t.Fatalf("Unexpected err: %s", err)
@@ -796,6 +1074,562 @@ func TestRoomsRemove(t *testing.T) {
 			t.Errorf("Unexpected err: %s", err)
 		}
 	})
+
+	t.Run("protect referenced", func(t *testing.T) {
+		const (
+			lockQ       = `^SELECT room_id FROM rooms WHERE room_id = \$1 FOR UPDATE$`
+			referencesQ = `^SELECT \(SELECT count\(\*\) FROM items WHERE location_id = \$1\), ` +
+				`\(SELECT count\(\*\) FROM links WHERE location_id = \$1 OR destination_id = \$1\)$`
+		)
+
+		t.Run("not found", func(t *testing.T) {
+			r, mock := setupRooms(t)
+			r.ProtectReferenced = true
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockQ).WithArgs(id).WillReturnError(sql.ErrNoRows)
+			mock.ExpectRollback()
+
+			err := r.Remove(context.Background(), id, false)
+
+			expected := "failed to remove room: not found"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("referenced", func(t *testing.T) {
+			r, mock := setupRooms(t)
+			r.ProtectReferenced = true
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"room_id"}).AddRow(id))
+			mock.ExpectQuery(referencesQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"items", "links"}).AddRow(3, 2))
+			mock.ExpectRollback()
+
+			err := r.Remove(context.Background(), id, false)
+
+			expected := "failed to remove room: invalid argument: room is referenced by 3 items and 2 links"
+			if err == nil || err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("unreferenced", func(t *testing.T) {
+			r, mock := setupRooms(t)
+			r.ProtectReferenced = true
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"room_id"}).AddRow(id))
+			mock.ExpectQuery(referencesQ).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"items", "links"}).AddRow(0, 0))
+			mock.ExpectExec(removeQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			if err := r.Remove(context.Background(), id, false); err != nil {
+				t.Fatalf("Unexpected err: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+
+		t.Run("cascade", func(t *testing.T) {
+			r, mock := setupRooms(t)
+			r.ProtectReferenced = true
+			mock.ExpectExec(removeQ).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := r.Remove(context.Background(), id, true); err != nil {
+				t.Fatalf("Unexpected err: %s", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	})
+}
+
+func TestRoomsExists(t *testing.T) {
+	const (
+		existsQ = `^SELECT room_id FROM rooms WHERE room_id = ANY\((.+)\)$`
+	)
+
+	var (
+		ids = []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+		arg = "{" + strings.Join(ids, ",") + "}"
+	)
+
+	t.Run("invalid room id", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.Exists(context.Background(), []string{ids[0], "42"})
+
+		expected := "failed to check rooms exist: invalid argument: invalid roomID: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(existsQ).WithArgs(arg).WillReturnError(errors.New("unknown error"))
+
+		_, err := r.Exists(context.Background(), ids)
+
+		expected := "failed to check rooms exist: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		// Only the first and last rooms exist.
+		rows := sqlmock.NewRows([]string{"room_id"}).AddRow(ids[0]).AddRow(ids[2])
+
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(existsQ).WithArgs(arg).WillReturnRows(rows)
+
+		exists, err := r.Exists(context.Background(), ids)
+
+		if err != nil {
+			t.Fatalf("Unexpected err: %s", err)
+		}
+		if len(exists) != 3 || !exists[ids[0]] || exists[ids[1]] || !exists[ids[2]] {
+			t.Errorf("Unexpected exists: %v", exists)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestRoomsMerge(t *testing.T) {
+	const (
+		lockQ       = `^SELECT room_id FROM rooms WHERE room_id = \$1 FOR UPDATE$`
+		moveItemsQ  = `^UPDATE items SET location_id = \$2, updated = now\(\) WHERE location_id = \$1$`
+		moveLinksQ  = `^UPDATE links SET location_id = \$2, updated = now\(\) WHERE location_id = \$1$`
+		redirectQ   = `^UPDATE links SET destination_id = \$2, updated = now\(\) WHERE destination_id = \$1$`
+		removeRoomQ = `^DELETE FROM rooms WHERE room_id = \$1$`
+		dependentsQ = `^SELECT \(SELECT count\(\*\) FROM items WHERE location_id = \$1\) \+ ` +
+			`\(SELECT count\(\*\) FROM links WHERE location_id = \$1 OR destination_id = \$1\)$`
+	)
+
+	var (
+		roomID   = uuid.NewString()
+		intoID   = uuid.NewString()
+		lockRows = func(id string) *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"room_id"}).AddRow(id)
+		}
+	)
+
+	t.Run("self merge", func(t *testing.T) {
+		r, mock := setupRooms(t)
+
+		err := r.Merge(context.Background(), roomID, roomID, false)
+
+		expected := "failed to merge room: invalid argument: cannot merge a room into itself"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("invalid room id", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		err := r.Merge(context.Background(), roomID, "42", false)
+
+		expected := "failed to merge room: invalid argument: invalid room id: '42'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("room not found", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQ).WithArgs(roomID).WillReturnRows(lockRows(roomID))
+		mock.ExpectQuery(lockQ).WithArgs(intoID).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := r.Merge(context.Background(), roomID, intoID, false)
+
+		expected := "failed to merge room: not found: room '" + intoID + "'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("links failure rolls back items", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQ).WithArgs(roomID).WillReturnRows(lockRows(roomID))
+		mock.ExpectQuery(lockQ).WithArgs(intoID).WillReturnRows(lockRows(intoID))
+		mock.ExpectExec(moveItemsQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(moveLinksQ).WithArgs(roomID, intoID).WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
+
+		err := r.Merge(context.Background(), roomID, intoID, false)
+
+		expected := "failed to merge room: internal error: unknown error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("too many dependents", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		r.MaxMergeDependents = 5
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQ).WithArgs(roomID).WillReturnRows(lockRows(roomID))
+		mock.ExpectQuery(lockQ).WithArgs(intoID).WillReturnRows(lockRows(intoID))
+		mock.ExpectQuery(dependentsQ).WithArgs(roomID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+		mock.ExpectRollback()
+
+		err := r.Merge(context.Background(), roomID, intoID, false)
+
+		expected := "failed to merge room: invalid argument: operation affects too many entities (6 > 5)"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("dependents within limit", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		r.MaxMergeDependents = 6
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQ).WithArgs(roomID).WillReturnRows(lockRows(roomID))
+		mock.ExpectQuery(lockQ).WithArgs(intoID).WillReturnRows(lockRows(intoID))
+		mock.ExpectQuery(dependentsQ).WithArgs(roomID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+		mock.ExpectExec(moveItemsQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(moveLinksQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(redirectQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := r.Merge(context.Background(), roomID, intoID, false); err != nil {
+			t.Fatalf("Unexpected err: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	for _, remove := range []bool{false, true} {
+		t.Run(fmt.Sprintf("success remove %t", remove), func(t *testing.T) {
+			r, mock := setupRooms(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockQ).WithArgs(roomID).WillReturnRows(lockRows(roomID))
+			mock.ExpectQuery(lockQ).WithArgs(intoID).WillReturnRows(lockRows(intoID))
+			mock.ExpectExec(moveItemsQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 3))
+			mock.ExpectExec(moveLinksQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec(redirectQ).WithArgs(roomID, intoID).WillReturnResult(sqlmock.NewResult(0, 1))
+			if remove {
+				mock.ExpectExec(removeRoomQ).WithArgs(roomID).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			if err := r.Merge(context.Background(), roomID, intoID, remove); err != nil {
+				t.Fatalf("Unexpected err: %s", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected err: %s", err)
+			}
+		})
+	}
+}
+
+func TestRoomsTags(t *testing.T) {
+	const (
+		addTagQ    = `^UPDATE rooms SET tags = array_append\(tags, (.+)\), updated = now\(\) WHERE room_id = ANY\((.+)\) AND NOT \((.+) = ANY\(tags\)\)$`
+		removeTagQ = `^UPDATE rooms SET tags = array_remove\(tags, (.+)\), updated = now\(\) WHERE room_id = ANY\((.+)\) AND (.+) = ANY\(tags\)$`
+	)
+
+	var (
+		tag = "dungeon"
+		ids = []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+		arg = "{" + strings.Join(ids, ",") + "}"
+	)
+
+	t.Run("empty tag", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.AddTag(context.Background(), ids, "")
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to add room tag: invalid argument: empty room tag"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("invalid room id", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.RemoveTag(context.Background(), []string{ids[0], "42"}, tag)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to remove room tag: invalid argument: invalid roomID: '42'"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectExec(addTagQ).
+			WithArgs(tag, arg).
+			WillReturnError(errors.New("unknown error"))
+
+		_, err := r.AddTag(context.Background(), ids, tag)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to add room tag: internal error: unknown error"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("add skips tagged rooms", func(t *testing.T) {
+		// One of the three rooms already has the tag, so only two are changed.
+		r, mock := setupRooms(t)
+		mock.ExpectExec(addTagQ).
+			WithArgs(tag, arg).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		count, err := r.AddTag(context.Background(), ids, tag)
+
+		if err != nil {
+			t.Fatalf("Unexpected err: %s", err)
+		}
+		if count != 2 {
+			t.Errorf("Unexpected count: %d", count)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("remove only matching rooms", func(t *testing.T) {
+		// Only one of the three rooms has the tag, so only one is changed.
+		r, mock := setupRooms(t)
+		mock.ExpectExec(removeTagQ).
+			WithArgs(tag, arg).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		count, err := r.RemoveTag(context.Background(), ids, tag)
+
+		if err != nil {
+			t.Fatalf("Unexpected err: %s", err)
+		}
+		if count != 1 {
+			t.Errorf("Unexpected count: %d", count)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestRoomsRename(t *testing.T) {
+	const (
+		takenQ  = `^SELECT EXISTS\(SELECT 1 FROM rooms WHERE name = \$1 AND room_id != \$2\)$`
+		renameQ = `^UPDATE rooms SET name = \$2, updated = now\(\) WHERE room_id = \$1 RETURNING (.+)$`
+	)
+
+	var (
+		id          = uuid.NewString()
+		description = "A room."
+		ownerID     = uuid.NewString()
+		parentID    = uuid.NewString()
+		created     = time.Now()
+		updated     = time.Now()
+	)
+	taken := func(taken bool) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"exists"}).AddRow(taken)
+	}
+	renamed := func(name string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, 0, arcade.DefaultActor, created, updated)
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		r, _ := setupRooms(t)
+
+		_, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to rename room: invalid argument: empty room name"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnRows(taken(false))
+		mock.ExpectQuery(renameQ).WithArgs(id, "Hall").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall"})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to rename room: not found"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("clean rename", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnRows(taken(false))
+		mock.ExpectQuery(renameQ).WithArgs(id, "Hall").WillReturnRows(renamed("Hall"))
+		mock.ExpectCommit()
+
+		room, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall", AutoSuffix: true})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if room.ID != id || room.Name != "Hall" {
+			t.Errorf("Unexpected room: %+v", room)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnRows(taken(true))
+		mock.ExpectRollback()
+
+		_, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall"})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to rename room: already exists: room name is taken"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("taken query error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnError(errors.New("query error"))
+		mock.ExpectRollback()
+
+		_, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall"})
+
+		expected := "failed to rename room: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("auto suffixed rename", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnRows(taken(true))
+		mock.ExpectQuery(takenQ).WithArgs("Hall (2)", id).WillReturnRows(taken(true))
+		mock.ExpectQuery(takenQ).WithArgs("Hall (3)", id).WillReturnRows(taken(false))
+		mock.ExpectQuery(renameQ).WithArgs(id, "Hall (3)").WillReturnRows(renamed("Hall (3)"))
+		mock.ExpectCommit()
+
+		room, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall", AutoSuffix: true})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if room.Name != "Hall (3)" {
+			t.Errorf("Unexpected name: %s", room.Name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("suffixes exhausted", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(takenQ).WithArgs("Hall", id).WillReturnRows(taken(true))
+		for suffix := 2; suffix <= arcade.MaxRoomRenameSuffix; suffix++ {
+			mock.ExpectQuery(takenQ).WithArgs(fmt.Sprintf("Hall (%d)", suffix), id).WillReturnRows(taken(true))
+		}
+		mock.ExpectRollback()
+
+		_, err := r.Rename(context.Background(), id, arcade.RoomRenameRequest{Name: "Hall", AutoSuffix: true})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		expected := "failed to rename room: already exists: room name is taken"
+		if err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
 }
 
 func setupRooms(t *testing.T) (storage.Rooms, sqlmock.Sqlmock) {
@@ -0,0 +1,30 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+
+	"arcadium.dev/arcade"
+)
+
+// readQuery returns the given list or get query, reading slightly stale data
+// when the context tolerates it.
+func readQuery(ctx context.Context, driver arcade.StorageDriver, query string) string {
+	if arcade.StaleReadsFromContext(ctx) {
+		return driver.StaleReadQuery(query)
+	}
+	return query
+}
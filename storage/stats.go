@@ -0,0 +1,106 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	cerrors "arcadium.dev/core/errors"
+	"arcadium.dev/core/log"
+
+	"arcadium.dev/arcade"
+)
+
+type (
+	// Stats is used to manage the persistent storage of the entity counts
+	// over time.
+	Stats struct {
+		DB     *sql.DB
+		Driver arcade.StorageDriver
+	}
+
+	// Recorder is implemented by Stats.
+	Recorder interface {
+		Record(ctx context.Context) error
+	}
+)
+
+// Record records the current count of each of the stats entities, as of a
+// single point in time.
+func (p Stats) Record(ctx context.Context) error {
+	failMsg := "failed to record stats"
+
+	log.LoggerFromContext(ctx).Info("msg", "record stats")
+
+	if _, err := p.DB.ExecContext(ctx, p.Driver.StatsRecordQuery()); err != nil {
+		return fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	return nil
+}
+
+// History returns the recorded counts of the filtered entity, oldest first.
+func (p Stats) History(ctx context.Context, filter arcade.StatsHistoryFilter) ([]arcade.StatsPoint, error) {
+	failMsg := "failed to read stats history"
+
+	logger := log.LoggerFromContext(ctx).With("entity", filter.Entity)
+	logger.Info("msg", "read stats history")
+
+	rows, err := p.DB.QueryContext(ctx, p.Driver.StatsHistoryQuery(), filter.Entity, filter.Since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	points := make([]arcade.StatsPoint, 0)
+	for rows.Next() {
+		var point arcade.StatsPoint
+		if err := rows.Scan(&point.Count, &point.Recorded); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+		}
+		points = append(points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", failMsg, cerrors.ErrInternal, err)
+	}
+	if max := p.Driver.ListRowsCap(); len(points) >= max {
+		logger.Warn("msg", "stats history reached the maximum rows", "max", max)
+	}
+
+	return points, nil
+}
+
+// RecordStats periodically records the entity counts at the given interval,
+// until the context is cancelled. A failed record is logged, and recording
+// continues.
+func RecordStats(ctx context.Context, stats Recorder, interval time.Duration) {
+	logger := log.LoggerFromContext(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := stats.Record(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("msg", "failed to record stats", "error", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"arcadium.dev/arcade"
+	"arcadium.dev/arcade/storage"
+	"arcadium.dev/arcade/storage/cockroach"
+)
+
+func TestStatsRecord(t *testing.T) {
+	const recordQ = `^INSERT INTO entity_stats \(entity, count\) SELECT 'player', count\(\*\) FROM players UNION ALL (.+) FROM items$`
+
+	t.Run("exec error", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectExec(recordQ).WillReturnError(errors.New("exec error"))
+
+		err := s.Record(context.Background())
+
+		expected := "failed to record stats: internal error: exec error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectExec(recordQ).WillReturnResult(sqlmock.NewResult(0, int64(len(arcade.StatsEntities))))
+
+		if err := s.Record(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
+
+func TestStatsHistory(t *testing.T) {
+	const historyQ = `^SELECT count, recorded FROM entity_stats WHERE entity = \$1 AND recorded >= \$2 ORDER BY recorded ASC LIMIT 10000$`
+
+	var (
+		since    = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+		recorded = since.Add(time.Hour)
+		filter   = arcade.StatsHistoryFilter{Entity: "item", Since: since}
+	)
+
+	t.Run("query error", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectQuery(historyQ).WithArgs("item", since).WillReturnError(errors.New("query error"))
+
+		_, err := s.History(context.Background(), filter)
+
+		expected := "failed to read stats history: internal error: query error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectQuery(historyQ).WithArgs("item", since).WillReturnRows(
+			sqlmock.NewRows([]string{"count", "recorded"}).AddRow("many", recorded),
+		)
+
+		_, err := s.History(context.Background(), filter)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+
+	t.Run("ordered points", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectQuery(historyQ).WithArgs("item", since).WillReturnRows(
+			sqlmock.NewRows([]string{"count", "recorded"}).
+				AddRow(3, recorded).
+				AddRow(5, recorded.Add(time.Hour)).
+				AddRow(4, recorded.Add(2*time.Hour)),
+		)
+
+		points, err := s.History(context.Background(), filter)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(points) != 3 || points[0].Count != 3 || points[1].Count != 5 || points[2].Count != 4 {
+			t.Fatalf("Unexpected points: %+v", points)
+		}
+		for i := 1; i < len(points); i++ {
+			if !points[i-1].Recorded.Before(points[i].Recorded.Time) {
+				t.Errorf("Unexpected order: %+v", points)
+			}
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("no points", func(t *testing.T) {
+		s, mock := setupStats(t)
+		mock.ExpectQuery(historyQ).WithArgs("item", since).WillReturnRows(sqlmock.NewRows([]string{"count", "recorded"}))
+
+		points, err := s.History(context.Background(), filter)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if points == nil || len(points) != 0 {
+			t.Errorf("Unexpected points: %+v", points)
+		}
+	})
+}
+
+type mockRecorder struct {
+	records int32
+}
+
+func (m *mockRecorder) Record(context.Context) error {
+	atomic.AddInt32(&m.records, 1)
+	return nil
+}
+
+func TestRecordStats(t *testing.T) {
+	m := &mockRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		storage.RecordStats(ctx, m, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&m.records) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if atomic.LoadInt32(&m.records) < 2 {
+		t.Fatal("Expected the stats to be recorded")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the recorder to stop on context cancellation")
+	}
+}
+
+func setupStats(t *testing.T) (storage.Stats, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Failed to create sqlmock db")
+	}
+
+	return storage.Stats{DB: db, Driver: cockroach.Driver{}}, mock
+}
@@ -0,0 +1,78 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"time"
+)
+
+// Operation is a kind of storage operation, each with its own timeout.
+type Operation string
+
+const (
+	OpList   Operation = "list"
+	OpGet    Operation = "get"
+	OpCreate Operation = "create"
+	OpUpdate Operation = "update"
+	OpRemove Operation = "remove"
+)
+
+type (
+	// Timeouts are the time limits of storage operations. An operation
+	// without a timeout of its own falls back to the default. The zero
+	// value has no time limits.
+	Timeouts struct {
+		Default time.Duration
+
+		List   time.Duration
+		Get    time.Duration
+		Create time.Duration
+		Update time.Duration
+		Remove time.Duration
+	}
+)
+
+// Timeout returns the time limit of the given operation, zero when it has
+// none.
+func (t Timeouts) Timeout(op Operation) time.Duration {
+	var timeout time.Duration
+	switch op {
+	case OpList:
+		timeout = t.List
+	case OpGet:
+		timeout = t.Get
+	case OpCreate:
+		timeout = t.Create
+	case OpUpdate:
+		timeout = t.Update
+	case OpRemove:
+		timeout = t.Remove
+	}
+	if timeout > 0 {
+		return timeout
+	}
+	return t.Default
+}
+
+// WithTimeout returns a context with the deadline of the given operation, or
+// the given context when the operation has no time limit.
+func (t Timeouts) WithTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	timeout := t.Timeout(op)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,226 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"arcadium.dev/arcade"
+	"arcadium.dev/arcade/storage"
+)
+
+func TestTimeoutsWithTimeout(t *testing.T) {
+	timeouts := storage.Timeouts{
+		Default: time.Minute,
+		List:    time.Hour,
+		Get:     2 * time.Hour,
+		Create:  3 * time.Hour,
+		Update:  4 * time.Hour,
+		Remove:  5 * time.Hour,
+	}
+
+	t.Run("no timeouts", func(t *testing.T) {
+		ctx, cancel := storage.Timeouts{}.WithTimeout(context.Background(), storage.OpList)
+		defer cancel()
+
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("Unexpected deadline")
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		for _, op := range []storage.Operation{storage.OpList, storage.OpGet, storage.OpCreate, storage.OpUpdate, storage.OpRemove} {
+			ctx, cancel := storage.Timeouts{Default: time.Minute}.WithTimeout(context.Background(), op)
+			defer cancel()
+
+			checkDeadline(t, ctx, op, time.Minute)
+		}
+	})
+
+	t.Run("per operation", func(t *testing.T) {
+		tests := []struct {
+			op      storage.Operation
+			timeout time.Duration
+		}{
+			{op: storage.OpList, timeout: time.Hour},
+			{op: storage.OpGet, timeout: 2 * time.Hour},
+			{op: storage.OpCreate, timeout: 3 * time.Hour},
+			{op: storage.OpUpdate, timeout: 4 * time.Hour},
+			{op: storage.OpRemove, timeout: 5 * time.Hour},
+		}
+
+		for _, test := range tests {
+			ctx, cancel := timeouts.WithTimeout(context.Background(), test.op)
+			defer cancel()
+
+			checkDeadline(t, ctx, test.op, test.timeout)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		timeouts := storage.Timeouts{Default: time.Minute, Get: time.Hour}
+
+		ctx, cancel := timeouts.WithTimeout(context.Background(), storage.OpGet)
+		defer cancel()
+		checkDeadline(t, ctx, storage.OpGet, time.Hour)
+
+		ctx, cancel = timeouts.WithTimeout(context.Background(), storage.OpRemove)
+		defer cancel()
+		checkDeadline(t, ctx, storage.OpRemove, time.Minute)
+	})
+}
+
+func TestTimeoutsOperation(t *testing.T) {
+	const (
+		getQ = "^SELECT item_id, name, description, owner_id, location_id, inventory_id, weight, created_by, created, updated FROM items WHERE item_id = (.+)$"
+	)
+
+	id := uuid.NewString()
+
+	t.Run("exceeded", func(t *testing.T) {
+		p, mock := setupItems(t)
+		p.Timeouts = storage.Timeouts{Default: time.Hour, Get: 10 * time.Millisecond}
+
+		mock.ExpectQuery(getQ).WithArgs(id).WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows(nil))
+
+		_, err := p.Get(context.Background(), id)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		if !strings.HasPrefix(err.Error(), "failed to get item: ") || !strings.Contains(err.Error(), "canceling query") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+}
+
+func TestTimeoutsItemsOperations(t *testing.T) {
+	id := uuid.NewString()
+	row := arcade.ItemImportRow{Line: 2, Request: arcade.ItemRequest{
+		Name: "Sword", Description: "A Sword.", OwnerID: id, LocationID: id, InventoryID: id,
+	}}
+
+	query := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("^SELECT (.+)$").WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows(nil))
+	}
+	begin := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin().WillDelayFor(time.Second)
+	}
+
+	tests := []struct {
+		name     string
+		timeouts storage.Timeouts
+		expect   func(sqlmock.Sqlmock)
+		call     func(storage.Items) error
+	}{
+		{
+			name:     "search",
+			timeouts: storage.Timeouts{Default: time.Hour, List: 10 * time.Millisecond},
+			expect:   query,
+			call: func(p storage.Items) error {
+				_, err := p.Search(context.Background(), arcade.ItemsSearchFilter{Query: "sword"})
+				return err
+			},
+		},
+		{
+			name:     "changed since",
+			timeouts: storage.Timeouts{Default: time.Hour, List: 10 * time.Millisecond},
+			expect:   query,
+			call: func(p storage.Items) error {
+				_, err := p.ChangedSince(context.Background(), arcade.Timestamp{}, "", 10)
+				return err
+			},
+		},
+		{
+			name:     "top owners",
+			timeouts: storage.Timeouts{Default: time.Hour, List: 10 * time.Millisecond},
+			expect:   query,
+			call: func(p storage.Items) error {
+				_, err := p.TopOwners(context.Background(), 10)
+				return err
+			},
+		},
+		{
+			name:     "snapshot",
+			timeouts: storage.Timeouts{Default: time.Hour, Get: 10 * time.Millisecond},
+			expect:   query,
+			call: func(p storage.Items) error {
+				_, err := p.Snapshot(context.Background())
+				return err
+			},
+		},
+		{
+			name:     "exists",
+			timeouts: storage.Timeouts{Default: time.Hour, Get: 10 * time.Millisecond},
+			expect:   query,
+			call: func(p storage.Items) error {
+				_, err := p.Exists(context.Background(), id)
+				return err
+			},
+		},
+		{
+			name:     "import",
+			timeouts: storage.Timeouts{Default: time.Hour, Create: 10 * time.Millisecond},
+			expect:   begin,
+			call: func(p storage.Items) error {
+				_, err := p.Import(context.Background(), []arcade.ItemImportRow{row}, false)
+				return err
+			},
+		},
+		{
+			name:     "swap locations",
+			timeouts: storage.Timeouts{Default: time.Hour, Update: 10 * time.Millisecond},
+			expect:   begin,
+			call: func(p storage.Items) error {
+				return p.SwapLocations(context.Background(), id, uuid.NewString())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, mock := setupItems(t)
+			p.Timeouts = test.timeouts
+			test.expect(mock)
+
+			err := test.call(p)
+
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), "canceling query") {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func checkDeadline(t *testing.T, ctx context.Context, op storage.Operation, timeout time.Duration) {
+	t.Helper()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("Expected a deadline for %s", op)
+	}
+	if remaining := time.Until(deadline); remaining > timeout || remaining < timeout-time.Minute/2 {
+		t.Errorf("Unexpected %s deadline: %s remaining, expected %s", op, remaining, timeout)
+	}
+}
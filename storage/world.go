@@ -0,0 +1,48 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage // import "arcadium.dev/arcade/storage"
+
+import (
+	"context"
+	"fmt"
+
+	cerrors "arcadium.dev/core/errors"
+
+	"arcadium.dev/arcade"
+)
+
+type (
+	// WorldLimit caps the number of entities, the players, rooms, links and
+	// items together, a world may hold. The zero value is unlimited.
+	WorldLimit struct {
+		MaxEntities int
+	}
+)
+
+// check returns an invalid argument error if the world cannot hold n more
+// entities. The entities are counted only when the world is limited.
+func (l WorldLimit) check(ctx context.Context, db queryer, driver arcade.StorageDriver, n int) error {
+	if l.MaxEntities <= 0 {
+		return nil
+	}
+	var count int
+	if err := db.QueryRowContext(ctx, driver.WorldEntitiesQuery()).Scan(&count); err != nil {
+		return fmt.Errorf("%w: %s", cerrors.ErrInternal, err)
+	}
+	if count+n > l.MaxEntities {
+		return fmt.Errorf("%w: world entity limit reached", cerrors.ErrInvalidArgument)
+	}
+	return nil
+}
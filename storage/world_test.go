@@ -0,0 +1,119 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"arcadium.dev/arcade"
+	"arcadium.dev/arcade/storage"
+)
+
+func TestWorldLimit(t *testing.T) {
+	const (
+		countQ  = `^SELECT \(SELECT count\(\*\) FROM players\) \+ (.+) \+ \(SELECT count\(\*\) FROM items\)$`
+		createQ = `^INSERT INTO rooms \(name, description, owner_id, parent_id, capacity, created_by\) ` +
+			`VALUES \((.+), (.+), (.+), (.+)\) ` +
+			`RETURNING room_id, name, description, owner_id, parent_id, capacity, created_by, created, updated$`
+	)
+
+	var (
+		id          = uuid.NewString()
+		name        = "Nobody"
+		description = "No one of importance."
+		ownerID     = "00000000-0000-0000-0000-000000000001"
+		parentID    = "00000000-0000-0000-0000-000000000001"
+		capacity    = 2
+		req         = arcade.RoomRequest{Name: name, Description: description, OwnerID: ownerID, ParentID: parentID, Capacity: capacity}
+	)
+
+	newRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"room_id", "name", "description", "owner_id", "parent_id", "capacity", "created_by", "created", "updated"}).
+			AddRow(id, name, description, ownerID, parentID, capacity, arcade.DefaultActor, time.Now(), time.Now())
+	}
+
+	t.Run("count error", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		r.World = storage.WorldLimit{MaxEntities: 10}
+		mock.ExpectQuery(countQ).WillReturnError(errors.New("count error"))
+
+		_, err := r.Create(context.Background(), req)
+
+		expected := "failed to create room: internal error: count error"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		r.World = storage.WorldLimit{MaxEntities: 10}
+		mock.ExpectQuery(countQ).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+		_, err := r.Create(context.Background(), req)
+
+		expected := "failed to create room: invalid argument: world entity limit reached"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected error: %s\nActual error:   %s", expected, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("below limit", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		r.World = storage.WorldLimit{MaxEntities: 10}
+		mock.ExpectQuery(countQ).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(9))
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
+			WillReturnRows(newRow())
+
+		room, err := r.Create(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if room.ID != id {
+			t.Errorf("\nExpected room: %+v", room)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		r, mock := setupRooms(t)
+		mock.ExpectQuery(createQ).
+			WithArgs(name, description, ownerID, parentID, capacity, arcade.DefaultActor).
+			WillReturnRows(newRow())
+
+		if _, err := r.Create(context.Background(), req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unexpected err: %s", err)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade // import "arcadium.dev/arcade"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+var (
+	// TimestampLocation is the time zone timestamps are serialized in,
+	// regardless of the zone they were stored or read in. It is expected
+	// to be set once, at startup.
+	TimestampLocation = time.UTC
+)
+
+type (
+	// Timestamp is a time which is serialized as RFC 3339 in the
+	// TimestampLocation, so clients see consistent offsets.
+	Timestamp struct {
+		time.Time
+	}
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return t.In(TimestampLocation).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting any
+// offset and normalizing it to the TimestampLocation.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if err := t.Time.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	t.Time = t.In(TimestampLocation)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *Timestamp) Scan(src interface{}) error {
+	v, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("unable to scan %T into a timestamp", src)
+	}
+	t.Time = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (t Timestamp) Value() (driver.Value, error) {
+	return t.Time, nil
+}
@@ -0,0 +1,86 @@
+//  Copyright 2022 arcadium.dev <info@arcadium.dev>
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package arcade_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"arcadium.dev/arcade"
+)
+
+func TestTimestamp(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	ts := arcade.Timestamp{Time: time.Date(2022, time.March, 4, 7, 30, 0, 0, est)}
+
+	t.Run("marshal normalizes to utc", func(t *testing.T) {
+		b, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if string(b) != `"2022-03-04T12:30:00Z"` {
+			t.Errorf("Unexpected json: %s", b)
+		}
+	})
+
+	t.Run("marshal to the configured location", func(t *testing.T) {
+		defer func(loc *time.Location) { arcade.TimestampLocation = loc }(arcade.TimestampLocation)
+		arcade.TimestampLocation = time.FixedZone("CET", 60*60)
+
+		b, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if string(b) != `"2022-03-04T13:30:00+01:00"` {
+			t.Errorf("Unexpected json: %s", b)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var actual arcade.Timestamp
+		if err := json.Unmarshal([]byte(`"2022-03-04T07:30:00-05:00"`), &actual); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !actual.Equal(ts.Time) || actual.Location() != time.UTC {
+			t.Errorf("Unexpected timestamp: %s", actual)
+		}
+
+		b, err := json.Marshal(actual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if string(b) != `"2022-03-04T12:30:00Z"` {
+			t.Errorf("Unexpected json: %s", b)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		var actual arcade.Timestamp
+		if err := json.Unmarshal([]byte(`"yesterday"`), &actual); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var actual arcade.Timestamp
+		if err := actual.Scan(ts.Time); err != nil || !actual.Equal(ts.Time) {
+			t.Errorf("Unexpected scan: %s, %s", actual, err)
+		}
+		if err := actual.Scan("yesterday"); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}